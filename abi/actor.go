@@ -33,6 +33,3 @@ func (e MethodNum) String() string {
 // Multiaddrs is a byte array representing a Libp2p MultiAddress
 type Multiaddrs = []byte
 
-// PeerID is a byte array representing a Libp2p PeerID
-type PeerID = []byte
-
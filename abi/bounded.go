@@ -0,0 +1,168 @@
+package abi
+
+import (
+	"fmt"
+	"io"
+	"unicode/utf8"
+
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// Strings and byte arrays decoded from on-chain data are bounded so that hostile data cannot cause large
+// allocations. The length in a CBOR header is checked against the bound before any buffer is allocated.
+
+// Maximum length in bytes of a deal label.
+const MaxLabelLength = 256
+
+// Maximum length in bytes of a miner's libp2p peer ID.
+const MaxPeerIDLength = 128
+
+// Maximum total length in bytes of a miner's multiaddrs.
+const MaxMultiaddrData = 1024
+
+// Maximum length in bytes of the parameters of a proposed multisig transaction.
+const MaxProposalParamsLength = 1 << 20
+
+// Label is a UTF-8 string of at most MaxLabelLength bytes, such as a storage deal label.
+type Label string
+
+var _ cbg.CBORMarshaler = (*Label)(nil)
+var _ cbg.CBORUnmarshaler = (*Label)(nil)
+
+// Validate checks that the label is within the length bound and is valid UTF-8.
+func (l Label) Validate() error {
+	if len(l) > MaxLabelLength {
+		return fmt.Errorf("label length %d exceeds maximum %d", len(l), MaxLabelLength)
+	}
+	if !utf8.ValidString(string(l)) {
+		return fmt.Errorf("label is not valid UTF-8")
+	}
+	return nil
+}
+
+func (l *Label) MarshalCBOR(w io.Writer) error {
+	if err := l.Validate(); err != nil {
+		return err
+	}
+	return WriteBoundedString(w, string(*l), MaxLabelLength)
+}
+
+func (l *Label) UnmarshalCBOR(r io.Reader) error {
+	s, err := ReadBoundedString(r, MaxLabelLength)
+	if err != nil {
+		return err
+	}
+	*l = Label(s)
+	return nil
+}
+
+// CBORMajorType returns the major type of the label's encoding, so that a label can be a union variant.
+func (l *Label) CBORMajorType() byte {
+	return cbg.MajTextString
+}
+
+// LabelBytes is a raw byte label of at most MaxLabelLength bytes, the alternative to a string Label.
+type LabelBytes []byte
+
+var _ cbg.CBORMarshaler = (*LabelBytes)(nil)
+var _ cbg.CBORUnmarshaler = (*LabelBytes)(nil)
+
+// Validate checks that the label is within the length bound.
+func (l LabelBytes) Validate() error {
+	if len(l) > MaxLabelLength {
+		return fmt.Errorf("label length %d exceeds maximum %d", len(l), MaxLabelLength)
+	}
+	return nil
+}
+
+func (l *LabelBytes) MarshalCBOR(w io.Writer) error {
+	if err := l.Validate(); err != nil {
+		return err
+	}
+	return WriteBoundedBytes(w, *l, MaxLabelLength)
+}
+
+func (l *LabelBytes) UnmarshalCBOR(r io.Reader) error {
+	b, err := ReadBoundedBytes(r, MaxLabelLength)
+	if err != nil {
+		return err
+	}
+	*l = b
+	return nil
+}
+
+// CBORMajorType returns the major type of the label's encoding, so that a label can be a union variant.
+func (l *LabelBytes) CBORMajorType() byte {
+	return cbg.MajByteString
+}
+
+// WriteBoundedString writes a CBOR text string, failing if it is longer than maxLen bytes.
+func WriteBoundedString(w io.Writer, s string, maxLen uint64) error {
+	if uint64(len(s)) > maxLen {
+		return fmt.Errorf("string length %d exceeds maximum %d", len(s), maxLen)
+	}
+	if err := cbg.WriteMajorTypeHeaderBuf(make([]byte, 9), w, cbg.MajTextString, uint64(len(s))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+// ReadBoundedString reads a CBOR text string of at most maxLen bytes, which must be valid UTF-8.
+func ReadBoundedString(r io.Reader, maxLen uint64) (string, error) {
+	br := cbg.GetPeeker(r)
+	maj, extra, err := cbg.CborReadHeaderBuf(br, make([]byte, 8))
+	if err != nil {
+		return "", err
+	}
+	if maj != cbg.MajTextString {
+		return "", fmt.Errorf("expected cbor type 'text string' in input")
+	}
+	if extra > maxLen {
+		return "", fmt.Errorf("string length %d exceeds maximum %d", extra, maxLen)
+	}
+	buf := make([]byte, extra)
+	if _, err := io.ReadAtLeast(br, buf, int(extra)); err != nil {
+		return "", err
+	}
+	if !utf8.Valid(buf) {
+		return "", fmt.Errorf("string is not valid UTF-8")
+	}
+	return string(buf), nil
+}
+
+// WriteBoundedBytes writes a CBOR byte string, failing if it is longer than maxLen bytes.
+func WriteBoundedBytes(w io.Writer, b []byte, maxLen uint64) error {
+	if uint64(len(b)) > maxLen {
+		return fmt.Errorf("byte array length %d exceeds maximum %d", len(b), maxLen)
+	}
+	if err := cbg.WriteMajorTypeHeaderBuf(make([]byte, 9), w, cbg.MajByteString, uint64(len(b))); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+// ReadBoundedBytes reads a CBOR byte string of at most maxLen bytes.
+// An empty byte string is read as nil.
+func ReadBoundedBytes(r io.Reader, maxLen uint64) ([]byte, error) {
+	br := cbg.GetPeeker(r)
+	maj, extra, err := cbg.CborReadHeaderBuf(br, make([]byte, 8))
+	if err != nil {
+		return nil, err
+	}
+	if maj != cbg.MajByteString {
+		return nil, fmt.Errorf("expected cbor type 'byte string' in input")
+	}
+	if extra > maxLen {
+		return nil, fmt.Errorf("byte array length %d exceeds maximum %d", extra, maxLen)
+	}
+	if extra == 0 {
+		return nil, nil
+	}
+	buf := make([]byte, extra)
+	if _, err := io.ReadAtLeast(br, buf, int(extra)); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
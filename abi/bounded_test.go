@@ -0,0 +1,44 @@
+package abi_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestLabel(t *testing.T) {
+	l := abi.Label("deal label ✓")
+	var buf bytes.Buffer
+	require.NoError(t, l.MarshalCBOR(&buf))
+
+	var out abi.Label
+	require.NoError(t, out.UnmarshalCBOR(&buf))
+	assert.Equal(t, l, out)
+
+	long := abi.Label(strings.Repeat("a", abi.MaxLabelLength+1))
+	assert.Error(t, long.Validate())
+	assert.Error(t, long.MarshalCBOR(&buf))
+
+	// A hostile header claiming a huge length is rejected before allocation.
+	assert.Error(t, out.UnmarshalCBOR(bytes.NewReader([]byte{0x7b, 0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})))
+	// Invalid UTF-8.
+	assert.Error(t, out.UnmarshalCBOR(bytes.NewReader([]byte{0x61, 0xff})))
+}
+
+func TestBoundedBytes(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, abi.WriteBoundedBytes(&buf, []byte{1, 2, 3}, 3))
+	require.Error(t, abi.WriteBoundedBytes(&buf, []byte{1, 2, 3}, 2))
+
+	b, err := abi.ReadBoundedBytes(bytes.NewReader(buf.Bytes()), 3)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1, 2, 3}, b)
+
+	_, err = abi.ReadBoundedBytes(bytes.NewReader(buf.Bytes()), 2)
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+//go:build go1.18
+
+package abi
+
+import (
+	"reflect"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+// CborEncode returns the CBOR encoding of v.
+func CborEncode[T cbor.Marshaler](v T) ([]byte, error) {
+	return cbor.Marshal(v)
+}
+
+// CborDecode decodes b into a new value of type T, which must implement cbor.Unmarshaler either directly
+// or (more usually) through its pointer type. If T is itself a pointer type, a new value is allocated.
+// The entire input must be consumed.
+func CborDecode[T any](b []byte) (T, error) {
+	var out T
+	u := unmarshalerFor(&out)
+	if u == nil {
+		return out, xerrors.Errorf("cannot decode CBOR into %T", out)
+	}
+
+	rest, err := cbor.Unmarshal(b, u)
+	if err != nil {
+		return out, err
+	}
+	if rest != 0 {
+		return out, xerrors.Errorf("%d trailing bytes after decoding %T", rest, out)
+	}
+	return out, nil
+}
+
+// Returns the unmarshaler for *out, allocating a new value if T is a pointer type, or nil if there is none.
+func unmarshalerFor[T any](out *T) cbor.Unmarshaler {
+	if t := reflect.TypeOf(*out); t != nil && t.Kind() == reflect.Ptr {
+		v := reflect.New(t.Elem())
+		reflect.ValueOf(out).Elem().Set(v)
+		u, _ := v.Interface().(cbor.Unmarshaler)
+		return u
+	}
+	u, _ := any(out).(cbor.Unmarshaler)
+	return u
+}
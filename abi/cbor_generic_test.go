@@ -0,0 +1,34 @@
+//go:build go1.18
+
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestCborEncodeDecode(t *testing.T) {
+	id := &abi.SectorID{Miner: 1000, Number: 42}
+	b, err := abi.CborEncode(id)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x82, 0x19, 0x03, 0xe8, 0x18, 0x2a}, b)
+
+	decoded, err := abi.CborDecode[abi.SectorID](b)
+	require.NoError(t, err)
+	assert.Equal(t, *id, decoded)
+
+	decodedPtr, err := abi.CborDecode[*abi.SectorID](b)
+	require.NoError(t, err)
+	assert.Equal(t, id, decodedPtr)
+
+	_, err = abi.CborDecode[abi.SectorID](append(b, 0x00))
+	assert.Error(t, err)
+	_, err = abi.CborDecode[abi.SectorID](b[:3])
+	assert.Error(t, err)
+	_, err = abi.CborDecode[int](b)
+	assert.Error(t, err)
+}
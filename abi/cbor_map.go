@@ -0,0 +1,133 @@
+//go:build go1.18
+
+package abi
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"unicode/utf8"
+
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+// Maximum number of entries accepted by CborDecodeMap.
+const MaxCborMapEntries = 1 << 16
+
+// Maximum length of a key accepted by CborDecodeMap.
+const maxCborMapKeyLength = 1 << 10
+
+// CborEncodeMap returns the deterministic DAG-CBOR encoding of a map with string keys: a CBOR map with keys
+// sorted by length and then bytewise, so that equal maps always encode (and hash) identically.
+func CborEncodeMap[V cbor.Marshaler](m map[string]V) ([]byte, error) {
+	if len(m) > MaxCborMapEntries {
+		return nil, xerrors.Errorf("map has too many entries (%d)", len(m))
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		if !utf8.ValidString(k) {
+			return nil, xerrors.Errorf("map key %q is not valid UTF-8", k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return cmpCborMapKeys(keys[i], keys[j]) < 0
+	})
+
+	buf := cbor.GetBuffer()
+	defer cbor.PutBuffer(buf)
+	if err := cbg.CborWriteHeader(buf, cbg.MajMap, uint64(len(keys))); err != nil {
+		return nil, err
+	}
+	for _, k := range keys {
+		if err := cbg.CborWriteHeader(buf, cbg.MajTextString, uint64(len(k))); err != nil {
+			return nil, err
+		}
+		buf.WriteString(k)
+		if err := m[k].MarshalCBOR(buf); err != nil {
+			return nil, xerrors.Errorf("failed to encode value for key %q: %w", k, err)
+		}
+	}
+	return append([]byte(nil), buf.Bytes()...), nil
+}
+
+// CborDecodeMap decodes a CBOR map with text string keys into a map, decoding each value as in CborDecode.
+// Keys may appear in any order, but duplicate keys are rejected, as is any input after the map.
+func CborDecodeMap[V any](b []byte) (map[string]V, error) {
+	r := bytes.NewReader(b)
+	maj, n, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	if maj != cbg.MajMap {
+		return nil, xerrors.Errorf("expected cbor map, got major type %d", maj)
+	}
+	if n > MaxCborMapEntries {
+		return nil, xerrors.Errorf("map has too many entries (%d)", n)
+	}
+
+	out := make(map[string]V, n)
+	for i := uint64(0); i < n; i++ {
+		k, err := readCborMapKey(r)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := out[k]; ok {
+			return nil, xerrors.Errorf("duplicate map key %q", k)
+		}
+		var v V
+		u := unmarshalerFor(&v)
+		if u == nil {
+			return nil, xerrors.Errorf("cannot decode CBOR into %T", v)
+		}
+		if err := u.UnmarshalCBOR(r); err != nil {
+			return nil, xerrors.Errorf("failed to decode value for key %q: %w", k, err)
+		}
+		out[k] = v
+	}
+	if r.Len() != 0 {
+		return nil, xerrors.Errorf("%d trailing bytes after map", r.Len())
+	}
+	return out, nil
+}
+
+func readCborMapKey(r *bytes.Reader) (string, error) {
+	maj, n, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return "", err
+	}
+	if maj != cbg.MajTextString {
+		return "", xerrors.Errorf("map keys must be text strings, got major type %d", maj)
+	}
+	if n > maxCborMapKeyLength {
+		return "", xerrors.Errorf("map key too long (%d bytes)", n)
+	}
+	k := make([]byte, n)
+	if _, err := io.ReadFull(r, k); err != nil {
+		return "", err
+	}
+	if !utf8.Valid(k) {
+		return "", xerrors.Errorf("map key %q is not valid UTF-8", k)
+	}
+	return string(k), nil
+}
+
+// Compares map keys in DAG-CBOR order: shorter keys first, then bytewise.
+func cmpCborMapKeys(a, b string) int {
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,43 @@
+//go:build go1.18
+
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+func TestCborEncodeDecodeMap(t *testing.T) {
+	one, two, three := abi.CborUint(1), abi.CborUint(2), abi.CborUint(3)
+	m := map[string]*abi.CborUint{"bb": &one, "a": &two, "ab": &three}
+	b, err := abi.CborEncodeMap(m)
+	require.NoError(t, err)
+	// Keys are sorted by length, then bytewise.
+	assert.Equal(t, []byte{0xa3, 0x61, 'a', 0x02, 0x62, 'a', 'b', 0x03, 0x62, 'b', 'b', 0x01}, b)
+	assert.NoError(t, cbor.IsCanonical(b))
+
+	decoded, err := abi.CborDecodeMap[abi.CborUint](b)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]abi.CborUint{"a": 2, "ab": 3, "bb": 1}, decoded)
+
+	// Keys out of order are accepted, duplicates are not.
+	decoded, err = abi.CborDecodeMap[abi.CborUint]([]byte{0xa2, 0x62, 'b', 'b', 0x01, 0x61, 'a', 0x02})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]abi.CborUint{"a": 2, "bb": 1}, decoded)
+	_, err = abi.CborDecodeMap[abi.CborUint]([]byte{0xa2, 0x61, 'a', 0x01, 0x61, 'a', 0x02})
+	assert.Error(t, err)
+
+	_, err = abi.CborDecodeMap[abi.CborUint](append(b, 0x00))
+	assert.Error(t, err)
+	_, err = abi.CborDecodeMap[abi.CborUint]([]byte{0xa1, 0x01, 0x01})
+	assert.Error(t, err)
+
+	empty, err := abi.CborEncodeMap(map[string]*abi.CborUint{})
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xa0}, empty)
+}
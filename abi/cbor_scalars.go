@@ -0,0 +1,162 @@
+package abi
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// Wrappers for actor methods that take or return a bare scalar, so that callers can decode the value directly
+// rather than declaring a single-field struct (which would encode as a one-element array).
+
+// CborBool is a bool encoding as a CBOR simple value.
+type CborBool bool
+
+// CborUint is a uint64 encoding as a CBOR unsigned integer.
+type CborUint uint64
+
+// CborInt is an int64 encoding as a CBOR unsigned or negative integer.
+type CborInt int64
+
+var _ cbg.CBORMarshaler = (*CborBool)(nil)
+var _ cbg.CBORUnmarshaler = (*CborBool)(nil)
+var _ cbg.CBORMarshaler = (*CborUint)(nil)
+var _ cbg.CBORUnmarshaler = (*CborUint)(nil)
+var _ cbg.CBORMarshaler = (*CborInt)(nil)
+var _ cbg.CBORUnmarshaler = (*CborInt)(nil)
+
+const (
+	cborFalse = 20
+	cborTrue  = 21
+	cborNull  = 22
+)
+
+func (b *CborBool) MarshalCBOR(w io.Writer) error {
+	v := byte(cbg.MajOther<<5 | cborFalse)
+	if *b {
+		v = cbg.MajOther<<5 | cborTrue
+	}
+	_, err := w.Write([]byte{v})
+	return err
+}
+
+func (b *CborBool) UnmarshalCBOR(r io.Reader) error {
+	maj, extra, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajOther {
+		return fmt.Errorf("expected bool, got major type %d", maj)
+	}
+	switch extra {
+	case cborFalse:
+		*b = false
+	case cborTrue:
+		*b = true
+	default:
+		return fmt.Errorf("expected bool, got simple value %d", extra)
+	}
+	return nil
+}
+
+func (u *CborUint) MarshalCBOR(w io.Writer) error {
+	return cbg.CborWriteHeader(w, cbg.MajUnsignedInt, uint64(*u))
+}
+
+func (u *CborUint) UnmarshalCBOR(r io.Reader) error {
+	maj, extra, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajUnsignedInt {
+		return fmt.Errorf("expected unsigned integer, got major type %d", maj)
+	}
+	*u = CborUint(extra)
+	return nil
+}
+
+func (i *CborInt) MarshalCBOR(w io.Writer) error {
+	if *i >= 0 {
+		return cbg.CborWriteHeader(w, cbg.MajUnsignedInt, uint64(*i))
+	}
+	return cbg.CborWriteHeader(w, cbg.MajNegativeInt, uint64(-*i-1))
+}
+
+func (i *CborInt) UnmarshalCBOR(r io.Reader) error {
+	maj, extra, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return err
+	}
+	if extra > math.MaxInt64 {
+		return fmt.Errorf("integer overflows int64")
+	}
+	switch maj {
+	case cbg.MajUnsignedInt:
+		*i = CborInt(extra)
+	case cbg.MajNegativeInt:
+		*i = CborInt(-1 - int64(extra))
+	default:
+		return fmt.Errorf("expected integer, got major type %d", maj)
+	}
+	return nil
+}
+
+// OptionalAggregationProof is an aggregation proof type that may be absent, encoding as CBOR null when it is.
+// It stands in for a *RegisteredAggregationProof field, which cbor-gen cannot encode.
+// The zero value is absent.
+type OptionalAggregationProof struct {
+	proof RegisteredAggregationProof
+	some  bool
+}
+
+var _ cbg.CBORMarshaler = (*OptionalAggregationProof)(nil)
+var _ cbg.CBORUnmarshaler = (*OptionalAggregationProof)(nil)
+
+// SomeAggregationProof returns an OptionalAggregationProof holding p.
+func SomeAggregationProof(p RegisteredAggregationProof) OptionalAggregationProof {
+	return OptionalAggregationProof{proof: p, some: true}
+}
+
+// IsSome returns whether a proof type is present.
+func (o OptionalAggregationProof) IsSome() bool {
+	return o.some
+}
+
+// Get returns the proof type and whether it is present.
+func (o OptionalAggregationProof) Get() (RegisteredAggregationProof, bool) {
+	return o.proof, o.some
+}
+
+func (o *OptionalAggregationProof) MarshalCBOR(w io.Writer) error {
+	if !o.some {
+		_, err := w.Write(cbg.CborNull)
+		return err
+	}
+	i := CborInt(o.proof)
+	return i.MarshalCBOR(w)
+}
+
+func (o *OptionalAggregationProof) UnmarshalCBOR(r io.Reader) error {
+	*o = OptionalAggregationProof{}
+	maj, extra, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return err
+	}
+	if maj == cbg.MajOther && extra == cborNull {
+		return nil
+	}
+	if extra > math.MaxInt64 {
+		return fmt.Errorf("integer overflows int64")
+	}
+	switch maj {
+	case cbg.MajUnsignedInt:
+		*o = SomeAggregationProof(RegisteredAggregationProof(extra))
+	case cbg.MajNegativeInt:
+		*o = SomeAggregationProof(RegisteredAggregationProof(-1 - int64(extra)))
+	default:
+		return fmt.Errorf("expected integer or null, got major type %d", maj)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package abi_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestCborScalars(t *testing.T) {
+	for _, v := range []bool{false, true} {
+		b := abi.CborBool(v)
+		var buf bytes.Buffer
+		require.NoError(t, b.MarshalCBOR(&buf))
+		assert.Len(t, buf.Bytes(), 1)
+		var out abi.CborBool
+		require.NoError(t, out.UnmarshalCBOR(&buf))
+		assert.Equal(t, b, out)
+	}
+
+	for _, v := range []uint64{0, 23, 24, 1 << 40, math.MaxUint64} {
+		u := abi.CborUint(v)
+		var buf bytes.Buffer
+		require.NoError(t, u.MarshalCBOR(&buf))
+		var out abi.CborUint
+		require.NoError(t, out.UnmarshalCBOR(&buf))
+		assert.Equal(t, u, out)
+	}
+
+	for _, v := range []int64{0, 1, -1, -24, -25, math.MaxInt64, math.MinInt64} {
+		i := abi.CborInt(v)
+		var buf bytes.Buffer
+		require.NoError(t, i.MarshalCBOR(&buf))
+		var out abi.CborInt
+		require.NoError(t, out.UnmarshalCBOR(&buf))
+		assert.Equal(t, i, out)
+	}
+	minusOne := abi.CborInt(-1)
+	var buf bytes.Buffer
+	require.NoError(t, minusOne.MarshalCBOR(&buf))
+	assert.Equal(t, []byte{0x20}, buf.Bytes())
+
+	// Type mismatches.
+	var b abi.CborBool
+	assert.Error(t, b.UnmarshalCBOR(bytes.NewReader([]byte{0x01})))
+	assert.Error(t, b.UnmarshalCBOR(bytes.NewReader([]byte{0xf6})))
+	var u abi.CborUint
+	assert.Error(t, u.UnmarshalCBOR(bytes.NewReader([]byte{0x20})))
+	var i abi.CborInt
+	assert.Error(t, i.UnmarshalCBOR(bytes.NewReader([]byte{0xf5})))
+	assert.Error(t, i.UnmarshalCBOR(bytes.NewReader([]byte{0x1b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})))
+}
+
+func TestOptionalAggregationProof(t *testing.T) {
+	var none abi.OptionalAggregationProof
+	assert.False(t, none.IsSome())
+	var buf bytes.Buffer
+	require.NoError(t, none.MarshalCBOR(&buf))
+	assert.Equal(t, []byte{0xf6}, buf.Bytes())
+	out := abi.SomeAggregationProof(abi.RegisteredAggregationProof_SnarkPackV1)
+	require.NoError(t, out.UnmarshalCBOR(&buf))
+	assert.Equal(t, none, out)
+
+	some := abi.SomeAggregationProof(abi.RegisteredAggregationProof_SnarkPackV2)
+	buf.Reset()
+	require.NoError(t, some.MarshalCBOR(&buf))
+	assert.Equal(t, []byte{0x01}, buf.Bytes())
+	require.NoError(t, out.UnmarshalCBOR(&buf))
+	p, ok := out.Get()
+	assert.True(t, ok)
+	assert.Equal(t, abi.RegisteredAggregationProof_SnarkPackV2, p)
+
+	// Neither an integer nor null.
+	assert.Error(t, out.UnmarshalCBOR(bytes.NewReader([]byte{0xf5})))
+	assert.Error(t, out.UnmarshalCBOR(bytes.NewReader([]byte{0x41, 0x01})))
+}
@@ -0,0 +1,162 @@
+package abi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	cid "github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// Size of a sealed (CommR) or unsealed (CommD) sector commitment, in bytes.
+const CommitmentBytesLen = 32
+
+// InvalidCommitmentError reports a CID that is not a well-formed sector commitment.
+type InvalidCommitmentError struct {
+	Cid    cid.Cid
+	Reason string
+}
+
+func (e *InvalidCommitmentError) Error() string {
+	return fmt.Sprintf("invalid commitment CID %s: %s", e.Cid, e.Reason)
+}
+
+// ValidateSealedCID checks that c is a well-formed sealed sector commitment (CommR) for a seal proof type.
+func ValidateSealedCID(c cid.Cid, proof RegisteredSealProof) error {
+	if _, ok := SealProofInfos[proof]; !ok {
+		return &InvalidCommitmentError{Cid: c, Reason: fmt.Sprintf("unsupported seal proof type %d", proof)}
+	}
+	// All current seal proofs commit to the replica with a Poseidon tree.
+	return validateCommitment(c, cid.FilCommitmentSealed, mh.POSEIDON_BLS12_381_A1_FC1)
+}
+
+// ValidateUnsealedCID checks that c is a well-formed unsealed sector or piece commitment (CommD or CommP).
+func ValidateUnsealedCID(c cid.Cid) error {
+	return validateCommitment(c, cid.FilCommitmentUnsealed, mh.SHA2_256_TRUNC254_PADDED)
+}
+
+func validateCommitment(c cid.Cid, codec uint64, hashFn uint64) error {
+	if !c.Defined() {
+		return &InvalidCommitmentError{Cid: c, Reason: "undefined"}
+	}
+	prefix := c.Prefix()
+	if prefix.Version != 1 {
+		return &InvalidCommitmentError{Cid: c, Reason: fmt.Sprintf("expected CID v1, got v%d", prefix.Version)}
+	}
+	if prefix.Codec != codec {
+		return &InvalidCommitmentError{Cid: c, Reason: fmt.Sprintf("expected codec %#x, got %#x", codec, prefix.Codec)}
+	}
+	if prefix.MhType != hashFn {
+		return &InvalidCommitmentError{Cid: c, Reason: fmt.Sprintf("expected multihash %#x, got %#x", hashFn, prefix.MhType)}
+	}
+	if prefix.MhLength != CommitmentBytesLen {
+		return &InvalidCommitmentError{Cid: c, Reason: fmt.Sprintf("expected digest length %d, got %d", CommitmentBytesLen, prefix.MhLength)}
+	}
+	return nil
+}
+
+// Commitment is the raw value of a sector commitment (CommR, CommD or CommC): the digest of its CID, if it
+// has one. It encodes as a byte string in CBOR, and as hex in text.
+type Commitment [CommitmentBytesLen]byte
+
+// CommitmentFromBytes returns the commitment with the raw value b, which must be CommitmentBytesLen long.
+func CommitmentFromBytes(b []byte) (Commitment, error) {
+	var c Commitment
+	if len(b) != CommitmentBytesLen {
+		return c, xerrors.Errorf("commitment must be %d bytes, got %d", CommitmentBytesLen, len(b))
+	}
+	copy(c[:], b)
+	return c, nil
+}
+
+// ParseCommitment parses a commitment from its hex encoding.
+func ParseCommitment(s string) (Commitment, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return Commitment{}, xerrors.Errorf("invalid commitment %q: %w", s, err)
+	}
+	return CommitmentFromBytes(b)
+}
+
+// SealedCommitment returns the raw value of a sealed sector commitment (CommR) CID, checking that it is
+// well-formed for a seal proof type.
+func SealedCommitment(c cid.Cid, proof RegisteredSealProof) (Commitment, error) {
+	if err := ValidateSealedCID(c, proof); err != nil {
+		return Commitment{}, err
+	}
+	return commitmentDigest(c), nil
+}
+
+// UnsealedCommitment returns the raw value of an unsealed sector or piece commitment (CommD or CommP) CID,
+// checking that it is well-formed.
+func UnsealedCommitment(c cid.Cid) (Commitment, error) {
+	if err := ValidateUnsealedCID(c); err != nil {
+		return Commitment{}, err
+	}
+	return commitmentDigest(c), nil
+}
+
+// Returns the digest of a validated commitment CID: the last bytes of its multihash, after the prefix.
+func commitmentDigest(c cid.Cid) Commitment {
+	var d Commitment
+	h := c.Hash()
+	copy(d[:], h[len(h)-CommitmentBytesLen:])
+	return d
+}
+
+// SealedCID returns the CID of the commitment as a sealed sector commitment (CommR).
+func (c Commitment) SealedCID() cid.Cid {
+	return c.cid(cid.FilCommitmentSealed, mh.POSEIDON_BLS12_381_A1_FC1)
+}
+
+// UnsealedCID returns the CID of the commitment as an unsealed sector or piece commitment (CommD or CommP).
+func (c Commitment) UnsealedCID() cid.Cid {
+	return c.cid(cid.FilCommitmentUnsealed, mh.SHA2_256_TRUNC254_PADDED)
+}
+
+func (c Commitment) cid(codec uint64, hashFn uint64) cid.Cid {
+	// Encoding fails only for an unknown hash function.
+	h, err := mh.Encode(c[:], hashFn)
+	if err != nil {
+		panic(err)
+	}
+	return cid.NewCidV1(codec, h)
+}
+
+// Bytes returns a copy of the raw value of the commitment.
+func (c Commitment) Bytes() []byte {
+	return append([]byte(nil), c[:]...)
+}
+
+func (c Commitment) String() string {
+	return hex.EncodeToString(c[:])
+}
+
+func (c Commitment) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Commitment) UnmarshalText(text []byte) (err error) {
+	*c, err = ParseCommitment(string(text))
+	return err
+}
+
+func (c Commitment) MarshalCBOR(w io.Writer) error {
+	if err := cbg.CborWriteHeader(w, cbg.MajByteString, uint64(len(c))); err != nil {
+		return err
+	}
+	_, err := w.Write(c[:])
+	return err
+}
+
+func (c *Commitment) UnmarshalCBOR(r io.Reader) error {
+	b, err := cbg.ReadByteArray(r, CommitmentBytesLen)
+	if err != nil {
+		return xerrors.Errorf("failed to read commitment: %w", err)
+	}
+	*c, err = CommitmentFromBytes(b)
+	return err
+}
@@ -0,0 +1,95 @@
+package abi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestValidateCommitments(t *testing.T) {
+	commit := func(codec, hashFn uint64, length int) cid.Cid {
+		hash, err := mh.Encode(make([]byte, length), hashFn)
+		require.NoError(t, err)
+		return cid.NewCidV1(codec, hash)
+	}
+	proof := abi.RegisteredSealProof_StackedDrg32GiBV1
+	sealed := commit(cid.FilCommitmentSealed, mh.POSEIDON_BLS12_381_A1_FC1, 32)
+	unsealed := commit(cid.FilCommitmentUnsealed, mh.SHA2_256_TRUNC254_PADDED, 32)
+
+	assert.NoError(t, abi.ValidateSealedCID(sealed, proof))
+	assert.NoError(t, abi.ValidateUnsealedCID(unsealed))
+	zero, err := abi.ZeroPieceCommitment(2048)
+	require.NoError(t, err)
+	assert.NoError(t, abi.ValidateUnsealedCID(zero))
+
+	invalid := []error{
+		abi.ValidateSealedCID(sealed, abi.RegisteredSealProof(-1)),
+		abi.ValidateSealedCID(unsealed, proof),
+		abi.ValidateSealedCID(cid.Undef, proof),
+		abi.ValidateSealedCID(commit(cid.FilCommitmentSealed, mh.SHA2_256_TRUNC254_PADDED, 32), proof),
+		abi.ValidateSealedCID(commit(cid.FilCommitmentSealed, mh.POSEIDON_BLS12_381_A1_FC1, 31), proof),
+		abi.ValidateUnsealedCID(sealed),
+		abi.ValidateUnsealedCID(commit(cid.FilCommitmentUnsealed, mh.SHA2_256_TRUNC254_PADDED, 64)),
+	}
+	for _, err := range invalid {
+		var target *abi.InvalidCommitmentError
+		assert.True(t, xerrors.As(err, &target), "%v", err)
+	}
+}
+
+func TestCommitment(t *testing.T) {
+	var raw [32]byte
+	for i := range raw {
+		raw[i] = byte(i)
+	}
+	c, err := abi.CommitmentFromBytes(raw[:])
+	require.NoError(t, err)
+	assert.Equal(t, raw[:], c.Bytes())
+	_, err = abi.CommitmentFromBytes(raw[:31])
+	assert.Error(t, err)
+
+	// Round trips through CIDs of either kind.
+	sealed, err := abi.SealedCommitment(c.SealedCID(), abi.RegisteredSealProof_StackedDrg32GiBV1)
+	require.NoError(t, err)
+	assert.Equal(t, c, sealed)
+	unsealed, err := abi.UnsealedCommitment(c.UnsealedCID())
+	require.NoError(t, err)
+	assert.Equal(t, c, unsealed)
+	_, err = abi.UnsealedCommitment(c.SealedCID())
+	assert.Error(t, err)
+	_, err = abi.SealedCommitment(c.UnsealedCID(), abi.RegisteredSealProof_StackedDrg32GiBV1)
+	assert.Error(t, err)
+
+	// Hex text.
+	assert.Equal(t, "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f", c.String())
+	parsed, err := abi.ParseCommitment(c.String())
+	require.NoError(t, err)
+	assert.Equal(t, c, parsed)
+	for _, s := range []string{"zz", "0001", ""} {
+		_, err := abi.ParseCommitment(s)
+		assert.Error(t, err, s)
+	}
+	j, err := json.Marshal(c)
+	require.NoError(t, err)
+	assert.Equal(t, `"`+c.String()+`"`, string(j))
+	var fromJSON abi.Commitment
+	require.NoError(t, json.Unmarshal(j, &fromJSON))
+	assert.Equal(t, c, fromJSON)
+
+	// CBOR byte string.
+	var buf bytes.Buffer
+	require.NoError(t, c.MarshalCBOR(&buf))
+	assert.Equal(t, append([]byte{0x58, 32}, raw[:]...), buf.Bytes())
+	var out abi.Commitment
+	require.NoError(t, out.UnmarshalCBOR(&buf))
+	assert.Equal(t, c, out)
+	assert.Error(t, out.UnmarshalCBOR(bytes.NewReader([]byte{0x41, 0})))
+}
@@ -0,0 +1,65 @@
+package abi
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// Compact keys encode identifiers as fixed-width big-endian integers, so that the byte order of keys matches
+// the numeric order of identifiers. They suit off-chain indexes and analytics databases, which can then scan
+// ranges of keys. On-chain state uses the varint keys of IntKey and UIntKey, and must not change.
+
+// The length of a compact actor ID key.
+const CompactActorIDKeyLength = 8
+
+// The length of a compact sector ID key.
+const CompactSectorIDKeyLength = 16
+
+// Adapts an actor ID as a compact mapping key.
+type CompactActorIDKey ActorID
+
+func (k CompactActorIDKey) Key() string {
+	return string(AppendCompactActorIDKey(make([]byte, 0, CompactActorIDKeyLength), ActorID(k)))
+}
+
+// Adapts a sector ID as a compact mapping key, ordered by miner and then sector number.
+type CompactSectorIDKey SectorID
+
+func (k CompactSectorIDKey) Key() string {
+	return string(AppendCompactSectorIDKey(make([]byte, 0, CompactSectorIDKeyLength), SectorID(k)))
+}
+
+// AppendCompactActorIDKey appends the compact key of an actor ID to b.
+func AppendCompactActorIDKey(b []byte, id ActorID) []byte {
+	var buf [CompactActorIDKeyLength]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(id))
+	return append(b, buf[:]...)
+}
+
+// AppendCompactSectorIDKey appends the compact key of a sector ID to b.
+func AppendCompactSectorIDKey(b []byte, id SectorID) []byte {
+	b = AppendCompactActorIDKey(b, id.Miner)
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(id.Number))
+	return append(b, buf[:]...)
+}
+
+// ParseCompactActorIDKey decodes a compact actor ID key.
+func ParseCompactActorIDKey(k string) (ActorID, error) {
+	if len(k) != CompactActorIDKeyLength {
+		return 0, errors.New("compact actor ID key must be 8 bytes")
+	}
+	return ActorID(binary.BigEndian.Uint64([]byte(k))), nil
+}
+
+// ParseCompactSectorIDKey decodes a compact sector ID key.
+func ParseCompactSectorIDKey(k string) (SectorID, error) {
+	if len(k) != CompactSectorIDKeyLength {
+		return SectorID{}, errors.New("compact sector ID key must be 16 bytes")
+	}
+	b := []byte(k)
+	return SectorID{
+		Miner:  ActorID(binary.BigEndian.Uint64(b[:8])),
+		Number: SectorNumber(binary.BigEndian.Uint64(b[8:])),
+	}, nil
+}
@@ -0,0 +1,71 @@
+package abi_test
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestCompactKeys(t *testing.T) {
+	assert.Equal(t, "\x00\x00\x00\x00\x00\x00\x03\xe8", abi.CompactActorIDKey(1000).Key())
+	id, err := abi.ParseCompactActorIDKey(abi.CompactActorIDKey(1000).Key())
+	require.NoError(t, err)
+	assert.Equal(t, abi.ActorID(1000), id)
+	_, err = abi.ParseCompactActorIDKey(abi.UIntKey(1000).Key())
+	assert.Error(t, err)
+
+	sid := abi.SectorID{Miner: 1000, Number: 7}
+	sector, err := abi.ParseCompactSectorIDKey(abi.CompactSectorIDKey(sid).Key())
+	require.NoError(t, err)
+	assert.Equal(t, sid, sector)
+	_, err = abi.ParseCompactSectorIDKey("short")
+	assert.Error(t, err)
+
+	// Keys sort in the order of the identifiers, unlike varint keys.
+	ids := []abi.SectorID{{Miner: 1000, Number: 300}, {Miner: 1000, Number: 2}, {Miner: 2, Number: 1 << 40}, {Miner: 1000, Number: 128}}
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = abi.CompactSectorIDKey(id).Key()
+	}
+	sort.Strings(keys)
+	var sorted []abi.SectorID
+	for _, k := range keys {
+		id, err := abi.ParseCompactSectorIDKey(k)
+		require.NoError(t, err)
+		sorted = append(sorted, id)
+	}
+	assert.Equal(t, []abi.SectorID{{Miner: 2, Number: 1 << 40}, {Miner: 1000, Number: 2}, {Miner: 1000, Number: 128}, {Miner: 1000, Number: 300}}, sorted)
+}
+
+func BenchmarkCompactActorIDKey(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = abi.CompactActorIDKey(i).Key()
+	}
+}
+
+func BenchmarkUIntKey(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = abi.UIntKey(uint64(i)).Key()
+	}
+}
+
+func BenchmarkAddrKey(b *testing.B) {
+	a := newIDAddr(b, 1000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = abi.AddrKey(a).Key()
+	}
+}
+
+func BenchmarkCompactSectorIDKey(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = abi.CompactSectorIDKey{Miner: 1000, Number: abi.SectorNumber(i)}.Key()
+	}
+}
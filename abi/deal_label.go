@@ -0,0 +1,23 @@
+//go:build go1.18
+
+package abi
+
+import (
+	"github.com/filecoin-project/go-state-types/typegen"
+)
+
+// DealLabel is a storage deal label, either a UTF-8 string or raw bytes.
+// It is encoded as a CBOR text or byte string respectively.
+type DealLabel = typegen.Union[Label, LabelBytes]
+
+// NewStringDealLabel returns a deal label holding a string, checking it is valid.
+func NewStringDealLabel(s string) (DealLabel, error) {
+	l := typegen.UnionA[Label, LabelBytes](Label(s))
+	return l, l.Validate()
+}
+
+// NewBytesDealLabel returns a deal label holding bytes, checking it is valid.
+func NewBytesDealLabel(b []byte) (DealLabel, error) {
+	l := typegen.UnionB[Label, LabelBytes](LabelBytes(b))
+	return l, l.Validate()
+}
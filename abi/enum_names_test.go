@@ -0,0 +1,25 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+func TestEnumNames(t *testing.T) {
+	assert.Equal(t, "StackedDrg32GiBV2", abi.RegisteredSealProof_StackedDrg32GiBV2.String())
+	assert.Equal(t, "StackedDrg64GiBV1_2_Feat_NiPoRep", abi.RegisteredSealProof_StackedDrg64GiBV1_2_Feat_NiPoRep.String())
+	assert.Equal(t, "RegisteredSealProof(99)", abi.RegisteredSealProof(99).String())
+	assert.Equal(t, "StackedDrgWindow32GiBV2", abi.RegisteredPoStProof_StackedDrgWindow32GiBV2.String())
+	assert.Equal(t, "SnarkPackV2", abi.RegisteredAggregationProof_SnarkPackV2.String())
+	assert.Equal(t, "StackedDrg32GiBV1", abi.RegisteredUpdateProof_StackedDrg32GiBV1.String())
+	assert.Equal(t, "BLS", crypto.SigTypeBLS.String())
+	assert.Equal(t, "SigType(7)", crypto.SigType(7).String())
+
+	// Names appear in formatted errors in place of numbers.
+	_, err := abi.RegisteredSealProof(99).SectorSize()
+	assert.EqualError(t, err, "unsupported proof type: RegisteredSealProof(99)")
+}
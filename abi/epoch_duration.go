@@ -0,0 +1,60 @@
+package abi
+
+import (
+	"strconv"
+	"time"
+)
+
+// The duration of a chain epoch, in seconds.
+const EpochDurationSeconds = 30
+
+// EpochDuration is a length of chain time in epochs, as distinct from a ChainEpoch, which is a point in
+// chain time. Being distinct types, a duration cannot be mistaken for an epoch (or vice versa) without an
+// explicit conversion: an epoch plus a duration is an epoch, and the difference of two epochs is a duration.
+type EpochDuration int64
+
+// Standard durations.
+const (
+	EpochDurationHour = EpochDuration(60 * 60 / EpochDurationSeconds)
+	EpochDurationDay  = 24 * EpochDurationHour
+	EpochDurationYear = 365 * EpochDurationDay
+)
+
+func (d EpochDuration) String() string {
+	return strconv.FormatInt(int64(d), 10)
+}
+
+// Seconds returns the duration in seconds.
+func (d EpochDuration) Seconds() int64 {
+	return int64(d) * EpochDurationSeconds
+}
+
+// Duration returns the duration in wall-clock time.
+func (d EpochDuration) Duration() time.Duration {
+	return time.Duration(d.Seconds()) * time.Second
+}
+
+// Mul returns the duration multiplied by n.
+func (d EpochDuration) Mul(n int64) EpochDuration {
+	return d * EpochDuration(n)
+}
+
+// Add returns the epoch a duration after e.
+func (e ChainEpoch) Add(d EpochDuration) ChainEpoch {
+	return e + ChainEpoch(d)
+}
+
+// Sub returns the epoch a duration before e.
+func (e ChainEpoch) Sub(d EpochDuration) ChainEpoch {
+	return e - ChainEpoch(d)
+}
+
+// Since returns the duration from an earlier epoch to e, which is negative if the epoch is later.
+func (e ChainEpoch) Since(earlier ChainEpoch) EpochDuration {
+	return EpochDuration(e - earlier)
+}
+
+// Until returns the duration from e to a later epoch, which is negative if the epoch is earlier.
+func (e ChainEpoch) Until(later ChainEpoch) EpochDuration {
+	return EpochDuration(later - e)
+}
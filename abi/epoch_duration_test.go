@@ -0,0 +1,30 @@
+package abi_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestEpochDuration(t *testing.T) {
+	assert.Equal(t, abi.EpochDuration(120), abi.EpochDurationHour)
+	assert.Equal(t, abi.EpochDuration(2880), abi.EpochDurationDay)
+	assert.Equal(t, abi.EpochDuration(1051200), abi.EpochDurationYear)
+	assert.Equal(t, int64(86400), abi.EpochDurationDay.Seconds())
+	assert.Equal(t, 24*time.Hour, abi.EpochDurationDay.Duration())
+	assert.Equal(t, abi.EpochDurationDay*180, abi.EpochDurationDay.Mul(180))
+	assert.Equal(t, "2880", abi.EpochDurationDay.String())
+}
+
+func TestChainEpochArithmetic(t *testing.T) {
+	e := abi.ChainEpoch(1000)
+	assert.Equal(t, abi.ChainEpoch(1120), e.Add(abi.EpochDurationHour))
+	assert.Equal(t, abi.ChainEpoch(880), e.Sub(abi.EpochDurationHour))
+	assert.Equal(t, abi.EpochDuration(400), e.Since(600))
+	assert.Equal(t, abi.EpochDuration(-400), abi.ChainEpoch(600).Since(e))
+	assert.Equal(t, abi.EpochDuration(400), abi.ChainEpoch(600).Until(e))
+	assert.Equal(t, e, e.Add(e.Until(5000)).Sub(abi.ChainEpoch(5000).Since(e)))
+}
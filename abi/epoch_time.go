@@ -0,0 +1,56 @@
+package abi
+
+import (
+	"time"
+)
+
+// Genesis timestamps (Unix seconds) of the networks with a known genesis.
+// A devnet's genesis is whenever it was started, so it has none.
+var genesisTimestamps = map[NetworkPreset]uint64{
+	PresetMainnet:  1598306400, // 2020-08-24T22:00:00Z
+	PresetCalibnet: 1667326380, // 2022-11-01T18:13:00Z
+}
+
+// GenesisTimestamp returns the genesis timestamp of a network preset, in Unix seconds, if known.
+func GenesisTimestamp(preset NetworkPreset) (uint64, bool) {
+	ts, ok := genesisTimestamps[preset]
+	return ts, ok
+}
+
+// EpochToTime returns the time at which an epoch begins, given the genesis timestamp (in Unix seconds) and the
+// block delay (in seconds) of the network. This is the timestamp of the epoch's tipset, if it is not null.
+func EpochToTime(epoch ChainEpoch, genesisTimestamp uint64, blockDelay uint64) time.Time {
+	return time.Unix(int64(genesisTimestamp)+int64(epoch)*int64(blockDelay), 0).UTC()
+}
+
+// TimeToEpoch returns the epoch in progress at a time: the latest epoch beginning no later than the time.
+// This is the inverse of EpochToTime for the times at which epochs begin. Times before genesis have negative
+// epochs. The block delay must be positive.
+func TimeToEpoch(t time.Time, genesisTimestamp uint64, blockDelay uint64) ChainEpoch {
+	since := t.Unix() - int64(genesisTimestamp)
+	epoch := since / int64(blockDelay)
+	if since%int64(blockDelay) < 0 {
+		epoch-- // round towards negative infinity
+	}
+	return ChainEpoch(epoch)
+}
+
+// EpochToTime returns the time at which an epoch begins on the network, which must have a known genesis
+// and epochs of EpochDurationSeconds.
+func (p NetworkPreset) EpochToTime(epoch ChainEpoch) (time.Time, bool) {
+	genesis, ok := GenesisTimestamp(p)
+	if !ok {
+		return time.Time{}, false
+	}
+	return EpochToTime(epoch, genesis, EpochDurationSeconds), true
+}
+
+// TimeToEpoch returns the epoch in progress at a time on the network, which must have a known genesis
+// and epochs of EpochDurationSeconds.
+func (p NetworkPreset) TimeToEpoch(t time.Time) (ChainEpoch, bool) {
+	genesis, ok := GenesisTimestamp(p)
+	if !ok {
+		return 0, false
+	}
+	return TimeToEpoch(t, genesis, EpochDurationSeconds), true
+}
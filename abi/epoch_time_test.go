@@ -0,0 +1,42 @@
+package abi_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestEpochToTime(t *testing.T) {
+	genesis := uint64(1000)
+	assert.Equal(t, time.Unix(1000, 0).UTC(), abi.EpochToTime(0, genesis, 30))
+	assert.Equal(t, time.Unix(1300, 0).UTC(), abi.EpochToTime(10, genesis, 30))
+	assert.Equal(t, time.Unix(970, 0).UTC(), abi.EpochToTime(-1, genesis, 30))
+
+	for _, epoch := range []abi.ChainEpoch{-2, -1, 0, 1, 1234567} {
+		start := abi.EpochToTime(epoch, genesis, 30)
+		assert.Equal(t, epoch, abi.TimeToEpoch(start, genesis, 30))
+		assert.Equal(t, epoch, abi.TimeToEpoch(start.Add(29*time.Second), genesis, 30))
+		assert.Equal(t, epoch-1, abi.TimeToEpoch(start.Add(-time.Second), genesis, 30))
+	}
+}
+
+func TestPresetEpochToTime(t *testing.T) {
+	// Mainnet genesis was at 22:00 UTC on 24 August 2020.
+	ts, ok := abi.PresetMainnet.EpochToTime(abi.ChainEpoch(abi.EpochDurationDay))
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2020, 8, 25, 22, 0, 0, 0, time.UTC), ts)
+	epoch, ok := abi.PresetMainnet.TimeToEpoch(ts.Add(time.Minute))
+	require.True(t, ok)
+	assert.Equal(t, abi.ChainEpoch(2882), epoch)
+
+	_, ok = abi.GenesisTimestamp(abi.PresetCalibnet)
+	assert.True(t, ok)
+	_, ok = abi.PresetDevnet.EpochToTime(0)
+	assert.False(t, ok)
+	_, ok = abi.PresetDevnet.TimeToEpoch(time.Now())
+	assert.False(t, ok)
+}
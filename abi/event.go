@@ -0,0 +1,80 @@
+package abi
+
+import (
+	"context"
+
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/adt"
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+// Flags of an event entry, indicating which of its parts clients should index.
+const (
+	EventFlagIndexedKey   = 1 << 0
+	EventFlagIndexedValue = 1 << 1
+	EventFlagIndexedAll   = EventFlagIndexedKey | EventFlagIndexedValue
+)
+
+// Event is an event emitted by an actor during message execution, stamped with the emitter's ID.
+// Events are recorded, in order of emission, in an AMT whose root is in the message receipt.
+type Event struct {
+	// The ID of the actor that emitted the event.
+	Emitter ActorID
+	// Key-value entries of the event.
+	Entries []EventEntry
+}
+
+// EventEntry is a key-value entry of an event.
+type EventEntry struct {
+	// Indexing hints (EventFlag*).
+	Flags uint64
+	// The key of the entry.
+	Key string
+	// The IPLD codec of the value.
+	Codec uint64
+	// The encoded value.
+	Value []byte
+}
+
+// Bit width of the events AMT in a message receipt.
+const EventsAmtBitwidth = 5
+
+// DecodeEvents loads the events AMT with some root, returning the events in order of emission.
+func DecodeEvents(ctx context.Context, store ipldcbor.IpldStore, root cid.Cid) ([]Event, error) {
+	a, err := adt.LoadAmt(ctx, store, root)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to load events AMT: %w", err)
+	}
+	if a.BitWidth != EventsAmtBitwidth {
+		return nil, xerrors.Errorf("events AMT root %s: unexpected bit width %d", root, a.BitWidth)
+	}
+
+	events := make([]Event, 0, minUint64(a.Count, 1<<EventsAmtBitwidth))
+	if err := a.ForEach(ctx, func(i uint64, v []byte) error {
+		if i != uint64(len(events)) {
+			return xerrors.Errorf("missing event %d", len(events))
+		}
+		var e Event
+		if _, err := cbor.Unmarshal(v, &e); err != nil {
+			return xerrors.Errorf("failed to decode event %d: %w", i, err)
+		}
+		events = append(events, e)
+		return nil
+	}); err != nil {
+		return nil, xerrors.Errorf("events AMT root %s: %w", root, err)
+	}
+	if uint64(len(events)) != a.Count {
+		return nil, xerrors.Errorf("events AMT root %s: expected %d events, found %d", root, a.Count, len(events))
+	}
+	return events, nil
+}
+
+func minUint64(a, b uint64) uint64 {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,66 @@
+package abi_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+	events := make([]abi.Event, 40)
+	for i := range events {
+		events[i] = abi.Event{
+			Emitter: abi.ActorID(1000 + i),
+			Entries: []abi.EventEntry{{Flags: abi.EventFlagIndexedAll, Key: "t1", Codec: 0x55, Value: []byte{byte(i)}}},
+		}
+	}
+
+	// A single leaf.
+	leaf := eventsNode(t, events[:3], nil)
+	root, err := store.PutRaw(ipld.AmtRoot(abi.EventsAmtBitwidth, 0, 3, leaf))
+	require.NoError(t, err)
+	decoded, err := abi.DecodeEvents(ctx, store, root)
+	require.NoError(t, err)
+	assert.Equal(t, events[:3], decoded)
+
+	// Two full levels: a root with two children holding 32 and 8 events.
+	c1, err := store.PutRaw(eventsNode(t, events[:32], nil))
+	require.NoError(t, err)
+	c2, err := store.PutRaw(eventsNode(t, events[32:], nil))
+	require.NoError(t, err)
+	root, err = store.PutRaw(ipld.AmtRoot(abi.EventsAmtBitwidth, 1, 40, eventsNode(t, nil, []cid.Cid{c1, c2})))
+	require.NoError(t, err)
+	decoded, err = abi.DecodeEvents(ctx, store, root)
+	require.NoError(t, err)
+	assert.Equal(t, events, decoded)
+
+	// Count mismatch.
+	root, err = store.PutRaw(ipld.AmtRoot(abi.EventsAmtBitwidth, 0, 4, leaf))
+	require.NoError(t, err)
+	_, err = abi.DecodeEvents(ctx, store, root)
+	assert.Error(t, err)
+}
+
+// Encodes a node with the leading slots occupied by either values or links.
+func eventsNode(t *testing.T, values []abi.Event, links []cid.Cid) []byte {
+	var slots []uint64
+	for i := 0; i < len(values)+len(links); i++ {
+		slots = append(slots, uint64(i))
+	}
+	encoded := make([][]byte, len(values))
+	for i := range values {
+		var buf bytes.Buffer
+		require.NoError(t, values[i].MarshalCBOR(&buf))
+		encoded[i] = buf.Bytes()
+	}
+	return ipld.AmtNode(ipld.AmtBitmap(1<<abi.EventsAmtBitwidth, slots...), links, encoded...)
+}
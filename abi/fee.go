@@ -0,0 +1,66 @@
+package abi
+
+import (
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+// Parameters of the base fee adjustment (an EIP-1559-style fee market).
+// These must match the values used in consensus, otherwise fee estimates will diverge from the chain.
+
+// The maximum total gas limit of messages included in a block.
+const BlockGasLimit = 10_000_000_000
+
+// The gas usage per block at which the base fee is unchanged.
+const BlockGasTarget = BlockGasLimit / 2
+
+// The base fee changes by at most 1/BaseFeeMaxChangeDenom (12.5%) from one epoch to the next.
+const BaseFeeMaxChangeDenom = 8
+
+// The base fee at genesis.
+const InitialBaseFee = 100e6
+
+// The base fee never falls below this value.
+const MinimumBaseFee = 100
+
+// Prior to network version 2, gas usage was scaled by the inverse of this packing efficiency
+// (PackingEfficiencyNum/PackingEfficiencyDenom) before comparison with the target.
+const (
+	PackingEfficiencyNum   = 4
+	PackingEfficiencyDenom = 5
+)
+
+// ComputeNextBaseFee returns the base fee for the epoch following one with the given base fee, in which
+// noOfBlocks blocks included messages with a total gas limit of gasLimitUsed.
+// The network version is that in effect at the epoch for which the base fee is computed.
+// An epoch with no blocks (a null round) is treated as one in which no gas was used.
+func ComputeNextBaseFee(baseFee TokenAmount, gasLimitUsed int64, noOfBlocks int, nv network.Version) TokenAmount {
+	var delta int64
+	switch {
+	case noOfBlocks <= 0:
+		// No gas used.
+	case nv >= network.Version2:
+		delta = gasLimitUsed / int64(noOfBlocks)
+	default:
+		delta = PackingEfficiencyDenom * gasLimitUsed / (int64(noOfBlocks) * PackingEfficiencyNum)
+	}
+	delta -= BlockGasTarget
+
+	// Cap the change at 1/BaseFeeMaxChangeDenom by capping delta.
+	if delta > BlockGasTarget {
+		delta = BlockGasTarget
+	}
+	if delta < -BlockGasTarget {
+		delta = -BlockGasTarget
+	}
+
+	change := big.Mul(baseFee, big.NewInt(delta))
+	change = big.Div(change, big.NewInt(BlockGasTarget))
+	change = big.Div(change, big.NewInt(BaseFeeMaxChangeDenom))
+
+	nextBaseFee := big.Add(baseFee, change)
+	if big.Cmp(nextBaseFee, big.NewInt(MinimumBaseFee)) < 0 {
+		nextBaseFee = big.NewInt(MinimumBaseFee)
+	}
+	return nextBaseFee
+}
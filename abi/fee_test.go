@@ -0,0 +1,42 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestComputeNextBaseFee(t *testing.T) {
+	tests := []struct {
+		baseFee   int64
+		gasUsed   int64
+		noOfBlock int
+		nv        network.Version
+		expected  int64
+	}{
+		{100e6, 0, 1, network.Version2, 87.5e6},
+		{100e6, 0, 5, network.Version2, 87.5e6},
+		{100e6, abi.BlockGasTarget, 1, network.Version2, 100e6},
+		{100e6, abi.BlockGasTarget * 2, 2, network.Version2, 100e6},
+		{100e6, abi.BlockGasLimit * 2, 2, network.Version2, 112.5e6},
+		{100e6, abi.BlockGasLimit * 1.5, 2, network.Version2, 106.25e6},
+		// Packing efficiency scaling prior to version 2.
+		{100e6, abi.BlockGasTarget * 4 / 5, 1, network.Version1, 100e6},
+		{100e6, abi.BlockGasLimit, 1, network.Version1, 112.5e6},
+		// Floor.
+		{100, 0, 1, network.Version2, abi.MinimumBaseFee},
+		// Null rounds use no gas.
+		{100e6, 0, 0, network.Version2, 87.5e6},
+		{100e6, abi.BlockGasLimit, 0, network.Version2, 87.5e6},
+		{100e6, 0, -1, network.Version1, 87.5e6},
+	}
+
+	for _, test := range tests {
+		next := abi.ComputeNextBaseFee(big.NewInt(test.baseFee), test.gasUsed, test.noOfBlock, test.nv)
+		assert.Equal(t, big.NewInt(test.expected), next, "%+v", test)
+	}
+}
@@ -0,0 +1,69 @@
+package abi
+
+import (
+	"bytes"
+
+	addr "github.com/filecoin-project/go-address"
+	cid "github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+// The only supported message version.
+const MessageVersion = 0
+
+// Message is an unsigned message, transferring value and/or invoking a method of the receiving actor.
+type Message struct {
+	Version uint64
+
+	To   addr.Address
+	From addr.Address
+
+	// Sequence number of the message from the sender, which must match the sender's nonce.
+	Nonce uint64
+
+	Value TokenAmount
+
+	GasLimit   int64
+	GasFeeCap  TokenAmount
+	GasPremium TokenAmount
+
+	Method MethodNum
+	Params []byte
+}
+
+// Serialize returns the CBOR encoding of the message.
+func (m *Message) Serialize() ([]byte, error) {
+	return cbor.Marshal(m)
+}
+
+// Cid returns the CID of the message: that of its CBOR encoding with the default CID builder.
+func (m *Message) Cid() (cid.Cid, error) {
+	data, err := m.Serialize()
+	if err != nil {
+		return cid.Undef, err
+	}
+	return CidBuilder.Sum(data)
+}
+
+// SigningBytes returns the bytes signed by the sender to authorize the message, which are the bytes of its CID.
+func (m *Message) SigningBytes() ([]byte, error) {
+	c, err := m.Cid()
+	if err != nil {
+		return nil, err
+	}
+	return c.Bytes(), nil
+}
+
+// DecodeMessage decodes a message, rejecting unsupported message versions.
+func DecodeMessage(b []byte) (*Message, error) {
+	var msg Message
+	if err := msg.UnmarshalCBOR(bytes.NewReader(b)); err != nil {
+		return nil, err
+	}
+	if msg.Version != MessageVersion {
+		return nil, xerrors.Errorf("decoded message had incorrect version (%d)", msg.Version)
+	}
+	return &msg, nil
+}
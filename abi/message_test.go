@@ -0,0 +1,58 @@
+package abi_test
+
+import (
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+func testMessage(t *testing.T) *abi.Message {
+	to, err := addr.NewIDAddress(100)
+	require.NoError(t, err)
+	from, err := addr.NewIDAddress(101)
+	require.NoError(t, err)
+	return &abi.Message{
+		Version:    abi.MessageVersion,
+		To:         to,
+		From:       from,
+		Nonce:      7,
+		Value:      big.NewInt(1000),
+		GasLimit:   1_000_000,
+		GasFeeCap:  big.NewInt(200),
+		GasPremium: big.NewInt(100),
+		Method:     2,
+		Params:     []byte{0x80},
+	}
+}
+
+func TestMessageCid(t *testing.T) {
+	msg := testMessage(t)
+	b, err := msg.Serialize()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x8a, 0x00, 0x42, 0x00, 0x64, 0x42, 0x00, 0x65, 0x07}, b[:9])
+
+	c, err := msg.Cid()
+	require.NoError(t, err)
+	expected, err := abi.CidBuilder.Sum(b)
+	require.NoError(t, err)
+	assert.Equal(t, expected, c)
+
+	signing, err := msg.SigningBytes()
+	require.NoError(t, err)
+	assert.Equal(t, c.Bytes(), signing)
+
+	decoded, err := abi.DecodeMessage(b)
+	require.NoError(t, err)
+	assert.Equal(t, msg, decoded)
+
+	msg.Version = 1
+	b, err = msg.Serialize()
+	require.NoError(t, err)
+	_, err = abi.DecodeMessage(b)
+	assert.Error(t, err)
+}
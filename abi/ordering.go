@@ -0,0 +1,162 @@
+package abi
+
+import (
+	"sort"
+
+	"golang.org/x/xerrors"
+)
+
+// Iteration order over Go maps is randomised, and iteration order over HAMTs depends on key hashes.
+// Neither may influence the result of a state computation (or of a migration or diff that produces state),
+// since that leads to nondeterministic state roots. The collections below gather the keys of such a mapping
+// so that they can subsequently be visited in ascending order.
+
+// KeySet collects the string keys of a mapping for visiting in ascending (byte-wise) order.
+// Add has the signature of a HAMT iteration callback, so a KeySet may be populated directly by ForEach.
+// The key buffer is retained across Reset so that a KeySet may be reused for many mappings.
+type KeySet struct {
+	keys   []string
+	sorted bool
+}
+
+// NewKeySet returns an empty set with capacity for sizeHint keys.
+func NewKeySet(sizeHint int) *KeySet {
+	return &KeySet{keys: make([]string, 0, sizeHint), sorted: true}
+}
+
+// Add appends a key to the set. Keys are expected to be unique, as they are in a mapping.
+// The error return is always nil.
+func (s *KeySet) Add(k string) error {
+	s.keys = append(s.keys, k)
+	s.sorted = false
+	return nil
+}
+
+// Len returns the number of keys collected.
+func (s *KeySet) Len() int {
+	return len(s.keys)
+}
+
+// Keys returns the collected keys in ascending order.
+// The returned slice is owned by the set and is invalidated by Add or Reset.
+func (s *KeySet) Keys() []string {
+	if !s.sorted {
+		sort.Strings(s.keys)
+		s.sorted = true
+	}
+	return s.keys
+}
+
+// ForEach calls cb for each collected key in ascending order, stopping at the first error.
+func (s *KeySet) ForEach(cb func(k string) error) error {
+	for _, k := range s.Keys() {
+		if err := cb(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset empties the set, retaining its buffer.
+func (s *KeySet) Reset() {
+	s.keys = s.keys[:0]
+	s.sorted = true
+}
+
+// IndexSet collects the integer indices of an array (such as an AMT) or integer-keyed mapping for
+// visiting in ascending order.
+// Add has the signature of an AMT iteration callback, so an IndexSet may be populated directly by ForEach.
+type IndexSet struct {
+	indices []uint64
+	sorted  bool
+}
+
+// NewIndexSet returns an empty set with capacity for sizeHint indices.
+func NewIndexSet(sizeHint int) *IndexSet {
+	return &IndexSet{indices: make([]uint64, 0, sizeHint), sorted: true}
+}
+
+// Add appends an index to the set. Array indices are never negative, so a negative index is an error.
+func (s *IndexSet) Add(i int64) error {
+	if i < 0 {
+		return xerrors.Errorf("negative index %d", i)
+	}
+	s.indices = append(s.indices, uint64(i))
+	s.sorted = false
+	return nil
+}
+
+// AddUint appends an index to the set.
+func (s *IndexSet) AddUint(i uint64) {
+	s.indices = append(s.indices, i)
+	s.sorted = false
+}
+
+// Len returns the number of indices collected.
+func (s *IndexSet) Len() int {
+	return len(s.indices)
+}
+
+// Indices returns the collected indices in ascending order.
+// The returned slice is owned by the set and is invalidated by Add or Reset.
+func (s *IndexSet) Indices() []uint64 {
+	if !s.sorted {
+		sort.Slice(s.indices, func(i, j int) bool { return s.indices[i] < s.indices[j] })
+		s.sorted = true
+	}
+	return s.indices
+}
+
+// ForEach calls cb for each collected index in ascending order, stopping at the first error.
+func (s *IndexSet) ForEach(cb func(i uint64) error) error {
+	for _, i := range s.Indices() {
+		if err := cb(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reset empties the set, retaining its buffer.
+func (s *IndexSet) Reset() {
+	s.indices = s.indices[:0]
+	s.sorted = true
+}
+
+// SortActorIDs sorts a slice of actor IDs in ascending order, in place.
+// This is intended for the keys of a Go map collected prior to iteration.
+func SortActorIDs(ids []ActorID) {
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+}
+
+// SortSectorNumbers sorts a slice of sector numbers in ascending order, in place.
+func SortSectorNumbers(nums []SectorNumber) {
+	sort.Slice(nums, func(i, j int) bool { return nums[i] < nums[j] })
+}
+
+// SortChainEpochs sorts a slice of epochs in ascending order, in place.
+func SortChainEpochs(epochs []ChainEpoch) {
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+}
+
+// SortKeyers sorts a slice of mapping keys by their encoded key string, in place.
+// This is the order in which a KeySet would visit the same keys.
+func SortKeyers(keys []Keyer) {
+	encoded := make([]string, len(keys))
+	for i, k := range keys {
+		encoded[i] = k.Key()
+	}
+	sort.Sort(keyerSorter{keys: keys, encoded: encoded})
+}
+
+type keyerSorter struct {
+	keys    []Keyer
+	encoded []string
+}
+
+func (s keyerSorter) Len() int           { return len(s.keys) }
+func (s keyerSorter) Less(i, j int) bool { return s.encoded[i] < s.encoded[j] }
+func (s keyerSorter) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.encoded[i], s.encoded[j] = s.encoded[j], s.encoded[i]
+}
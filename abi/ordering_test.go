@@ -0,0 +1,52 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestKeySet(t *testing.T) {
+	s := abi.NewKeySet(4)
+	for _, k := range []string{"c", "a", "\x00\x66", "b", "\x00\x65"} {
+		require.NoError(t, s.Add(k))
+	}
+	assert.Equal(t, 5, s.Len())
+	assert.Equal(t, []string{"\x00\x65", "\x00\x66", "a", "b", "c"}, s.Keys())
+
+	var visited []string
+	require.NoError(t, s.ForEach(func(k string) error {
+		visited = append(visited, k)
+		return nil
+	}))
+	assert.Equal(t, s.Keys(), visited)
+
+	s.Reset()
+	assert.Equal(t, 0, s.Len())
+	require.NoError(t, s.Add("z"))
+	assert.Equal(t, []string{"z"}, s.Keys())
+}
+
+func TestIndexSet(t *testing.T) {
+	s := abi.NewIndexSet(0)
+	require.NoError(t, s.Add(7))
+	require.NoError(t, s.Add(0))
+	require.Error(t, s.Add(-1))
+	s.AddUint(1 << 40)
+	s.AddUint(3)
+	assert.Equal(t, []uint64{0, 3, 7, 1 << 40}, s.Indices())
+}
+
+func TestSortKeyers(t *testing.T) {
+	keys := []abi.Keyer{abi.UIntKey(300), abi.UIntKey(1), abi.UIntKey(2)}
+	abi.SortKeyers(keys)
+	// Varint encoding of 300 begins with 0xac, so sorts after single-byte encodings.
+	assert.Equal(t, []abi.Keyer{abi.UIntKey(1), abi.UIntKey(2), abi.UIntKey(300)}, keys)
+
+	ids := []abi.ActorID{5, 1, 3}
+	abi.SortActorIDs(ids)
+	assert.Equal(t, []abi.ActorID{1, 3, 5}, ids)
+}
@@ -0,0 +1,99 @@
+package abi
+
+import (
+	"io"
+
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+// The maximum size of an encoded message accepted by node message pools. The parameters of a message
+// can be no larger.
+const MessageSizeLimit = 64 << 10
+
+// The maximum size of message parameters from each network version, in order of version.
+var paramsSizeLimits = []struct {
+	from  network.Version
+	limit int
+}{
+	{network.Version0, MessageSizeLimit},
+}
+
+// MaxParamsSize returns the maximum size of message parameters at a network version.
+func MaxParamsSize(nv network.Version) int {
+	limit := paramsSizeLimits[0].limit
+	for _, l := range paramsSizeLimits {
+		if l.from > nv {
+			break
+		}
+		limit = l.limit
+	}
+	return limit
+}
+
+// The largest limit at any network version, enforced when encoding and decoding Params.
+var maxParamsSizeAnyVersion = func() int {
+	max := 0
+	for _, l := range paramsSizeLimits {
+		if l.limit > max {
+			max = l.limit
+		}
+	}
+	return max
+}()
+
+// Params are the raw (usually CBOR-encoded) parameters of a message, encoded as a CBOR byte string.
+// Encoding and decoding reject parameters larger than allowed at any network version; use NewParams or
+// Validate to check the limit at a particular network version.
+type Params []byte
+
+// NewParams returns params holding b, if within the limit at a network version.
+func NewParams(nv network.Version, b []byte) (Params, error) {
+	p := Params(b)
+	if err := p.Validate(nv); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Validate checks that the params are within the size limit at a network version.
+func (p Params) Validate(nv network.Version) error {
+	if limit := MaxParamsSize(nv); len(p) > limit {
+		return xerrors.Errorf("params of %d bytes exceed the limit of %d bytes at network version %v", len(p), limit, nv)
+	}
+	return nil
+}
+
+// MarshalCBOR writes the params as a byte string.
+func (p Params) MarshalCBOR(w io.Writer) error {
+	if len(p) > maxParamsSizeAnyVersion {
+		return xerrors.Errorf("params of %d bytes exceed the limit of %d bytes", len(p), maxParamsSizeAnyVersion)
+	}
+	if err := cbg.CborWriteHeader(w, cbg.MajByteString, uint64(len(p))); err != nil {
+		return err
+	}
+	_, err := w.Write(p)
+	return err
+}
+
+// UnmarshalCBOR reads the params from a byte string, checking its length before reading it.
+func (p *Params) UnmarshalCBOR(r io.Reader) error {
+	maj, n, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajByteString {
+		return xerrors.Errorf("expected byte string for params, got major type %d", maj)
+	}
+	if n > uint64(maxParamsSizeAnyVersion) {
+		return xerrors.Errorf("params of %d bytes exceed the limit of %d bytes", n, maxParamsSizeAnyVersion)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return err
+	}
+	*p = b
+	return nil
+}
@@ -0,0 +1,40 @@
+package abi_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestParams(t *testing.T) {
+	assert.Equal(t, abi.MessageSizeLimit, abi.MaxParamsSize(network.VersionLatest))
+
+	p, err := abi.NewParams(network.VersionLatest, []byte{1, 2, 3})
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, p.MarshalCBOR(&buf))
+	assert.Equal(t, []byte{0x43, 1, 2, 3}, buf.Bytes())
+	var out abi.Params
+	require.NoError(t, out.UnmarshalCBOR(&buf))
+	assert.Equal(t, p, out)
+
+	large := make([]byte, abi.MessageSizeLimit+1)
+	_, err = abi.NewParams(network.VersionLatest, large)
+	assert.Error(t, err)
+	assert.Error(t, abi.Params(large).MarshalCBOR(&buf))
+
+	// The length is checked before reading the data.
+	buf.Reset()
+	require.NoError(t, cbg.CborWriteHeader(&buf, cbg.MajByteString, 1<<40))
+	assert.Error(t, out.UnmarshalCBOR(&buf))
+
+	buf.Reset()
+	buf.WriteByte(0x60)
+	assert.Error(t, out.UnmarshalCBOR(&buf))
+}
@@ -0,0 +1,100 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	gobig "math/big"
+	"strings"
+
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// Errors classifying the failures of NewTokenAmountFromString and NewStoragePowerFromString.
+var (
+	ErrAmountSyntax = errors.New("invalid syntax")
+	ErrAmountSign   = errors.New("sign not allowed")
+	ErrAmountRange  = errors.New("value out of range")
+)
+
+// ParseAmountError reports a string that could not be parsed as a token amount or storage power.
+// Its cause is one of ErrAmountSyntax, ErrAmountSign or ErrAmountRange.
+type ParseAmountError struct {
+	// Kind of value parsed, for the error message.
+	Kind  string
+	Input string
+	Err   error
+}
+
+func (e *ParseAmountError) Error() string {
+	return fmt.Sprintf("parsing %s %q: %s", e.Kind, e.Input, e.Err)
+}
+
+func (e *ParseAmountError) Unwrap() error {
+	return e.Err
+}
+
+// The largest magnitude which can be serialized, in bytes: the serialization also holds a sign byte.
+const maxAmountMagnitudeBytes = big.BigIntMaxSerializedLen - 1
+
+// NewTokenAmountFromString strictly parses a token amount in attoFIL, as a decimal integer or a hexadecimal
+// integer with a "0x" prefix, optionally preceded by "-". Whitespace, a "+" sign, leading zeros (other than
+// a single "0"), underscores and values too large to serialize are rejected.
+func NewTokenAmountFromString(s string) (TokenAmount, error) {
+	return parseAmount("token amount", s, true)
+}
+
+// NewStoragePowerFromString strictly parses a storage power in bytes, as NewTokenAmountFromString but
+// rejecting any sign, since power is never negative at an API boundary.
+func NewStoragePowerFromString(s string) (StoragePower, error) {
+	return parseAmount("storage power", s, false)
+}
+
+func parseAmount(kind, s string, signed bool) (big.Int, error) {
+	fail := func(err error) (big.Int, error) {
+		return big.Int{}, &ParseAmountError{Kind: kind, Input: s, Err: err}
+	}
+	digits := s
+	negative := false
+	if strings.HasPrefix(digits, "-") || strings.HasPrefix(digits, "+") {
+		if !signed || digits[0] == '+' {
+			return fail(ErrAmountSign)
+		}
+		negative = true
+		digits = digits[1:]
+	}
+	base := 10
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		base = 16
+		digits = digits[2:]
+	}
+	if digits == "" || (len(digits) > 1 && digits[0] == '0') {
+		return fail(ErrAmountSyntax)
+	}
+	for _, c := range digits {
+		if !isDigit(c, base) {
+			return fail(ErrAmountSyntax)
+		}
+	}
+	v, ok := new(gobig.Int).SetString(digits, base)
+	if !ok {
+		return fail(ErrAmountSyntax)
+	}
+	if (v.BitLen()+7)/8 > maxAmountMagnitudeBytes {
+		return fail(ErrAmountRange)
+	}
+	if negative {
+		v.Neg(v)
+	}
+	return big.NewFromGo(v), nil
+}
+
+func isDigit(c rune, base int) bool {
+	switch {
+	case c >= '0' && c <= '9':
+		return true
+	case base == 16 && (c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'):
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,63 @@
+package abi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+func TestNewTokenAmountFromString(t *testing.T) {
+	for s, expected := range map[string]abi.TokenAmount{
+		"0":                    big.Zero(),
+		"1000000000000000000":  big.NewInt(1e18),
+		"-42":                  big.NewInt(-42),
+		"0x2a":                 big.NewInt(42),
+		"-0xFF":                big.NewInt(-255),
+		"0x0":                  big.Zero(),
+		"18446744073709551616": big.Lsh(big.NewInt(1), 64),
+	} {
+		v, err := abi.NewTokenAmountFromString(s)
+		require.NoError(t, err, s)
+		assert.Equal(t, expected, v, s)
+	}
+
+	for s, cause := range map[string]error{
+		"":                              abi.ErrAmountSyntax,
+		" 1":                            abi.ErrAmountSyntax,
+		"1 ":                            abi.ErrAmountSyntax,
+		"1_000":                         abi.ErrAmountSyntax,
+		"007":                           abi.ErrAmountSyntax,
+		"1.5":                           abi.ErrAmountSyntax,
+		"0x":                            abi.ErrAmountSyntax,
+		"0xg":                           abi.ErrAmountSyntax,
+		"-":                             abi.ErrAmountSyntax,
+		"--1":                           abi.ErrAmountSyntax,
+		"+1":                            abi.ErrAmountSign,
+		"0x" + strings.Repeat("f", 256): abi.ErrAmountRange,
+	} {
+		_, err := abi.NewTokenAmountFromString(s)
+		var perr *abi.ParseAmountError
+		require.True(t, xerrors.As(err, &perr), "%q", s)
+		assert.True(t, xerrors.Is(err, cause), "%q: %v", s, err)
+	}
+
+	// The largest serializable magnitude is accepted.
+	_, err := abi.NewTokenAmountFromString("0x" + strings.Repeat("f", 254))
+	assert.NoError(t, err)
+}
+
+func TestNewStoragePowerFromString(t *testing.T) {
+	v, err := abi.NewStoragePowerFromString("34359738368")
+	require.NoError(t, err)
+	assert.Equal(t, abi.NewStoragePower(32<<30), v)
+
+	_, err = abi.NewStoragePowerFromString("-1")
+	assert.True(t, xerrors.Is(err, abi.ErrAmountSign))
+	assert.EqualError(t, err, `parsing storage power "-1": sign not allowed`)
+}
@@ -0,0 +1,56 @@
+package abi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	mh "github.com/multiformats/go-multihash"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// PeerID is the binary form of a libp2p peer ID: a multihash of the peer's public key.
+// It encodes as a CBOR byte string of at most MaxPeerIDLength bytes.
+type PeerID []byte
+
+var _ cbg.CBORMarshaler = (*PeerID)(nil)
+var _ cbg.CBORUnmarshaler = (*PeerID)(nil)
+
+// Validate checks that the peer ID is within the length bound.
+func (p PeerID) Validate() error {
+	if len(p) > MaxPeerIDLength {
+		return fmt.Errorf("peer ID length %d exceeds maximum %d", len(p), MaxPeerIDLength)
+	}
+	return nil
+}
+
+// String renders the peer ID in base58, as libp2p does, if it is a valid multihash, and otherwise in hex.
+func (p PeerID) String() string {
+	if h, err := mh.Cast(p); err == nil {
+		return h.B58String()
+	}
+	return fmt.Sprintf("0x%x", []byte(p))
+}
+
+// Equals reports whether two peer IDs are the same.
+func (p PeerID) Equals(o PeerID) bool {
+	return bytes.Equal(p, o)
+}
+
+// Compare orders peer IDs by their bytes, returning -1, 0 or 1.
+func (p PeerID) Compare(o PeerID) int {
+	return bytes.Compare(p, o)
+}
+
+func (p *PeerID) MarshalCBOR(w io.Writer) error {
+	return WriteBoundedBytes(w, *p, MaxPeerIDLength)
+}
+
+func (p *PeerID) UnmarshalCBOR(r io.Reader) error {
+	b, err := ReadBoundedBytes(r, MaxPeerIDLength)
+	if err != nil {
+		return err
+	}
+	*p = b
+	return nil
+}
@@ -0,0 +1,38 @@
+package abi_test
+
+import (
+	"bytes"
+	"testing"
+
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestPeerID(t *testing.T) {
+	h, err := mh.Sum([]byte("key"), mh.SHA2_256, -1)
+	require.NoError(t, err)
+	p := abi.PeerID(h)
+	assert.Equal(t, h.B58String(), p.String())
+	assert.Equal(t, "0x0102", abi.PeerID{1, 2}.String())
+
+	assert.True(t, p.Equals(abi.PeerID(h)))
+	assert.False(t, p.Equals(abi.PeerID{1, 2}))
+	assert.Equal(t, -1, abi.PeerID{1, 2}.Compare(abi.PeerID{1, 3}))
+	assert.Equal(t, 0, p.Compare(abi.PeerID(h)))
+
+	var buf bytes.Buffer
+	require.NoError(t, p.MarshalCBOR(&buf))
+	var decoded abi.PeerID
+	require.NoError(t, decoded.UnmarshalCBOR(&buf))
+	assert.Equal(t, p, decoded)
+
+	long := make(abi.PeerID, abi.MaxPeerIDLength+1)
+	assert.Error(t, long.Validate())
+	assert.Error(t, long.MarshalCBOR(&buf))
+	buf.Reset()
+	require.NoError(t, abi.WriteBoundedBytes(&buf, long, abi.MaxPeerIDLength+1))
+	assert.Error(t, decoded.UnmarshalCBOR(&buf))
+}
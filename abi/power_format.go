@@ -0,0 +1,97 @@
+package abi
+
+import (
+	"fmt"
+	gobig "math/big"
+	"strings"
+
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// Display units for quantities of storage, such as storage power.
+
+var iecUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB", "ZiB", "YiB"}
+var siUnits = []string{"B", "kB", "MB", "GB", "TB", "PB", "EB", "ZB", "YB"}
+
+// FormatStoragePowerIEC renders a quantity of bytes in binary (IEC) units, e.g. "3.14 PiB", with the given
+// number of decimal places. The largest unit not exceeding the magnitude of the value is used.
+func FormatStoragePowerIEC(p StoragePower, precision int) string {
+	return formatStorage(p, precision, 1024, iecUnits)
+}
+
+// FormatStoragePowerSI renders a quantity of bytes in decimal (SI) units, e.g. "3.14 PB", with the given
+// number of decimal places. The largest unit not exceeding the magnitude of the value is used.
+func FormatStoragePowerSI(p StoragePower, precision int) string {
+	return formatStorage(p, precision, 1000, siUnits)
+}
+
+func formatStorage(p StoragePower, precision int, base int64, units []string) string {
+	if p.Int == nil {
+		p = big.Zero()
+	}
+	if precision < 0 {
+		precision = 0
+	}
+	abs := new(gobig.Int).Abs(p.Int)
+	bigBase := gobig.NewInt(base)
+	unit := 0
+	divisor := gobig.NewInt(1)
+	for unit < len(units)-1 {
+		next := new(gobig.Int).Mul(divisor, bigBase)
+		if abs.Cmp(next) < 0 {
+			break
+		}
+		divisor = next
+		unit++
+	}
+	if unit == 0 {
+		// Whole bytes have no fractional part.
+		return fmt.Sprintf("%s %s", p.Int.String(), units[0])
+	}
+	value := new(gobig.Rat).SetFrac(p.Int, divisor)
+	return fmt.Sprintf("%s %s", value.FloatString(precision), units[unit])
+}
+
+// ParseStoragePower parses a quantity of bytes as rendered by FormatStoragePowerIEC or FormatStoragePowerSI,
+// or a plain integer number of bytes. The space between number and unit is optional, and the unit is
+// case-insensitive (so "1 KB" and "1 kb" are both 1000 bytes). A value that is not a whole number of bytes is
+// truncated towards zero.
+func ParseStoragePower(s string) (StoragePower, error) {
+	s = strings.TrimSpace(s)
+	numEnd := strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= '0' && r <= '9' || r == '.' || r == '-' || r == '+')
+	})
+	numPart, unitPart := s, ""
+	if numEnd >= 0 {
+		numPart, unitPart = s[:numEnd], strings.TrimSpace(s[numEnd:])
+	}
+	if numPart == "" {
+		return big.Int{}, fmt.Errorf("invalid storage quantity %q: missing number", s)
+	}
+
+	value, ok := new(gobig.Rat).SetString(numPart)
+	if !ok {
+		return big.Int{}, fmt.Errorf("invalid storage quantity %q: bad number %q", s, numPart)
+	}
+	multiplier, err := storageUnitMultiplier(unitPart)
+	if err != nil {
+		return big.Int{}, fmt.Errorf("invalid storage quantity %q: %w", s, err)
+	}
+	value.Mul(value, new(gobig.Rat).SetInt(multiplier))
+	return big.NewFromGo(new(gobig.Int).Quo(value.Num(), value.Denom())), nil
+}
+
+func storageUnitMultiplier(unit string) (*gobig.Int, error) {
+	if unit == "" {
+		return gobig.NewInt(1), nil
+	}
+	for i := range iecUnits {
+		if strings.EqualFold(unit, iecUnits[i]) {
+			return new(gobig.Int).Exp(gobig.NewInt(1024), gobig.NewInt(int64(i)), nil), nil
+		}
+		if strings.EqualFold(unit, siUnits[i]) {
+			return new(gobig.Int).Exp(gobig.NewInt(1000), gobig.NewInt(int64(i)), nil), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown unit %q", unit)
+}
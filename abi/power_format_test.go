@@ -0,0 +1,57 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+func TestFormatStoragePower(t *testing.T) {
+	pib := big.Lsh(big.NewInt(1), 50)
+	pi := big.Div(big.Mul(pib, big.NewInt(314)), big.NewInt(100))
+
+	assert.Equal(t, "3.14 PiB", abi.FormatStoragePowerIEC(pi, 2))
+	assert.Equal(t, "3.1 PiB", abi.FormatStoragePowerIEC(pi, 1))
+	assert.Equal(t, "3.54 PB", abi.FormatStoragePowerSI(pi, 2))
+	assert.Equal(t, "1023 B", abi.FormatStoragePowerIEC(big.NewInt(1023), 2))
+	assert.Equal(t, "1.000 KiB", abi.FormatStoragePowerIEC(big.NewInt(1024), 3))
+	assert.Equal(t, "1.02 kB", abi.FormatStoragePowerSI(big.NewInt(1024), 2))
+	assert.Equal(t, "-32.00 GiB", abi.FormatStoragePowerIEC(big.NewInt(-32<<30), 2))
+	assert.Equal(t, "0 B", abi.FormatStoragePowerIEC(big.Int{}, 2))
+	assert.Equal(t, "1024.0 YiB", abi.FormatStoragePowerIEC(big.Lsh(big.NewInt(1), 90), 1))
+}
+
+func TestParseStoragePower(t *testing.T) {
+	for _, tc := range []struct {
+		in       string
+		expected big.Int
+	}{
+		{"1024", big.NewInt(1024)},
+		{"1 KiB", big.NewInt(1024)},
+		{"1kib", big.NewInt(1024)},
+		{"1 kB", big.NewInt(1000)},
+		{"32 GiB", big.NewInt(32 << 30)},
+		{"1.5 MiB", big.NewInt(3 << 19)},
+		{"-2 TB", big.NewInt(-2e12)},
+		{"0.0001 B", big.NewInt(0)},
+	} {
+		p, err := abi.ParseStoragePower(tc.in)
+		require.NoError(t, err, tc.in)
+		assert.Equal(t, tc.expected, p, tc.in)
+	}
+
+	for _, in := range []string{"", "KiB", "1 XiB", "1..2 KiB", "one"} {
+		_, err := abi.ParseStoragePower(in)
+		assert.Error(t, err, in)
+	}
+
+	// Formatting with full precision round-trips.
+	p := big.NewInt(123456789012345)
+	parsed, err := abi.ParseStoragePower(abi.FormatStoragePowerIEC(p, 20))
+	require.NoError(t, err)
+	assert.Equal(t, p, parsed)
+}
@@ -0,0 +1,71 @@
+package abi
+
+import "sort"
+
+// Maximum lifetime, in epochs, of a sector sealed with a V1 seal proof.
+const SectorMaximumLifetimeV1 = 540 * epochsInDay
+
+// Maximum lifetime, in epochs, of a sector sealed with a V2 (V1_1) or later seal proof.
+const SectorMaximumLifetimeV2 = 5 * 365 * epochsInDay
+
+// ProofCapability describes the features supported by a seal proof type. Its JSON encoding is intended for
+// generating SDKs in other languages.
+type ProofCapability struct {
+	Proof      RegisteredSealProof `json:"proof"`
+	SectorSize SectorSize          `json:"sectorSize"`
+	// Whether proofs of this type may be aggregated, and with which schemes.
+	Aggregation       bool                         `json:"aggregation"`
+	AggregationProofs []RegisteredAggregationProof `json:"aggregationProofs"`
+	// Whether sectors sealed with this type may be updated with new data (snap deals).
+	Snap bool `json:"snap"`
+	// Whether sectors may be sealed with synthetic challenges.
+	Synthetic bool `json:"synthetic"`
+	// Whether the proof is non-interactive, i.e. needs no pre-commit.
+	NonInteractive bool `json:"nonInteractive"`
+	// The maximum lifetime of a sector, in epochs.
+	MaxSectorLifetime ChainEpoch `json:"maxSectorLifetime"`
+}
+
+// ProofCapabilities returns the capabilities of each supported seal proof type, in order of proof type.
+func ProofCapabilities() []ProofCapability {
+	out := make([]ProofCapability, 0, len(SealProofInfos))
+	for p, info := range SealProofInfos {
+		out = append(out, proofCapability(p, info))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Proof < out[j].Proof
+	})
+	return out
+}
+
+// GetProofCapability returns the capabilities of a seal proof type.
+func GetProofCapability(p RegisteredSealProof) (ProofCapability, bool) {
+	info, ok := SealProofInfos[p]
+	if !ok {
+		return ProofCapability{}, false
+	}
+	return proofCapability(p, info), true
+}
+
+func proofCapability(p RegisteredSealProof, info *SealProofInfo) ProofCapability {
+	c := ProofCapability{
+		Proof:             p,
+		SectorSize:        info.SectorSize,
+		AggregationProofs: []RegisteredAggregationProof{},
+		Synthetic:         p.IsSynthetic(),
+		NonInteractive:    p.IsNonInteractive(),
+		MaxSectorLifetime: SectorMaximumLifetimeV2,
+	}
+	switch {
+	case c.NonInteractive:
+		c.AggregationProofs = []RegisteredAggregationProof{RegisteredAggregationProof_SnarkPackV2}
+	case p >= RegisteredSealProof_StackedDrg2KiBV2:
+		c.AggregationProofs = []RegisteredAggregationProof{RegisteredAggregationProof_SnarkPackV1, RegisteredAggregationProof_SnarkPackV2}
+	default:
+		c.MaxSectorLifetime = SectorMaximumLifetimeV1
+	}
+	c.Aggregation = len(c.AggregationProofs) > 0
+	_, err := p.RegisteredUpdateProof()
+	c.Snap = err == nil
+	return c
+}
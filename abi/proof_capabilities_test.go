@@ -0,0 +1,44 @@
+package abi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestProofCapabilities(t *testing.T) {
+	caps := abi.ProofCapabilities()
+	require.Len(t, caps, len(abi.SealProofInfos))
+	for i := 1; i < len(caps); i++ {
+		assert.Less(t, int64(caps[i-1].Proof), int64(caps[i].Proof))
+	}
+
+	v1, ok := abi.GetProofCapability(abi.RegisteredSealProof_StackedDrg32GiBV1)
+	require.True(t, ok)
+	assert.False(t, v1.Aggregation)
+	assert.False(t, v1.Snap)
+	assert.Equal(t, abi.SectorMaximumLifetimeV1, v1.MaxSectorLifetime)
+
+	v2, ok := abi.GetProofCapability(abi.RegisteredSealProof_StackedDrg32GiBV2)
+	require.True(t, ok)
+	assert.True(t, v2.Aggregation)
+	assert.True(t, v2.Snap)
+	assert.False(t, v2.NonInteractive)
+	assert.Equal(t, abi.SectorMaximumLifetimeV2, v2.MaxSectorLifetime)
+
+	ni, ok := abi.GetProofCapability(abi.RegisteredSealProof_StackedDrg64GiBV1_2_Feat_NiPoRep)
+	require.True(t, ok)
+	assert.True(t, ni.NonInteractive)
+	assert.Equal(t, []abi.RegisteredAggregationProof{abi.RegisteredAggregationProof_SnarkPackV2}, ni.AggregationProofs)
+
+	_, ok = abi.GetProofCapability(abi.RegisteredSealProof(99))
+	assert.False(t, ok)
+
+	j, err := json.Marshal(v1)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"proof":3,"sectorSize":34359738368,"aggregation":false,"aggregationProofs":[],"snap":false,"synthetic":false,"nonInteractive":false,"maxSectorLifetime":1555200}`, string(j))
+}
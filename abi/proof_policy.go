@@ -0,0 +1,97 @@
+package abi
+
+import (
+	"sync"
+
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+// NetworkPreset names a set of consensus policy overrides for a network, such as which sector sizes may be
+// onboarded.
+type NetworkPreset string
+
+const (
+	// Mainnet policy. This is also the policy of any preset that has not been registered.
+	PresetMainnet = NetworkPreset("mainnet")
+	// The calibration network, which follows mainnet proof policy.
+	PresetCalibnet = NetworkPreset("calibrationnet")
+	// Development and test networks, which additionally allow the small (2KiB, 8MiB and 512MiB) sector sizes.
+	PresetDevnet = NetworkPreset("devnet")
+)
+
+// ProofPolicy is the proof policy of a network preset.
+type ProofPolicy struct {
+	// Sector sizes that may be onboarded.
+	SectorSizes []SectorSize
+}
+
+// The sector sizes allowed for onboarding on mainnet.
+var mainnetSectorSizes = []SectorSize{32 << 30, 64 << 30}
+
+// Registered proof policies, by preset.
+var (
+	proofPoliciesLk sync.RWMutex
+	proofPolicies   = map[NetworkPreset]ProofPolicy{
+		PresetMainnet:  {SectorSizes: mainnetSectorSizes},
+		PresetCalibnet: {SectorSizes: mainnetSectorSizes},
+		PresetDevnet:   {SectorSizes: []SectorSize{2 << 10, 8 << 20, 512 << 20, 32 << 30, 64 << 30}},
+	}
+)
+
+// RegisterNetworkPreset records the proof policy of a network preset, replacing any policy previously
+// registered for it.
+func RegisterNetworkPreset(preset NetworkPreset, policy ProofPolicy) {
+	sizes := make([]SectorSize, len(policy.SectorSizes))
+	copy(sizes, policy.SectorSizes)
+	proofPoliciesLk.Lock()
+	defer proofPoliciesLk.Unlock()
+	proofPolicies[preset] = ProofPolicy{SectorSizes: sizes}
+}
+
+// GetProofPolicy returns the proof policy registered for a network preset, or mainnet policy if there is none.
+func GetProofPolicy(preset NetworkPreset) ProofPolicy {
+	proofPoliciesLk.RLock()
+	defer proofPoliciesLk.RUnlock()
+	policy, ok := proofPolicies[preset]
+	if !ok {
+		policy = proofPolicies[PresetMainnet]
+	}
+	sizes := make([]SectorSize, len(policy.SectorSizes))
+	copy(sizes, policy.SectorSizes)
+	return ProofPolicy{SectorSizes: sizes}
+}
+
+// SealProofAllowedForOnboarding returns whether new sectors may be sealed with a proof type on a network preset
+// at some network version. The proof's sector size must be allowed by the preset, and the proof version must be
+// enabled at the network version: V1 proofs before network version 8, V2 (V1_1) proofs from network version 7,
+// synthetic PoRep proofs from network version 21, and NI-PoRep proofs from network version 23.
+func SealProofAllowedForOnboarding(p RegisteredSealProof, preset NetworkPreset, nv network.Version) bool {
+	info, ok := SealProofInfos[p]
+	if !ok {
+		return false
+	}
+	switch {
+	case p.IsNonInteractive():
+		if nv < network.Version23 {
+			return false
+		}
+	case p.IsSynthetic():
+		if nv < network.Version21 {
+			return false
+		}
+	case p >= RegisteredSealProof_StackedDrg2KiBV2:
+		if nv < network.Version7 {
+			return false
+		}
+	default:
+		if nv >= network.Version8 {
+			return false
+		}
+	}
+	for _, size := range GetProofPolicy(preset).SectorSizes {
+		if size == info.SectorSize {
+			return true
+		}
+	}
+	return false
+}
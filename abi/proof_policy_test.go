@@ -0,0 +1,49 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestSealProofAllowedForOnboarding(t *testing.T) {
+	for _, tc := range []struct {
+		proof   abi.RegisteredSealProof
+		preset  abi.NetworkPreset
+		nv      network.Version
+		allowed bool
+	}{
+		{abi.RegisteredSealProof_StackedDrg32GiBV1, abi.PresetMainnet, network.Version7, true},
+		{abi.RegisteredSealProof_StackedDrg32GiBV1, abi.PresetMainnet, network.Version8, false},
+		{abi.RegisteredSealProof_StackedDrg32GiBV2, abi.PresetMainnet, network.Version6, false},
+		{abi.RegisteredSealProof_StackedDrg32GiBV2, abi.PresetMainnet, network.Version7, true},
+		{abi.RegisteredSealProof_StackedDrg32GiBV2, abi.PresetMainnet, network.Version21, true},
+		{abi.RegisteredSealProof_StackedDrg64GiBV2, abi.PresetCalibnet, network.Version21, true},
+		{abi.RegisteredSealProof_StackedDrg2KiBV2, abi.PresetMainnet, network.Version21, false},
+		{abi.RegisteredSealProof_StackedDrg2KiBV2, abi.PresetCalibnet, network.Version21, false},
+		{abi.RegisteredSealProof_StackedDrg2KiBV2, abi.PresetDevnet, network.Version21, true},
+		{abi.RegisteredSealProof_StackedDrg8MiBV2, abi.PresetDevnet, network.Version21, true},
+		{abi.RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep, abi.PresetMainnet, network.Version22, false},
+		{abi.RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep, abi.PresetMainnet, network.Version23, true},
+		{abi.RegisteredSealProof(99), abi.PresetDevnet, network.Version21, false},
+		// Unregistered presets follow mainnet policy.
+		{abi.RegisteredSealProof_StackedDrg2KiBV2, abi.NetworkPreset("unknown"), network.Version21, false},
+	} {
+		assert.Equal(t, tc.allowed, abi.SealProofAllowedForOnboarding(tc.proof, tc.preset, tc.nv), "%d on %s at %d", tc.proof, tc.preset, tc.nv)
+	}
+}
+
+func TestRegisterNetworkPreset(t *testing.T) {
+	preset := abi.NetworkPreset("butterfly-test")
+	abi.RegisterNetworkPreset(preset, abi.ProofPolicy{SectorSizes: []abi.SectorSize{512 << 20}})
+	assert.True(t, abi.SealProofAllowedForOnboarding(abi.RegisteredSealProof_StackedDrg512MiBV2, preset, network.Version21))
+	assert.False(t, abi.SealProofAllowedForOnboarding(abi.RegisteredSealProof_StackedDrg32GiBV2, preset, network.Version21))
+
+	// The returned policy is a copy.
+	policy := abi.GetProofPolicy(preset)
+	policy.SectorSizes[0] = 32 << 30
+	assert.Equal(t, []abi.SectorSize{512 << 20}, abi.GetProofPolicy(preset).SectorSizes)
+}
@@ -0,0 +1,53 @@
+package abi
+
+// A QuantSpec specifies quantization of epochs to a regular grid: exact multiples of a unit, offset from zero.
+// Quantization is used by the miner actor to batch scheduled events (such as sector expirations) to
+// deadline boundaries, so schedulers outside the VM must quantize identically.
+type QuantSpec struct {
+	unit   ChainEpoch // The unit of quantization
+	offset ChainEpoch // The offset from zero from which to base the modulus
+}
+
+// NewQuantSpec returns a spec quantizing to multiples of unit offset by offset % unit. The unit must be positive.
+func NewQuantSpec(unit, offset ChainEpoch) QuantSpec {
+	return QuantSpec{unit: unit, offset: offset}
+}
+
+// A spec which leaves every epoch unchanged.
+var NoQuantization = NewQuantSpec(1, 0)
+
+// QuantizeUp rounds an epoch up to the nearest quantized epoch.
+func (q QuantSpec) QuantizeUp(e ChainEpoch) ChainEpoch {
+	return quantizeUp(e, q.unit, q.offset)
+}
+
+// QuantizeDown rounds an epoch down to the nearest quantized epoch.
+func (q QuantSpec) QuantizeDown(e ChainEpoch) ChainEpoch {
+	next := q.QuantizeUp(e)
+	// QuantizeDown == QuantizeUp iff e is a fixed point of QuantizeUp
+	if e == next {
+		return next
+	}
+	return next - q.unit
+}
+
+// Rounds e to the nearest exact multiple of the quantization unit offset by
+// offsetSeed % unit, rounding up.
+// This function is equivalent to `unit * ceil(e - (offsetSeed % unit) / unit) + (offsetSeed % unit)`
+// with the variables/operations are over real numbers instead of ints.
+// Precondition: unit >= 0 else behaviour is undefined
+func quantizeUp(e ChainEpoch, unit ChainEpoch, offsetSeed ChainEpoch) ChainEpoch {
+	offset := offsetSeed % unit
+
+	remainder := (e - offset) % unit
+	quotient := (e - offset) / unit
+	// Don't round if epoch falls on a quantization epoch
+	if remainder == 0 {
+		return unit*quotient + offset
+	}
+	// Negative truncating division rounds up
+	if e-offset < 0 {
+		return unit*quotient + offset
+	}
+	return unit*(quotient+1) + offset
+}
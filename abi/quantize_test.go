@@ -0,0 +1,43 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestQuantizeUp(t *testing.T) {
+	t.Run("no quantization", func(t *testing.T) {
+		q := abi.NoQuantization
+		assert.Equal(t, abi.ChainEpoch(0), q.QuantizeUp(0))
+		assert.Equal(t, abi.ChainEpoch(1), q.QuantizeUp(1))
+		assert.Equal(t, abi.ChainEpoch(2), q.QuantizeUp(2))
+		assert.Equal(t, abi.ChainEpoch(123456789), q.QuantizeUp(123456789))
+	})
+	t.Run("zero offset", func(t *testing.T) {
+		assert.Equal(t, abi.ChainEpoch(50), abi.NewQuantSpec(10, 0).QuantizeUp(42))
+		assert.Equal(t, abi.ChainEpoch(16000), abi.NewQuantSpec(100, 0).QuantizeUp(16000))
+		assert.Equal(t, abi.ChainEpoch(0), abi.NewQuantSpec(10, 0).QuantizeUp(-5))
+		assert.Equal(t, abi.ChainEpoch(-50), abi.NewQuantSpec(10, 0).QuantizeUp(-50))
+		assert.Equal(t, abi.ChainEpoch(-50), abi.NewQuantSpec(10, 0).QuantizeUp(-53))
+	})
+	t.Run("non zero offset", func(t *testing.T) {
+		assert.Equal(t, abi.ChainEpoch(6), abi.NewQuantSpec(5, 1).QuantizeUp(4))
+		assert.Equal(t, abi.ChainEpoch(1), abi.NewQuantSpec(5, 1).QuantizeUp(0))
+		assert.Equal(t, abi.ChainEpoch(-4), abi.NewQuantSpec(5, 1).QuantizeUp(-6))
+		assert.Equal(t, abi.ChainEpoch(4), abi.NewQuantSpec(10, 4).QuantizeUp(2))
+	})
+	t.Run("offset seed bigger than unit is normalized", func(t *testing.T) {
+		assert.Equal(t, abi.ChainEpoch(13), abi.NewQuantSpec(7, 1742).QuantizeUp(11)) // offset 1742 % 7 = 6
+	})
+}
+
+func TestQuantizeDown(t *testing.T) {
+	q := abi.NewQuantSpec(10, 3)
+	assert.Equal(t, abi.ChainEpoch(13), q.QuantizeDown(13))
+	assert.Equal(t, abi.ChainEpoch(13), q.QuantizeDown(22))
+	assert.Equal(t, abi.ChainEpoch(23), q.QuantizeDown(23))
+	assert.Equal(t, abi.ChainEpoch(-7), q.QuantizeDown(-5))
+}
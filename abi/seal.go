@@ -0,0 +1,60 @@
+package abi
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+// Policy parameters governing the epochs from which sealing randomness is drawn.
+// These must match the values used by the miner actor for the network version in question, otherwise
+// pre-commitments scheduled by a sealing pipeline will be rejected.
+
+// Epochs after which chain state is final with overwhelming probability (hence the likelihood of a fork
+// of this size is negligible).
+const ChainFinality = ChainEpoch(900)
+
+// Number of epochs between publishing a pre-commitment and the tip of the chain at which the
+// seal randomness (ticket) is sampled.
+const SealRandomnessLookback = ChainFinality
+
+// Maximum number of epochs before the pre-commitment epoch at which the seal randomness may have been sampled.
+// This is one day (2880 epochs of 30 seconds) plus the chain finality.
+const MaxPreCommitRandomnessLookback = ChainEpoch(2880) + ChainFinality
+
+// PreCommitChallengeDelay returns the number of epochs between publishing a pre-commitment and the epoch
+// from which the interactive seal challenge randomness is drawn, at some network version.
+// The delay has not changed at any network version to date.
+func PreCommitChallengeDelay(_ network.Version) ChainEpoch {
+	return ChainEpoch(150)
+}
+
+// SealRandomEpoch returns the epoch at which a sealer should sample the seal randomness (ticket) for
+// a sector to be pre-committed at the current epoch.
+func SealRandomEpoch(currEpoch ChainEpoch) ChainEpoch {
+	return currEpoch - SealRandomnessLookback
+}
+
+// EarliestSealRandomEpoch returns the earliest seal randomness epoch that will be accepted by a
+// pre-commitment at the current epoch.
+func EarliestSealRandomEpoch(currEpoch ChainEpoch) ChainEpoch {
+	return currEpoch - MaxPreCommitRandomnessLookback
+}
+
+// InteractiveSealChallengeEpoch returns the epoch at which the interactive seal challenge randomness is
+// drawn for a sector pre-committed at preCommitEpoch. The sector may not be proven until after this epoch.
+func InteractiveSealChallengeEpoch(preCommitEpoch ChainEpoch, nv network.Version) ChainEpoch {
+	return preCommitEpoch + PreCommitChallengeDelay(nv)
+}
+
+// ValidateSealRandEpoch checks that a seal randomness epoch would be accepted for a pre-commitment at the
+// current epoch.
+func ValidateSealRandEpoch(sealRandEpoch, currEpoch ChainEpoch) error {
+	if sealRandEpoch >= currEpoch {
+		return xerrors.Errorf("seal challenge epoch %d must be before now %d", sealRandEpoch, currEpoch)
+	}
+	if earliest := EarliestSealRandomEpoch(currEpoch); sealRandEpoch < earliest {
+		return xerrors.Errorf("seal challenge epoch %d too old, must be after %d", sealRandEpoch, earliest)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package abi
+
+import (
+	"strings"
+
+	"golang.org/x/xerrors"
+)
+
+// Seal proofs may be written as "<sector size>/<variant>", such as "32GiB/v1_1", for use in configuration.
+// The sector size is as formatted by SectorSize.ShortString. The variants are:
+//   - "v1": the original StackedDrg V1 proofs
+//   - "v1_1": the V2 (V1_1) proofs, the only interactive proofs accepted for new sectors since network version 7
+//   - "v1_1_synth": the synthetic PoRep proofs
+//   - "v1_2_ni": the non-interactive (NI-PoRep) proofs
+const (
+	SealProofVariantV1        = "v1"
+	SealProofVariantV1_1      = "v1_1"
+	SealProofVariantSynthetic = "v1_1_synth"
+	SealProofVariantNI        = "v1_2_ni"
+)
+
+var sealProofVariants = map[RegisteredSealProof]string{
+	RegisteredSealProof_StackedDrg2KiBV1:   SealProofVariantV1,
+	RegisteredSealProof_StackedDrg8MiBV1:   SealProofVariantV1,
+	RegisteredSealProof_StackedDrg512MiBV1: SealProofVariantV1,
+	RegisteredSealProof_StackedDrg32GiBV1:  SealProofVariantV1,
+	RegisteredSealProof_StackedDrg64GiBV1:  SealProofVariantV1,
+
+	RegisteredSealProof_StackedDrg2KiBV2:   SealProofVariantV1_1,
+	RegisteredSealProof_StackedDrg8MiBV2:   SealProofVariantV1_1,
+	RegisteredSealProof_StackedDrg512MiBV2: SealProofVariantV1_1,
+	RegisteredSealProof_StackedDrg32GiBV2:  SealProofVariantV1_1,
+	RegisteredSealProof_StackedDrg64GiBV2:  SealProofVariantV1_1,
+
+	RegisteredSealProof_StackedDrg2KiBV1_1_Feat_SyntheticPoRep:   SealProofVariantSynthetic,
+	RegisteredSealProof_StackedDrg8MiBV1_1_Feat_SyntheticPoRep:   SealProofVariantSynthetic,
+	RegisteredSealProof_StackedDrg512MiBV1_1_Feat_SyntheticPoRep: SealProofVariantSynthetic,
+	RegisteredSealProof_StackedDrg32GiBV1_1_Feat_SyntheticPoRep:  SealProofVariantSynthetic,
+	RegisteredSealProof_StackedDrg64GiBV1_1_Feat_SyntheticPoRep:  SealProofVariantSynthetic,
+
+	RegisteredSealProof_StackedDrg2KiBV1_2_Feat_NiPoRep:   SealProofVariantNI,
+	RegisteredSealProof_StackedDrg8MiBV1_2_Feat_NiPoRep:   SealProofVariantNI,
+	RegisteredSealProof_StackedDrg512MiBV1_2_Feat_NiPoRep: SealProofVariantNI,
+	RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep:  SealProofVariantNI,
+	RegisteredSealProof_StackedDrg64GiBV1_2_Feat_NiPoRep:  SealProofVariantNI,
+}
+
+// Seal proofs by formatted string.
+var sealProofsByString = func() map[string]RegisteredSealProof {
+	m := make(map[string]RegisteredSealProof, len(sealProofVariants))
+	for p, variant := range sealProofVariants {
+		m[SealProofInfos[p].SectorSize.ShortString()+"/"+variant] = p
+	}
+	return m
+}()
+
+// SealProofFromString parses a seal proof from its "<sector size>/<variant>" form, e.g. "32GiB/v1_1".
+func SealProofFromString(s string) (RegisteredSealProof, error) {
+	if !strings.Contains(s, "/") {
+		return 0, xerrors.Errorf("invalid seal proof %q: expected <sector size>/<variant>", s)
+	}
+	p, ok := sealProofsByString[s]
+	if !ok {
+		return 0, xerrors.Errorf("unknown seal proof %q", s)
+	}
+	return p, nil
+}
+
+// SealProofForSize returns the seal proof of some variant for a sector size.
+func SealProofForSize(size SectorSize, variant string) (RegisteredSealProof, error) {
+	p, ok := sealProofsByString[size.ShortString()+"/"+variant]
+	if !ok || SealProofInfos[p].SectorSize != size {
+		return 0, xerrors.Errorf("no %s seal proof for sector size %d", variant, size)
+	}
+	return p, nil
+}
+
+// FormatSealProof formats a seal proof in the "<sector size>/<variant>" form parsed by SealProofFromString.
+func FormatSealProof(p RegisteredSealProof) (string, error) {
+	variant, ok := sealProofVariants[p]
+	if !ok {
+		return "", xerrors.Errorf("unsupported seal proof type %d", p)
+	}
+	return SealProofInfos[p].SectorSize.ShortString() + "/" + variant, nil
+}
@@ -0,0 +1,61 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestSealProofFromString(t *testing.T) {
+	for s, p := range map[string]abi.RegisteredSealProof{
+		"2KiB/v1":       abi.RegisteredSealProof_StackedDrg2KiBV1,
+		"32GiB/v1_1":    abi.RegisteredSealProof_StackedDrg32GiBV2,
+		"512MiB/v1_1":   abi.RegisteredSealProof_StackedDrg512MiBV2,
+		"64GiB/v1_2_ni": abi.RegisteredSealProof_StackedDrg64GiBV1_2_Feat_NiPoRep,
+	} {
+		parsed, err := abi.SealProofFromString(s)
+		require.NoError(t, err)
+		assert.Equal(t, p, parsed, s)
+	}
+
+	// Every supported proof round trips.
+	for p := range abi.SealProofInfos {
+		s, err := abi.FormatSealProof(p)
+		require.NoError(t, err)
+		parsed, err := abi.SealProofFromString(s)
+		require.NoError(t, err)
+		assert.Equal(t, p, parsed, s)
+	}
+
+	for _, s := range []string{"", "32GiB", "32GiB/v3", "16GiB/v1", "32gib/v1_1", "32GiB/v1_1/"} {
+		_, err := abi.SealProofFromString(s)
+		assert.Error(t, err, s)
+	}
+	_, err := abi.FormatSealProof(abi.RegisteredSealProof(99))
+	assert.Error(t, err)
+
+	p, err := abi.SealProofForSize(abi.SectorSize32GiB, abi.SealProofVariantV1_1)
+	require.NoError(t, err)
+	assert.Equal(t, abi.RegisteredSealProof_StackedDrg32GiBV2, p)
+	_, err = abi.SealProofForSize(abi.SectorSize32GiB+1, abi.SealProofVariantV1_1)
+	assert.Error(t, err)
+}
+
+func TestSyntheticSealProofString(t *testing.T) {
+	p, err := abi.SealProofFromString("32GiB/v1_1_synth")
+	require.NoError(t, err)
+	assert.Equal(t, abi.RegisteredSealProof_StackedDrg32GiBV1_1_Feat_SyntheticPoRep, p)
+	assert.True(t, p.IsSynthetic())
+	assert.False(t, abi.RegisteredSealProof_StackedDrg32GiBV2.IsSynthetic())
+
+	c, ok := abi.GetProofCapability(p)
+	require.True(t, ok)
+	assert.True(t, c.Synthetic)
+	assert.True(t, c.Snap)
+	assert.False(t, abi.SealProofAllowedForOnboarding(p, abi.PresetMainnet, network.Version20))
+	assert.True(t, abi.SealProofAllowedForOnboarding(p, abi.PresetMainnet, network.Version21))
+}
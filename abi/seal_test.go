@@ -0,0 +1,37 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestSealRandomnessEpochs(t *testing.T) {
+	curr := abi.ChainEpoch(10000)
+	assert.Equal(t, abi.ChainEpoch(9100), abi.SealRandomEpoch(curr))
+	assert.Equal(t, abi.ChainEpoch(6220), abi.EarliestSealRandomEpoch(curr))
+	assert.Equal(t, abi.ChainEpoch(10150), abi.InteractiveSealChallengeEpoch(curr, network.Version21))
+	assert.Equal(t, abi.ChainEpoch(150), abi.PreCommitChallengeDelay(network.Version0))
+
+	// The epoch sealers sample is always accepted.
+	assert.NoError(t, abi.ValidateSealRandEpoch(abi.SealRandomEpoch(curr), curr))
+}
+
+func TestValidateSealRandEpoch(t *testing.T) {
+	curr := abi.ChainEpoch(10000)
+	earliest := curr - abi.MaxPreCommitRandomnessLookback
+
+	// Too old.
+	assert.Error(t, abi.ValidateSealRandEpoch(earliest-1, curr))
+	assert.Error(t, abi.ValidateSealRandEpoch(0, curr))
+	// Exactly at the earliest accepted epoch.
+	assert.NoError(t, abi.ValidateSealRandEpoch(earliest, curr))
+	// Exactly at the latest accepted epoch, just before now.
+	assert.NoError(t, abi.ValidateSealRandEpoch(curr-1, curr))
+	// Too recent.
+	assert.Error(t, abi.ValidateSealRandEpoch(curr, curr))
+	assert.Error(t, abi.ValidateSealRandEpoch(curr+1, curr))
+}
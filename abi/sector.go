@@ -22,18 +22,43 @@ func (s SectorNumber) String() string {
 const MaxSectorNumber = math.MaxInt64
 
 // SectorSize indicates one of a set of possible sizes in the network.
-// Ideally, SectorSize would be an enum
-// type SectorSize enum {
-//   1KiB = 1024
-//   1MiB = 1048576
-//   1GiB = 1073741824
-//   1TiB = 1099511627776
-//   1PiB = 1125899906842624
-//   1EiB = 1152921504606846976
-//   max  = 18446744073709551615
-// }
+// The sizes supported by some registered proof are enumerated below. Switches over a SectorSize should
+// handle each of these constants, and may use Known to reject any other value.
 type SectorSize uint64
 
+const (
+	SectorSize2KiB   = SectorSize(2 << 10)
+	SectorSize8MiB   = SectorSize(8 << 20)
+	SectorSize512MiB = SectorSize(512 << 20)
+	SectorSize32GiB  = SectorSize(32 << 30)
+	SectorSize64GiB  = SectorSize(64 << 30)
+)
+
+// The canonical set of sector sizes, in ascending order.
+var knownSectorSizes = [...]SectorSize{
+	SectorSize2KiB,
+	SectorSize8MiB,
+	SectorSize512MiB,
+	SectorSize32GiB,
+	SectorSize64GiB,
+}
+
+// KnownSectorSizes returns the canonical set of sector sizes, in ascending order.
+func KnownSectorSizes() []SectorSize {
+	sizes := knownSectorSizes
+	return sizes[:]
+}
+
+// Known returns whether the size is one of the canonical sector sizes.
+func (s SectorSize) Known() bool {
+	switch s {
+	case SectorSize2KiB, SectorSize8MiB, SectorSize512MiB, SectorSize32GiB, SectorSize64GiB:
+		return true
+	default:
+		return false
+	}
+}
+
 // Formats the size as a decimal string.
 func (s SectorSize) String() string {
 	return strconv.FormatUint(uint64(s), 10)
@@ -79,6 +104,23 @@ const (
 	RegisteredSealProof_StackedDrg512MiBV2 = RegisteredSealProof(7)
 	RegisteredSealProof_StackedDrg32GiBV2  = RegisteredSealProof(8)
 	RegisteredSealProof_StackedDrg64GiBV2  = RegisteredSealProof(9)
+
+	// Synthetic PoRep, for which the sealer precomputes proofs for a large set of synthetic challenges, and
+	// discards the layers before the interactive challenges are known. The interactive proof is then assembled
+	// from the precomputed proofs. Values 15-17 are reserved for other proof features.
+	RegisteredSealProof_StackedDrg2KiBV1_1_Feat_SyntheticPoRep   = RegisteredSealProof(10)
+	RegisteredSealProof_StackedDrg8MiBV1_1_Feat_SyntheticPoRep   = RegisteredSealProof(11)
+	RegisteredSealProof_StackedDrg512MiBV1_1_Feat_SyntheticPoRep = RegisteredSealProof(12)
+	RegisteredSealProof_StackedDrg32GiBV1_1_Feat_SyntheticPoRep  = RegisteredSealProof(13)
+	RegisteredSealProof_StackedDrg64GiBV1_1_Feat_SyntheticPoRep  = RegisteredSealProof(14)
+
+	// Non-interactive PoRep (NI-PoRep), for which challenges are derived from the seal randomness alone, so a
+	// sector can be proven in a single message without a pre-commit.
+	RegisteredSealProof_StackedDrg2KiBV1_2_Feat_NiPoRep   = RegisteredSealProof(18)
+	RegisteredSealProof_StackedDrg8MiBV1_2_Feat_NiPoRep   = RegisteredSealProof(19)
+	RegisteredSealProof_StackedDrg512MiBV1_2_Feat_NiPoRep = RegisteredSealProof(20)
+	RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep  = RegisteredSealProof(21)
+	RegisteredSealProof_StackedDrg64GiBV1_2_Feat_NiPoRep  = RegisteredSealProof(22)
 )
 
 type RegisteredPoStProof int64
@@ -108,64 +150,142 @@ const (
 
 // Metadata about a seal proof type.
 type SealProofInfo struct {
-	SectorSize                 SectorSize
-	WinningPoStProof           RegisteredPoStProof
-	WindowPoStProof            RegisteredPoStProof
+	SectorSize       SectorSize
+	WinningPoStProof RegisteredPoStProof
+	WindowPoStProof  RegisteredPoStProof
 }
 
 var SealProofInfos = map[RegisteredSealProof]*SealProofInfo{
 	RegisteredSealProof_StackedDrg2KiBV1: {
-		SectorSize:                 2 << 10,
-		WinningPoStProof:           RegisteredPoStProof_StackedDrgWinning2KiBV1,
-		WindowPoStProof:            RegisteredPoStProof_StackedDrgWindow2KiBV1,
+		SectorSize:       SectorSize2KiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning2KiBV1,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow2KiBV1,
 	},
 	RegisteredSealProof_StackedDrg8MiBV1: {
-		SectorSize:                 8 << 20,
-		WinningPoStProof:           RegisteredPoStProof_StackedDrgWinning8MiBV1,
-		WindowPoStProof:            RegisteredPoStProof_StackedDrgWindow8MiBV1,
+		SectorSize:       SectorSize8MiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning8MiBV1,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow8MiBV1,
 	},
 	RegisteredSealProof_StackedDrg512MiBV1: {
-		SectorSize:                 512 << 20,
-		WinningPoStProof:           RegisteredPoStProof_StackedDrgWinning512MiBV1,
-		WindowPoStProof:            RegisteredPoStProof_StackedDrgWindow512MiBV1,
+		SectorSize:       SectorSize512MiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning512MiBV1,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow512MiBV1,
 	},
 	RegisteredSealProof_StackedDrg32GiBV1: {
-		SectorSize:                 32 << 30,
-		WinningPoStProof:           RegisteredPoStProof_StackedDrgWinning32GiBV1,
-		WindowPoStProof:            RegisteredPoStProof_StackedDrgWindow32GiBV1,
+		SectorSize:       SectorSize32GiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning32GiBV1,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow32GiBV1,
 	},
 	RegisteredSealProof_StackedDrg64GiBV1: {
-		SectorSize:                 64 << 30,
-		WinningPoStProof:           RegisteredPoStProof_StackedDrgWinning64GiBV1,
-		WindowPoStProof:            RegisteredPoStProof_StackedDrgWindow64GiBV1,
+		SectorSize:       SectorSize64GiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning64GiBV1,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow64GiBV1,
 	},
-        RegisteredSealProof_StackedDrg2KiBV2: {
-		SectorSize:                 2 << 10,
-		WinningPoStProof:           RegisteredPoStProof_StackedDrgWinning2KiBV2,
-		WindowPoStProof:            RegisteredPoStProof_StackedDrgWindow2KiBV2,
+	RegisteredSealProof_StackedDrg2KiBV2: {
+		SectorSize:       SectorSize2KiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning2KiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow2KiBV2,
 	},
 	RegisteredSealProof_StackedDrg8MiBV2: {
-		SectorSize:                 8 << 20,
-		WinningPoStProof:           RegisteredPoStProof_StackedDrgWinning8MiBV2,
-		WindowPoStProof:            RegisteredPoStProof_StackedDrgWindow8MiBV2,
+		SectorSize:       SectorSize8MiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning8MiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow8MiBV2,
 	},
 	RegisteredSealProof_StackedDrg512MiBV2: {
-		SectorSize:                 512 << 20,
-		WinningPoStProof:           RegisteredPoStProof_StackedDrgWinning512MiBV2,
-		WindowPoStProof:            RegisteredPoStProof_StackedDrgWindow512MiBV2,
+		SectorSize:       SectorSize512MiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning512MiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow512MiBV2,
 	},
 	RegisteredSealProof_StackedDrg32GiBV2: {
-		SectorSize:                 32 << 30,
-		WinningPoStProof:           RegisteredPoStProof_StackedDrgWinning32GiBV2,
-		WindowPoStProof:            RegisteredPoStProof_StackedDrgWindow32GiBV2,
+		SectorSize:       SectorSize32GiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning32GiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow32GiBV2,
 	},
 	RegisteredSealProof_StackedDrg64GiBV2: {
-		SectorSize:                 64 << 30,
-		WinningPoStProof:           RegisteredPoStProof_StackedDrgWinning64GiBV2,
-		WindowPoStProof:            RegisteredPoStProof_StackedDrgWindow64GiBV2,
+		SectorSize:       SectorSize64GiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning64GiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow64GiBV2,
+	},
+	RegisteredSealProof_StackedDrg2KiBV1_1_Feat_SyntheticPoRep: {
+		SectorSize:       SectorSize2KiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning2KiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow2KiBV2,
+	},
+	RegisteredSealProof_StackedDrg8MiBV1_1_Feat_SyntheticPoRep: {
+		SectorSize:       SectorSize8MiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning8MiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow8MiBV2,
+	},
+	RegisteredSealProof_StackedDrg512MiBV1_1_Feat_SyntheticPoRep: {
+		SectorSize:       SectorSize512MiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning512MiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow512MiBV2,
+	},
+	RegisteredSealProof_StackedDrg32GiBV1_1_Feat_SyntheticPoRep: {
+		SectorSize:       SectorSize32GiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning32GiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow32GiBV2,
+	},
+	RegisteredSealProof_StackedDrg64GiBV1_1_Feat_SyntheticPoRep: {
+		SectorSize:       SectorSize64GiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning64GiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow64GiBV2,
+	},
+	RegisteredSealProof_StackedDrg2KiBV1_2_Feat_NiPoRep: {
+		SectorSize:       SectorSize2KiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning2KiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow2KiBV2,
+	},
+	RegisteredSealProof_StackedDrg8MiBV1_2_Feat_NiPoRep: {
+		SectorSize:       SectorSize8MiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning8MiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow8MiBV2,
+	},
+	RegisteredSealProof_StackedDrg512MiBV1_2_Feat_NiPoRep: {
+		SectorSize:       SectorSize512MiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning512MiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow512MiBV2,
+	},
+	RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep: {
+		SectorSize:       SectorSize32GiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning32GiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow32GiBV2,
+	},
+	RegisteredSealProof_StackedDrg64GiBV1_2_Feat_NiPoRep: {
+		SectorSize:       SectorSize64GiB,
+		WinningPoStProof: RegisteredPoStProof_StackedDrgWinning64GiBV2,
+		WindowPoStProof:  RegisteredPoStProof_StackedDrgWindow64GiBV2,
 	},
 }
 
+// IsSynthetic returns whether the proof type is a synthetic PoRep.
+func (p RegisteredSealProof) IsSynthetic() bool {
+	switch p {
+	case RegisteredSealProof_StackedDrg2KiBV1_1_Feat_SyntheticPoRep,
+		RegisteredSealProof_StackedDrg8MiBV1_1_Feat_SyntheticPoRep,
+		RegisteredSealProof_StackedDrg512MiBV1_1_Feat_SyntheticPoRep,
+		RegisteredSealProof_StackedDrg32GiBV1_1_Feat_SyntheticPoRep,
+		RegisteredSealProof_StackedDrg64GiBV1_1_Feat_SyntheticPoRep:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsNonInteractive returns whether the proof type is a non-interactive PoRep.
+func (p RegisteredSealProof) IsNonInteractive() bool {
+	switch p {
+	case RegisteredSealProof_StackedDrg2KiBV1_2_Feat_NiPoRep,
+		RegisteredSealProof_StackedDrg8MiBV1_2_Feat_NiPoRep,
+		RegisteredSealProof_StackedDrg512MiBV1_2_Feat_NiPoRep,
+		RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep,
+		RegisteredSealProof_StackedDrg64GiBV1_2_Feat_NiPoRep:
+		return true
+	default:
+		return false
+	}
+}
+
 func (p RegisteredSealProof) SectorSize() (SectorSize, error) {
 	info, ok := SealProofInfos[p]
 	if !ok {
@@ -234,6 +354,63 @@ func (p RegisteredPoStProof) SectorSize() (SectorSize, error) {
 	return sp.SectorSize()
 }
 
+// Identifies the scheme by which many seal proofs are aggregated into one.
+type RegisteredAggregationProof int64
+
+const (
+	RegisteredAggregationProof_SnarkPackV1 = RegisteredAggregationProof(0)
+	// Required for aggregating non-interactive PoReps.
+	RegisteredAggregationProof_SnarkPackV2 = RegisteredAggregationProof(1)
+)
+
+// RegisteredUpdateProof identifies the proof type of a replica update (snap deal), which replaces the data
+// in a committed-capacity sector without resealing it.
+type RegisteredUpdateProof int64
+
+const (
+	RegisteredUpdateProof_StackedDrg2KiBV1   = RegisteredUpdateProof(0)
+	RegisteredUpdateProof_StackedDrg8MiBV1   = RegisteredUpdateProof(1)
+	RegisteredUpdateProof_StackedDrg512MiBV1 = RegisteredUpdateProof(2)
+	RegisteredUpdateProof_StackedDrg32GiBV1  = RegisteredUpdateProof(3)
+	RegisteredUpdateProof_StackedDrg64GiBV1  = RegisteredUpdateProof(4)
+)
+
+var updateProofSectorSizes = map[RegisteredUpdateProof]SectorSize{
+	RegisteredUpdateProof_StackedDrg2KiBV1:   SectorSize2KiB,
+	RegisteredUpdateProof_StackedDrg8MiBV1:   SectorSize8MiB,
+	RegisteredUpdateProof_StackedDrg512MiBV1: SectorSize512MiB,
+	RegisteredUpdateProof_StackedDrg32GiBV1:  SectorSize32GiB,
+	RegisteredUpdateProof_StackedDrg64GiBV1:  SectorSize64GiB,
+}
+
+// SectorSize returns the size of sectors updated with this proof type.
+func (p RegisteredUpdateProof) SectorSize() (SectorSize, error) {
+	ss, ok := updateProofSectorSizes[p]
+	if !ok {
+		return 0, xerrors.Errorf("unsupported update proof type: %v", p)
+	}
+	return ss, nil
+}
+
+// RegisteredUpdateProof returns the proof type with which a sector sealed with this proof type may be updated.
+// Only sectors sealed with the V2 (V1_1) proofs may be updated.
+func (p RegisteredSealProof) RegisteredUpdateProof() (RegisteredUpdateProof, error) {
+	switch p {
+	case RegisteredSealProof_StackedDrg2KiBV2, RegisteredSealProof_StackedDrg2KiBV1_1_Feat_SyntheticPoRep:
+		return RegisteredUpdateProof_StackedDrg2KiBV1, nil
+	case RegisteredSealProof_StackedDrg8MiBV2, RegisteredSealProof_StackedDrg8MiBV1_1_Feat_SyntheticPoRep:
+		return RegisteredUpdateProof_StackedDrg8MiBV1, nil
+	case RegisteredSealProof_StackedDrg512MiBV2, RegisteredSealProof_StackedDrg512MiBV1_1_Feat_SyntheticPoRep:
+		return RegisteredUpdateProof_StackedDrg512MiBV1, nil
+	case RegisteredSealProof_StackedDrg32GiBV2, RegisteredSealProof_StackedDrg32GiBV1_1_Feat_SyntheticPoRep:
+		return RegisteredUpdateProof_StackedDrg32GiBV1, nil
+	case RegisteredSealProof_StackedDrg64GiBV2, RegisteredSealProof_StackedDrg64GiBV1_1_Feat_SyntheticPoRep:
+		return RegisteredUpdateProof_StackedDrg64GiBV1, nil
+	default:
+		return 0, xerrors.Errorf("seal proof type %v does not support updates", p)
+	}
+}
+
 type SealRandomness Randomness
 type InteractiveSealRandomness Randomness
 type PoStRandomness Randomness
@@ -0,0 +1,109 @@
+package abi
+
+// SectorIDSet is a set of sector IDs, grouped by miner into a set of sector numbers for each miner.
+// The sector numbers of each miner convert directly to a bitfield, as expected by the miner actor.
+// The zero value is an empty set. Sets are immutable: operations return new sets.
+type SectorIDSet struct {
+	// Non-empty sets of sector numbers by miner.
+	byMiner map[ActorID]SectorRangeSet
+}
+
+// NewSectorIDSet returns the set of the given sector IDs, which may be in any order and contain duplicates.
+func NewSectorIDSet(ids ...SectorID) SectorIDSet {
+	numbers := map[ActorID][]SectorNumber{}
+	for _, id := range ids {
+		numbers[id.Miner] = append(numbers[id.Miner], id.Number)
+	}
+	byMiner := make(map[ActorID]SectorRangeSet, len(numbers))
+	for miner, nums := range numbers {
+		byMiner[miner] = SectorRangeSetFromNumbers(nums...)
+	}
+	return SectorIDSet{byMiner: byMiner}
+}
+
+// SectorIDSetFromBitfields returns the set of sectors of each miner in a map. Empty sets are ignored.
+func SectorIDSetFromBitfields(bitfields map[ActorID]SectorRangeSet) SectorIDSet {
+	byMiner := make(map[ActorID]SectorRangeSet, len(bitfields))
+	for miner, numbers := range bitfields {
+		if !numbers.IsEmpty() {
+			byMiner[miner] = numbers
+		}
+	}
+	return SectorIDSet{byMiner: byMiner}
+}
+
+// Count returns the number of sector IDs in the set.
+func (s SectorIDSet) Count() uint64 {
+	var count uint64
+	for _, numbers := range s.byMiner {
+		count += numbers.Count()
+	}
+	return count
+}
+
+// IsEmpty returns whether the set is empty.
+func (s SectorIDSet) IsEmpty() bool {
+	return len(s.byMiner) == 0
+}
+
+// Has returns whether a sector ID is in the set.
+func (s SectorIDSet) Has(id SectorID) bool {
+	return s.byMiner[id.Miner].Has(id.Number)
+}
+
+// Miners returns the miners with sectors in the set, in ascending order.
+func (s SectorIDSet) Miners() []ActorID {
+	miners := make([]ActorID, 0, len(s.byMiner))
+	for miner := range s.byMiner {
+		miners = append(miners, miner)
+	}
+	SortActorIDs(miners)
+	return miners
+}
+
+// Sectors returns the set of sector numbers of a miner, which is empty if the miner has no sectors in the set.
+func (s SectorIDSet) Sectors(miner ActorID) SectorRangeSet {
+	return s.byMiner[miner]
+}
+
+// Bitfields returns the set of sector numbers of each miner with sectors in the set.
+func (s SectorIDSet) Bitfields() map[ActorID]SectorRangeSet {
+	out := make(map[ActorID]SectorRangeSet, len(s.byMiner))
+	for miner, numbers := range s.byMiner {
+		out[miner] = numbers
+	}
+	return out
+}
+
+// Union returns the set of sector IDs in either set.
+func (s SectorIDSet) Union(o SectorIDSet) SectorIDSet {
+	byMiner := make(map[ActorID]SectorRangeSet, len(s.byMiner)+len(o.byMiner))
+	for miner, numbers := range s.byMiner {
+		byMiner[miner] = numbers
+	}
+	for miner, numbers := range o.byMiner {
+		byMiner[miner] = byMiner[miner].Union(numbers)
+	}
+	return SectorIDSet{byMiner: byMiner}
+}
+
+// ForEachMiner calls cb with the sector numbers of each miner in the set in ascending order of miner,
+// stopping at the first error.
+func (s SectorIDSet) ForEachMiner(cb func(miner ActorID, numbers SectorRangeSet) error) error {
+	for _, miner := range s.Miners() {
+		if err := cb(miner, s.byMiner[miner]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ForEach calls cb for each sector ID in the set in ascending order of miner and then sector number,
+// stopping at the first error.
+func (s SectorIDSet) ForEach(cb func(id SectorID) error) error {
+	return s.ForEachMiner(func(miner ActorID, numbers SectorRangeSet) error {
+		return numbers.ForEach(func(n SectorNumber) error {
+			return cb(SectorID{Miner: miner, Number: n})
+		})
+	})
+}
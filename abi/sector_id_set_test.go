@@ -0,0 +1,53 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestSectorIDSet(t *testing.T) {
+	s := abi.NewSectorIDSet(
+		abi.SectorID{Miner: 1002, Number: 7},
+		abi.SectorID{Miner: 1000, Number: 3},
+		abi.SectorID{Miner: 1000, Number: 1},
+		abi.SectorID{Miner: 1000, Number: 2},
+		abi.SectorID{Miner: 1000, Number: 2},
+	)
+	assert.Equal(t, uint64(4), s.Count())
+	assert.False(t, s.IsEmpty())
+	assert.True(t, s.Has(abi.SectorID{Miner: 1000, Number: 2}))
+	assert.False(t, s.Has(abi.SectorID{Miner: 1002, Number: 2}))
+	assert.False(t, s.Has(abi.SectorID{Miner: 1001, Number: 2}))
+	assert.Equal(t, []abi.ActorID{1000, 1002}, s.Miners())
+	assert.Equal(t, []abi.SectorRange{{Start: 1, End: 4}}, s.Sectors(1000).Ranges())
+	assert.True(t, s.Sectors(1001).IsEmpty())
+
+	merged := s.Union(abi.NewSectorIDSet(abi.SectorID{Miner: 1002, Number: 8}, abi.SectorID{Miner: 1001, Number: 0}))
+	assert.Equal(t, uint64(4), s.Count())
+	assert.Equal(t, uint64(6), merged.Count())
+
+	var visited []abi.SectorID
+	require.NoError(t, merged.ForEach(func(id abi.SectorID) error {
+		visited = append(visited, id)
+		return nil
+	}))
+	assert.Equal(t, []abi.SectorID{
+		{Miner: 1000, Number: 1}, {Miner: 1000, Number: 2}, {Miner: 1000, Number: 3},
+		{Miner: 1001, Number: 0},
+		{Miner: 1002, Number: 7}, {Miner: 1002, Number: 8},
+	}, visited)
+
+	bitfields := merged.Bitfields()
+	assert.Len(t, bitfields, 3)
+	assert.Equal(t, []abi.SectorRange{{Start: 7, End: 9}}, bitfields[1002].Ranges())
+	bitfields[1003] = abi.SectorRangeSet{}
+	assert.Equal(t, merged, abi.SectorIDSetFromBitfields(bitfields))
+
+	var empty abi.SectorIDSet
+	assert.True(t, empty.IsEmpty())
+	assert.Equal(t, s, empty.Union(s))
+}
@@ -0,0 +1,63 @@
+package abi
+
+import "github.com/filecoin-project/go-state-types/big"
+
+// Quality multipliers for committed capacity, unverified deals and verified deals (relative to each other).
+// The quality of a sector is the space-time-weighted average of these over its content, relative to
+// QualityBaseMultiplier.
+const (
+	QualityBaseMultiplier        = 10
+	DealWeightMultiplier         = 10
+	VerifiedDealWeightMultiplier = 100
+)
+
+// Precision used for making quality-adjusted power calculations: quality is a fixed-point number with
+// this many fractional bits.
+const SectorQualityPrecision = 20
+
+// QualityMultiplierTable holds the sector quality multipliers and the precision of quality values.
+type QualityMultiplierTable struct {
+	Base         int64
+	Deal         int64
+	VerifiedDeal int64
+	// Number of fractional bits of a SectorQuality.
+	Precision uint
+}
+
+// QualityMultipliers returns the sector quality multipliers.
+func QualityMultipliers() QualityMultiplierTable {
+	return QualityMultiplierTable{
+		Base:         QualityBaseMultiplier,
+		Deal:         DealWeightMultiplier,
+		VerifiedDeal: VerifiedDealWeightMultiplier,
+		Precision:    SectorQualityPrecision,
+	}
+}
+
+// DealQualityMultiplier returns the quality multiplier of a deal's space-time.
+func DealQualityMultiplier(verified bool) int64 {
+	if verified {
+		return VerifiedDealWeightMultiplier
+	}
+	return DealWeightMultiplier
+}
+
+// BaseSectorQuality returns the quality of a sector with no deals, i.e. one in fixed-point.
+func BaseSectorQuality() SectorQuality {
+	return big.Lsh(big.NewInt(1), SectorQualityPrecision)
+}
+
+// QualityForWeight returns the quality of a sector, as a fixed-point number with SectorQualityPrecision
+// fractional bits, given its size, duration (from activation to expiration) and the space-time of its deals.
+func QualityForWeight(size SectorSize, duration ChainEpoch, dealWeight, verifiedWeight DealWeight) SectorQuality {
+	sectorSpaceTime := big.Mul(big.NewIntUnsigned(uint64(size)), big.NewInt(int64(duration)))
+	totalDealSpaceTime := big.Add(dealWeight, verifiedWeight)
+
+	weightedBaseSpaceTime := big.Mul(big.Sub(sectorSpaceTime, totalDealSpaceTime), big.NewInt(QualityBaseMultiplier))
+	weightedDealSpaceTime := big.Mul(dealWeight, big.NewInt(DealWeightMultiplier))
+	weightedVerifiedSpaceTime := big.Mul(verifiedWeight, big.NewInt(VerifiedDealWeightMultiplier))
+	weightedSumSpaceTime := big.Sum(weightedBaseSpaceTime, weightedDealSpaceTime, weightedVerifiedSpaceTime)
+	scaledUpWeightedSumSpaceTime := big.Lsh(weightedSumSpaceTime, SectorQualityPrecision)
+
+	return big.Div(big.Div(scaledUpWeightedSumSpaceTime, sectorSpaceTime), big.NewInt(QualityBaseMultiplier))
+}
@@ -0,0 +1,30 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+func TestQualityForWeight(t *testing.T) {
+	size := abi.SectorSize(32 << 30)
+	duration := abi.ChainEpoch(1000)
+	full := big.Mul(big.NewIntUnsigned(uint64(size)), big.NewInt(int64(duration)))
+	half := big.Div(full, big.NewInt(2))
+
+	assert.Equal(t, abi.BaseSectorQuality(), abi.QualityForWeight(size, duration, big.Zero(), big.Zero()))
+	assert.Equal(t, abi.BaseSectorQuality(), abi.QualityForWeight(size, duration, full, big.Zero()))
+	assert.Equal(t, big.Mul(abi.BaseSectorQuality(), big.NewInt(10)), abi.QualityForWeight(size, duration, big.Zero(), full))
+	// Half verified: (1 + 10) / 2.
+	assert.Equal(t, big.Div(big.Mul(abi.BaseSectorQuality(), big.NewInt(11)), big.NewInt(2)), abi.QualityForWeight(size, duration, big.Zero(), half))
+}
+
+func TestQualityMultipliers(t *testing.T) {
+	m := abi.QualityMultipliers()
+	assert.Equal(t, abi.QualityMultiplierTable{Base: 10, Deal: 10, VerifiedDeal: 100, Precision: 20}, m)
+	assert.Equal(t, m.Deal, abi.DealQualityMultiplier(false))
+	assert.Equal(t, m.VerifiedDeal, abi.DealQualityMultiplier(true))
+}
@@ -0,0 +1,396 @@
+package abi
+
+import (
+	"encoding/binary"
+	"io"
+	"sort"
+
+	"golang.org/x/xerrors"
+)
+
+// SectorRange is the half-open range of sector numbers [Start, End).
+type SectorRange struct {
+	Start SectorNumber
+	End   SectorNumber
+}
+
+// Len returns the number of sectors in the range.
+func (r SectorRange) Len() uint64 {
+	if r.End <= r.Start {
+		return 0
+	}
+	return uint64(r.End - r.Start)
+}
+
+// Contains returns whether a sector number is within the range.
+func (r SectorRange) Contains(n SectorNumber) bool {
+	return r.Start <= n && n < r.End
+}
+
+// SectorRangeSet is a set of sector numbers stored as a sorted list of maximal contiguous ranges.
+// Miners typically allocate sector numbers sequentially, so a set of millions of sectors is usually represented
+// by a handful of ranges.
+// The zero value is an empty set. Sets are immutable: operations return new sets.
+type SectorRangeSet struct {
+	// Non-empty, non-overlapping, non-adjacent ranges in ascending order.
+	ranges []SectorRange
+}
+
+// NewSectorRangeSet returns the set of sector numbers covered by any of the given ranges, which may
+// overlap and be in any order.
+func NewSectorRangeSet(ranges ...SectorRange) SectorRangeSet {
+	rs := make([]SectorRange, 0, len(ranges))
+	for _, r := range ranges {
+		if r.Len() > 0 {
+			rs = append(rs, r)
+		}
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i].Start < rs[j].Start })
+	return SectorRangeSet{ranges: coalesceRanges(rs)}
+}
+
+// SectorRangeSetFromNumbers returns the set of the given sector numbers, which may be in any order.
+func SectorRangeSetFromNumbers(nums ...SectorNumber) SectorRangeSet {
+	sorted := make([]SectorNumber, len(nums))
+	copy(sorted, nums)
+	SortSectorNumbers(sorted)
+	ranges := make([]SectorRange, 0, 1)
+	for _, n := range sorted {
+		if last := len(ranges) - 1; last >= 0 && ranges[last].End >= n {
+			if ranges[last].End == n {
+				ranges[last].End++
+			}
+			continue
+		}
+		ranges = append(ranges, SectorRange{Start: n, End: n + 1})
+	}
+	return SectorRangeSet{ranges: ranges}
+}
+
+// Merges overlapping or adjacent ranges, which must be sorted by start.
+func coalesceRanges(sorted []SectorRange) []SectorRange {
+	out := sorted[:0]
+	for _, r := range sorted {
+		if last := len(out) - 1; last >= 0 && out[last].End >= r.Start {
+			if r.End > out[last].End {
+				out[last].End = r.End
+			}
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// Ranges returns the maximal contiguous ranges of the set, in ascending order.
+func (s SectorRangeSet) Ranges() []SectorRange {
+	out := make([]SectorRange, len(s.ranges))
+	copy(out, s.ranges)
+	return out
+}
+
+// Count returns the number of sector numbers in the set.
+func (s SectorRangeSet) Count() uint64 {
+	var count uint64
+	for _, r := range s.ranges {
+		count += r.Len()
+	}
+	return count
+}
+
+// IsEmpty returns whether the set is empty.
+func (s SectorRangeSet) IsEmpty() bool {
+	return len(s.ranges) == 0
+}
+
+// Has returns whether a sector number is in the set.
+func (s SectorRangeSet) Has(n SectorNumber) bool {
+	i := sort.Search(len(s.ranges), func(i int) bool { return s.ranges[i].End > n })
+	return i < len(s.ranges) && s.ranges[i].Contains(n)
+}
+
+// ForEach calls cb for each sector number in the set in ascending order, stopping at the first error.
+func (s SectorRangeSet) ForEach(cb func(n SectorNumber) error) error {
+	for _, r := range s.ranges {
+		for n := r.Start; n < r.End; n++ {
+			if err := cb(n); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Union returns the set of sector numbers in either set.
+func (s SectorRangeSet) Union(o SectorRangeSet) SectorRangeSet {
+	merged := make([]SectorRange, 0, len(s.ranges)+len(o.ranges))
+	i, j := 0, 0
+	for i < len(s.ranges) || j < len(o.ranges) {
+		if j == len(o.ranges) || (i < len(s.ranges) && s.ranges[i].Start <= o.ranges[j].Start) {
+			merged = append(merged, s.ranges[i])
+			i++
+		} else {
+			merged = append(merged, o.ranges[j])
+			j++
+		}
+	}
+	return SectorRangeSet{ranges: coalesceRanges(merged)}
+}
+
+// Difference returns the set of sector numbers in s but not in o.
+func (s SectorRangeSet) Difference(o SectorRangeSet) SectorRangeSet {
+	var out []SectorRange
+	j := 0
+	for _, r := range s.ranges {
+		// Skip subtrahend ranges entirely before this one.
+		for j < len(o.ranges) && o.ranges[j].End <= r.Start {
+			j++
+		}
+		// Cut out each subtrahend range overlapping this one.
+		k := j
+		for k < len(o.ranges) && o.ranges[k].Start < r.End {
+			if o.ranges[k].Start > r.Start {
+				out = append(out, SectorRange{Start: r.Start, End: o.ranges[k].Start})
+			}
+			if o.ranges[k].End > r.Start {
+				r.Start = o.ranges[k].End
+			}
+			if r.Start >= r.End {
+				break
+			}
+			k++
+		}
+		if r.Start < r.End {
+			out = append(out, r)
+		}
+	}
+	return SectorRangeSet{ranges: out}
+}
+
+// Intersect returns the set of sector numbers in both sets.
+func (s SectorRangeSet) Intersect(o SectorRangeSet) SectorRangeSet {
+	var out []SectorRange
+	i, j := 0, 0
+	for i < len(s.ranges) && j < len(o.ranges) {
+		a, b := s.ranges[i], o.ranges[j]
+		start, end := a.Start, a.End
+		if b.Start > start {
+			start = b.Start
+		}
+		if b.End < end {
+			end = b.End
+		}
+		if start < end {
+			out = append(out, SectorRange{Start: start, End: end})
+		}
+		if a.End < b.End {
+			i++
+		} else {
+			j++
+		}
+	}
+	return SectorRangeSet{ranges: out}
+}
+
+//
+// Conversion to and from bitfields.
+//
+// A bitfield is serialized as RLE+: a two-bit version (zero), one bit giving the value of the first run,
+// then the length of each run of alternating values. Bits are packed into bytes least significant first.
+// A run length is encoded as a single 1 bit for a run of length one, a 01 prefix followed by 4 bits for a run
+// shorter than 16, or a 00 prefix followed by a LEB128 varint (8 bits per byte) otherwise.
+// Run lengths must use the shortest form, and the only zero-length run is that read from the zero bits padding
+// the final byte. The encoding of the empty set is empty, and trailing zero bytes are not permitted.
+//
+
+// Maximum length in bytes of an RLE+ bitfield accepted for decoding.
+const MaxRLEPlusLength = 32 << 10
+
+// RLEPlus returns the RLE+ serialization of the set, as found in the CBOR encoding of a bitfield.
+func (s SectorRangeSet) RLEPlus() []byte {
+	if len(s.ranges) == 0 {
+		return []byte{}
+	}
+	var w rleBitWriter
+	w.put(0, 2) // version
+	var next SectorNumber
+	if s.ranges[0].Start == 0 {
+		w.put(1, 1)
+	} else {
+		w.put(0, 1)
+	}
+	for _, r := range s.ranges {
+		if r.Start > next {
+			w.putRun(uint64(r.Start - next))
+		}
+		w.putRun(r.Len())
+		next = r.End
+	}
+	return w.out()
+}
+
+// SectorRangeSetFromRLEPlus decodes an RLE+ serialized bitfield.
+func SectorRangeSetFromRLEPlus(buf []byte) (SectorRangeSet, error) {
+	if len(buf) == 0 {
+		return SectorRangeSet{}, nil
+	}
+	if len(buf) > MaxRLEPlusLength {
+		return SectorRangeSet{}, xerrors.Errorf("bitfield length %d exceeds maximum %d", len(buf), MaxRLEPlusLength)
+	}
+	if buf[len(buf)-1] == 0 {
+		return SectorRangeSet{}, xerrors.Errorf("bitfield has trailing zero bytes")
+	}
+	r := rleBitReader{buf: buf}
+	if v := r.get(2); v != 0 {
+		return SectorRangeSet{}, xerrors.Errorf("invalid bitfield version %d", v)
+	}
+	val := r.get(1) == 1
+
+	var ranges []SectorRange
+	var pos uint64
+	for !r.done() {
+		runStart := r.pos
+		var runLen uint64
+		if r.get(1) == 1 {
+			runLen = 1
+		} else if r.get(1) == 1 {
+			runLen = r.get(4)
+			if runLen == 1 {
+				return SectorRangeSet{}, xerrors.Errorf("run of length 1 not minimally encoded")
+			}
+		} else {
+			var err error
+			if runLen, err = r.getVarint(); err != nil {
+				return SectorRangeSet{}, err
+			}
+			if runLen != 0 && runLen < 16 {
+				return SectorRangeSet{}, xerrors.Errorf("run of length %d not minimally encoded", runLen)
+			}
+		}
+		if runLen == 0 {
+			// Padding at the end of the final byte, which must be all zeros.
+			if !r.zeroFrom(runStart) {
+				return SectorRangeSet{}, xerrors.Errorf("zero-length run before end of bitfield")
+			}
+			break
+		}
+		if runLen > MaxSectorNumber-pos {
+			return SectorRangeSet{}, xerrors.Errorf("bitfield exceeds maximum sector number")
+		}
+		if val {
+			ranges = append(ranges, SectorRange{Start: SectorNumber(pos), End: SectorNumber(pos + runLen)})
+		}
+		pos += runLen
+		val = !val
+	}
+	return SectorRangeSet{ranges: ranges}, nil
+}
+
+// MarshalCBOR writes the set as a bitfield: a byte string of its RLE+ serialization.
+func (s *SectorRangeSet) MarshalCBOR(w io.Writer) error {
+	return WriteBoundedBytes(w, s.RLEPlus(), MaxRLEPlusLength)
+}
+
+// UnmarshalCBOR reads the set from a bitfield.
+func (s *SectorRangeSet) UnmarshalCBOR(r io.Reader) error {
+	b, err := ReadBoundedBytes(r, MaxRLEPlusLength)
+	if err != nil {
+		return err
+	}
+	*s, err = SectorRangeSetFromRLEPlus(b)
+	return err
+}
+
+type rleBitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+// Writes the low n bits of v (n <= 8).
+func (w *rleBitWriter) put(v byte, n uint) {
+	w.acc |= uint64(v&(1<<n-1)) << w.nbits
+	w.nbits += n
+	for w.nbits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.nbits -= 8
+	}
+}
+
+func (w *rleBitWriter) putRun(n uint64) {
+	switch {
+	case n == 1:
+		w.put(1, 1)
+	case n < 16:
+		w.put(2, 2)
+		w.put(byte(n), 4)
+	default:
+		w.put(0, 2)
+		var varint [binary.MaxVarintLen64]byte
+		size := binary.PutUvarint(varint[:], n)
+		for _, b := range varint[:size] {
+			w.put(b, 8)
+		}
+	}
+}
+
+func (w *rleBitWriter) out() []byte {
+	if w.nbits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+	}
+	// Trailing zero bits are implied, so trailing zero bytes are trimmed.
+	for len(w.buf) > 0 && w.buf[len(w.buf)-1] == 0 {
+		w.buf = w.buf[:len(w.buf)-1]
+	}
+	return w.buf
+}
+
+type rleBitReader struct {
+	buf []byte
+	pos uint // bit offset
+}
+
+func (r *rleBitReader) done() bool {
+	return r.pos >= uint(len(r.buf))*8
+}
+
+// Returns whether every bit from a bit offset to the end of the buffer is zero.
+func (r *rleBitReader) zeroFrom(pos uint) bool {
+	for ; pos < uint(len(r.buf))*8; pos++ {
+		if r.buf[pos/8]&(1<<(pos%8)) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Reads n bits (n <= 8), reading zeros past the end of the buffer.
+func (r *rleBitReader) get(n uint) uint64 {
+	var v uint64
+	for i := uint(0); i < n; i++ {
+		if !r.done() && r.buf[r.pos/8]&(1<<(r.pos%8)) != 0 {
+			v |= 1 << i
+		}
+		r.pos++
+	}
+	return v
+}
+
+func (r *rleBitReader) getVarint() (uint64, error) {
+	var v uint64
+	for i := 0; i < binary.MaxVarintLen64; i++ {
+		b := r.get(8)
+		if i == binary.MaxVarintLen64-1 && b > 1 {
+			break
+		}
+		v |= (b & 0x7f) << (7 * uint(i))
+		if b&0x80 == 0 {
+			if i > 0 && b == 0 {
+				return 0, xerrors.Errorf("bitfield run length varint not minimally encoded")
+			}
+			return v, nil
+		}
+	}
+	return 0, xerrors.Errorf("bitfield run length overflows uint64")
+}
@@ -0,0 +1,89 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestSectorRangeSet(t *testing.T) {
+	s := abi.SectorRangeSetFromNumbers(5, 1, 2, 3, 3, 10, 11)
+	assert.Equal(t, []abi.SectorRange{{1, 4}, {5, 6}, {10, 12}}, s.Ranges())
+	assert.Equal(t, uint64(6), s.Count())
+	assert.True(t, s.Has(3))
+	assert.False(t, s.Has(4))
+	assert.True(t, s.Has(11))
+	assert.False(t, s.Has(12))
+
+	o := abi.NewSectorRangeSet(abi.SectorRange{Start: 4, End: 5}, abi.SectorRange{Start: 11, End: 20}, abi.SectorRange{Start: 15, End: 30})
+	assert.Equal(t, []abi.SectorRange{{4, 5}, {11, 30}}, o.Ranges())
+
+	assert.Equal(t, []abi.SectorRange{{1, 6}, {10, 30}}, s.Union(o).Ranges())
+	assert.Equal(t, []abi.SectorRange{{1, 4}, {5, 6}, {10, 11}}, s.Difference(o).Ranges())
+	assert.Equal(t, []abi.SectorRange{{20, 30}}, o.Difference(abi.NewSectorRangeSet(abi.SectorRange{Start: 0, End: 20})).Ranges())
+	assert.Equal(t, []abi.SectorRange{{11, 12}}, s.Intersect(o).Ranges())
+	assert.True(t, s.Difference(s).IsEmpty())
+
+	var visited []abi.SectorNumber
+	require.NoError(t, s.ForEach(func(n abi.SectorNumber) error {
+		visited = append(visited, n)
+		return nil
+	}))
+	assert.Equal(t, []abi.SectorNumber{1, 2, 3, 5, 10, 11}, visited)
+}
+
+func TestSectorRangeSetRLEPlus(t *testing.T) {
+	testCases := []struct {
+		set     abi.SectorRangeSet
+		encoded []byte
+	}{
+		{abi.SectorRangeSet{}, []byte{}},
+		// version 00, first run 1, run of 1: 1.
+		{abi.SectorRangeSetFromNumbers(0), []byte{0x0c}},
+		// version 00, first run 0, run of 1, run of 1.
+		{abi.SectorRangeSetFromNumbers(1), []byte{0x18}},
+		// version 00, first run 1, run of 4 (01 0010).
+		{abi.NewSectorRangeSet(abi.SectorRange{Start: 0, End: 4}), []byte{0x94}},
+	}
+	for _, tc := range testCases {
+		assert.Equal(t, tc.encoded, tc.set.RLEPlus())
+		decoded, err := abi.SectorRangeSetFromRLEPlus(tc.encoded)
+		require.NoError(t, err)
+		assert.Equal(t, tc.set.Ranges(), decoded.Ranges())
+	}
+
+	s := abi.NewSectorRangeSet(
+		abi.SectorRange{Start: 3, End: 4},
+		abi.SectorRange{Start: 6, End: 20},
+		abi.SectorRange{Start: 1000, End: 1_000_000},
+		abi.SectorRange{Start: abi.MaxSectorNumber - 1, End: abi.MaxSectorNumber},
+	)
+	decoded, err := abi.SectorRangeSetFromRLEPlus(s.RLEPlus())
+	require.NoError(t, err)
+	assert.Equal(t, s.Ranges(), decoded.Ranges())
+
+	_, err = abi.SectorRangeSetFromRLEPlus([]byte{0x0c, 0x00})
+	assert.Error(t, err)
+	_, err = abi.SectorRangeSetFromRLEPlus([]byte{0x0d})
+	assert.Error(t, err)
+
+	// A run of 20 in the varint form.
+	decoded, err = abi.SectorRangeSetFromRLEPlus([]byte{0x84, 0x22})
+	require.NoError(t, err)
+	assert.Equal(t, []abi.SectorRange{{Start: 0, End: 20}}, decoded.Ranges())
+
+	nonCanonical := map[string][]byte{
+		"run of 1 in the short form":  {0x34},
+		"run of 5 in the varint form": {0xa4, 0x20},
+		"non-minimal varint":          {0x84, 0x12, 0x20},
+		"zero-length short run":       {0x2c, 0x04},
+		"zero-length varint run":      {0x0c, 0x40},
+	}
+	for name, b := range nonCanonical {
+		_, err := abi.SectorRangeSetFromRLEPlus(b)
+		assert.Error(t, err, name)
+	}
+}
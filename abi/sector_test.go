@@ -36,3 +36,26 @@ func TestSectorSizeShortString(t *testing.T) {
 	assert.Equal(t, "1EiB", abi.SectorSize(pib*kib).ShortString())
 	assert.Equal(t, "10EiB", abi.SectorSize(pib*kib*10).ShortString())
 }
+
+func TestKnownSectorSizes(t *testing.T) {
+	sizes := abi.KnownSectorSizes()
+	assert.Equal(t, []abi.SectorSize{
+		abi.SectorSize2KiB, abi.SectorSize8MiB, abi.SectorSize512MiB, abi.SectorSize32GiB, abi.SectorSize64GiB,
+	}, sizes)
+	for _, s := range sizes {
+		assert.True(t, s.Known(), s.ShortString())
+	}
+	assert.Equal(t, "32GiB", abi.SectorSize32GiB.ShortString())
+
+	// Mutating the returned slice does not affect the canonical set.
+	sizes[0] = 1
+	assert.Equal(t, abi.SectorSize2KiB, abi.KnownSectorSizes()[0])
+
+	assert.False(t, abi.SectorSize(0).Known())
+	assert.False(t, abi.SectorSize(1024).Known())
+	assert.False(t, abi.SectorSize(abi.SectorSize32GiB+1).Known())
+
+	for p, info := range abi.SealProofInfos {
+		assert.True(t, info.SectorSize.Known(), "proof %d", p)
+	}
+}
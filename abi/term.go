@@ -0,0 +1,88 @@
+package abi
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+// Term policy for verified allocations and storage deals. These must match the values used by the verified
+// registry and market actors at the network version in question.
+
+// Number of epochs in a day, at 30 seconds per epoch.
+const epochsInDay = ChainEpoch(2880)
+
+// Minimum term, in epochs, of a verified allocation (and hence of the claim made for it).
+const MinimumVerifiedAllocationTerm = 180 * epochsInDay
+
+// Maximum term, in epochs, of a verified allocation (and hence of the claim made for it).
+const MaximumVerifiedAllocationTerm = 5 * 365 * epochsInDay
+
+// Maximum delay, in epochs, between creating a verified allocation and its expiration.
+const MaximumVerifiedAllocationExpiration = 60 * epochsInDay
+
+// Minimum duration, in epochs, of a storage deal.
+const DealMinDuration = 180 * epochsInDay
+
+// Maximum duration, in epochs, of a storage deal before network version 21.
+const DealMaxDurationV0 = 540 * epochsInDay
+
+// Maximum duration, in epochs, of a storage deal from network version 21.
+const DealMaxDurationV21 = 1278 * epochsInDay
+
+// ClaimTerm is the term of a verified allocation: the range of durations for which a provider may claim it,
+// and the epoch by which it must be claimed.
+type ClaimTerm struct {
+	// The minimum duration which the provider must commit to storing the data.
+	TermMin ChainEpoch
+	// The maximum duration for which the provider may earn quality-adjusted power for the data.
+	TermMax ChainEpoch
+	// The latest epoch by which the provider must commit the data.
+	Expiration ChainEpoch
+}
+
+// Validate checks the term of an allocation made at the current epoch against policy at a network version.
+// Verified allocations were introduced at network version 17.
+func (t ClaimTerm) Validate(currEpoch ChainEpoch, nv network.Version) error {
+	if nv < network.Version17 {
+		return xerrors.Errorf("verified allocations are not supported at network version %v", nv)
+	}
+	if t.TermMin < MinimumVerifiedAllocationTerm {
+		return xerrors.Errorf("minimum term %d below minimum %d", t.TermMin, MinimumVerifiedAllocationTerm)
+	}
+	if t.TermMax > MaximumVerifiedAllocationTerm {
+		return xerrors.Errorf("maximum term %d above maximum %d", t.TermMax, MaximumVerifiedAllocationTerm)
+	}
+	if t.TermMax < t.TermMin {
+		return xerrors.Errorf("maximum term %d below minimum term %d", t.TermMax, t.TermMin)
+	}
+	if t.Expiration <= currEpoch {
+		return xerrors.Errorf("expiration %d not after current epoch %d", t.Expiration, currEpoch)
+	}
+	if t.Expiration > currEpoch+MaximumVerifiedAllocationExpiration {
+		return xerrors.Errorf("expiration %d more than %d epochs after current epoch %d", t.Expiration,
+			MaximumVerifiedAllocationExpiration, currEpoch)
+	}
+	return nil
+}
+
+// DealDurationBounds returns the minimum and maximum duration, in epochs, of a storage deal at a network
+// version.
+func DealDurationBounds(nv network.Version) (min, max ChainEpoch) {
+	if nv >= network.Version21 {
+		return DealMinDuration, DealMaxDurationV21
+	}
+	return DealMinDuration, DealMaxDurationV0
+}
+
+// ValidateDealTerm checks the start and end epochs of a storage deal against policy at a network version.
+func ValidateDealTerm(start, end ChainEpoch, nv network.Version) error {
+	if end <= start {
+		return xerrors.Errorf("deal end %d not after start %d", end, start)
+	}
+	min, max := DealDurationBounds(nv)
+	if duration := end - start; duration < min || duration > max {
+		return xerrors.Errorf("deal duration %d out of bounds [%d, %d]", duration, min, max)
+	}
+	return nil
+}
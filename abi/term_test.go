@@ -0,0 +1,43 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestClaimTermValidate(t *testing.T) {
+	const now = abi.ChainEpoch(1000)
+	valid := abi.ClaimTerm{
+		TermMin:    abi.MinimumVerifiedAllocationTerm,
+		TermMax:    abi.MaximumVerifiedAllocationTerm,
+		Expiration: now + abi.MaximumVerifiedAllocationExpiration,
+	}
+	assert.NoError(t, valid.Validate(now, network.Version21))
+	assert.Error(t, valid.Validate(now, network.Version16))
+
+	for _, modify := range []func(*abi.ClaimTerm){
+		func(ct *abi.ClaimTerm) { ct.TermMin-- },
+		func(ct *abi.ClaimTerm) { ct.TermMax++ },
+		func(ct *abi.ClaimTerm) { ct.TermMax = ct.TermMin - 1 },
+		func(ct *abi.ClaimTerm) { ct.Expiration = now },
+		func(ct *abi.ClaimTerm) { ct.Expiration++ },
+	} {
+		term := valid
+		modify(&term)
+		assert.Error(t, term.Validate(now, network.Version21), "%+v", term)
+	}
+}
+
+func TestValidateDealTerm(t *testing.T) {
+	start := abi.ChainEpoch(100)
+	assert.NoError(t, abi.ValidateDealTerm(start, start+abi.DealMinDuration, network.Version20))
+	assert.Error(t, abi.ValidateDealTerm(start, start+abi.DealMinDuration-1, network.Version20))
+	assert.NoError(t, abi.ValidateDealTerm(start, start+abi.DealMaxDurationV0, network.Version20))
+	assert.Error(t, abi.ValidateDealTerm(start, start+abi.DealMaxDurationV0+1, network.Version20))
+	assert.NoError(t, abi.ValidateDealTerm(start, start+abi.DealMaxDurationV21, network.Version21))
+	assert.Error(t, abi.ValidateDealTerm(start, start, network.Version21))
+}
@@ -0,0 +1,52 @@
+package abi
+
+import (
+	"fmt"
+)
+
+// InvalidTokenAmountError reports a token amount that is nil or out of the range required of it.
+// Token amounts decoded from data with missing fields are nil, and arithmetic on them panics, so amounts
+// received at API boundaries should be checked with one of the Require functions.
+type InvalidTokenAmountError struct {
+	// Name of the checked value, for the error message.
+	Name   string
+	Amount TokenAmount
+	Reason string
+}
+
+func (e *InvalidTokenAmountError) Error() string {
+	if e.Amount.Nil() {
+		return fmt.Sprintf("invalid %s: %s", e.Name, e.Reason)
+	}
+	return fmt.Sprintf("invalid %s %s: %s", e.Name, e.Amount, e.Reason)
+}
+
+// RequireNonNil checks that a token amount is not nil.
+func RequireNonNil(name string, a TokenAmount) error {
+	if a.Nil() {
+		return &InvalidTokenAmountError{Name: name, Amount: a, Reason: "nil"}
+	}
+	return nil
+}
+
+// RequireNonNegative checks that a token amount is neither nil nor negative.
+func RequireNonNegative(name string, a TokenAmount) error {
+	if err := RequireNonNil(name, a); err != nil {
+		return err
+	}
+	if a.Sign() < 0 {
+		return &InvalidTokenAmountError{Name: name, Amount: a, Reason: "negative"}
+	}
+	return nil
+}
+
+// RequirePositive checks that a token amount is neither nil, zero nor negative.
+func RequirePositive(name string, a TokenAmount) error {
+	if err := RequireNonNil(name, a); err != nil {
+		return err
+	}
+	if a.Sign() <= 0 {
+		return &InvalidTokenAmountError{Name: name, Amount: a, Reason: "not positive"}
+	}
+	return nil
+}
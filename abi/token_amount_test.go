@@ -0,0 +1,36 @@
+package abi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+func TestTokenAmountGuards(t *testing.T) {
+	var missing abi.TokenAmount
+	assert.True(t, missing.Nil())
+	assert.False(t, big.Zero().Nil())
+
+	assert.NoError(t, abi.RequireNonNil("value", big.Zero()))
+	assert.NoError(t, abi.RequireNonNegative("value", big.Zero()))
+	assert.NoError(t, abi.RequirePositive("value", big.NewInt(1)))
+
+	for _, err := range []error{
+		abi.RequireNonNil("value", missing),
+		abi.RequireNonNegative("value", missing),
+		abi.RequirePositive("value", missing),
+		abi.RequireNonNegative("value", big.NewInt(-1)),
+		abi.RequirePositive("value", big.Zero()),
+	} {
+		require.Error(t, err)
+		var invalid *abi.InvalidTokenAmountError
+		assert.True(t, xerrors.As(err, &invalid))
+	}
+	assert.EqualError(t, abi.RequirePositive("deposit", big.NewInt(-5)), "invalid deposit -5: not positive")
+	assert.EqualError(t, abi.RequirePositive("deposit", missing), "invalid deposit: nil")
+}
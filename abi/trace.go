@@ -0,0 +1,69 @@
+package abi
+
+import (
+	"time"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/go-state-types/exitcode"
+)
+
+// Execution traces record the calls made while executing a message, and the gas charged for each, in a
+// format shared by VM implementations, nodes and debuggers. They encode as JSON and as CBOR maps
+// keyed by field name, since traces are never committed to chain state and are mostly consumed outside of Go.
+
+// ExecutionTrace is the trace of a call: the message, its return, the gas charged and the nested calls
+// it made, in order.
+type ExecutionTrace struct {
+	Msg        MessageTrace
+	MsgRct     ReturnTrace
+	GasCharges []GasTrace
+	Subcalls   []ExecutionTrace
+}
+
+// MessageTrace describes a call from one actor to another.
+type MessageTrace struct {
+	From   addr.Address
+	To     addr.Address
+	Value  TokenAmount
+	Method MethodNum
+	Params []byte
+	// The IPLD codec of the parameters.
+	ParamsCodec uint64
+	GasLimit    uint64
+	// Whether the call was made in read-only mode, in which it could not modify state.
+	ReadOnly bool
+	// The code CID of the called actor, if known.
+	CodeCid cid.Cid
+}
+
+// ReturnTrace describes the result of a call.
+type ReturnTrace struct {
+	ExitCode exitcode.ExitCode
+	Return   []byte
+	// The IPLD codec of the return value.
+	ReturnCodec uint64
+}
+
+// GasTrace describes a gas charge.
+type GasTrace struct {
+	// The operation being charged, e.g. "OnMethodInvocation".
+	Name       string
+	TotalGas   int64         `json:"tg"`
+	ComputeGas int64         `json:"cg"`
+	StorageGas int64         `json:"sg"`
+	TimeTaken  time.Duration `json:"tt"`
+}
+
+// SumGas returns the total gas charged to the call and its nested calls.
+func (t *ExecutionTrace) SumGas() int64 {
+	var total int64
+	for _, g := range t.GasCharges {
+		total += g.TotalGas
+	}
+	for i := range t.Subcalls {
+		total += t.Subcalls[i].SumGas()
+	}
+	return total
+}
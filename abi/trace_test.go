@@ -0,0 +1,76 @@
+package abi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/exitcode"
+)
+
+func TestExecutionTraceEncoding(t *testing.T) {
+	from, err := addr.NewIDAddress(100)
+	require.NoError(t, err)
+	to, err := addr.NewIDAddress(101)
+	require.NoError(t, err)
+	code, err := abi.CidBuilder.Sum([]byte("code"))
+	require.NoError(t, err)
+	msg := abi.MessageTrace{From: from, To: to, Value: big.NewInt(5), Method: 2, Params: []byte{0x80}, ParamsCodec: 0x51, GasLimit: 1000, CodeCid: code}
+
+	trace := abi.ExecutionTrace{
+		Msg:        msg,
+		MsgRct:     abi.ReturnTrace{ExitCode: exitcode.Ok, Return: []byte{0xf6}, ReturnCodec: 0x51},
+		GasCharges: []abi.GasTrace{{Name: "OnMethodInvocation", TotalGas: 100, ComputeGas: 60, StorageGas: 40, TimeTaken: time.Millisecond}},
+		Subcalls: []abi.ExecutionTrace{{
+			Msg:        abi.MessageTrace{From: to, To: from, Value: big.Zero(), Method: 3, ReadOnly: true, CodeCid: code},
+			MsgRct:     abi.ReturnTrace{ExitCode: exitcode.ErrForbidden},
+			GasCharges: []abi.GasTrace{{Name: "OnBlockRead", TotalGas: 25}},
+		}},
+	}
+	assert.Equal(t, int64(125), trace.SumGas())
+
+	var buf bytes.Buffer
+	require.NoError(t, trace.MarshalCBOR(&buf))
+	var fromCBOR abi.ExecutionTrace
+	require.NoError(t, fromCBOR.UnmarshalCBOR(&buf))
+	assert.Equal(t, trace, fromCBOR)
+
+	j, err := json.Marshal(trace)
+	require.NoError(t, err)
+	assert.Contains(t, string(j), `"tg":100`)
+	var fromJSON abi.ExecutionTrace
+	require.NoError(t, json.Unmarshal(j, &fromJSON))
+	assert.Equal(t, trace, fromJSON)
+}
+
+func TestTraceMapEncoding(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, (&abi.ReturnTrace{ExitCode: exitcode.ErrForbidden, Return: []byte{1}, ReturnCodec: 0x51}).MarshalCBOR(&buf))
+	// Fields are keyed by name, in the order they are declared.
+	expected := []byte{0xa3,
+		0x68, 'E', 'x', 'i', 't', 'C', 'o', 'd', 'e', 0x12,
+		0x66, 'R', 'e', 't', 'u', 'r', 'n', 0x41, 0x01,
+		0x6b, 'R', 'e', 't', 'u', 'r', 'n', 'C', 'o', 'd', 'e', 'c', 0x18, 0x51,
+	}
+	assert.Equal(t, expected, buf.Bytes())
+
+	// Fields may appear in any order, and unknown fields are ignored.
+	reordered := []byte{0xa3,
+		0x68, 'E', 'x', 'i', 't', 'C', 'o', 'd', 'e', 0x12,
+		0x65, 'E', 'x', 't', 'r', 'a', 0x82, 0x01, 0xa1, 0x61, 'k', 0x02,
+		0x66, 'R', 'e', 't', 'u', 'r', 'n', 0x41, 0x01,
+	}
+	var rt abi.ReturnTrace
+	require.NoError(t, rt.UnmarshalCBOR(bytes.NewReader(reordered)))
+	assert.Equal(t, abi.ReturnTrace{ExitCode: exitcode.ErrForbidden, Return: []byte{1}}, rt)
+
+	// Tuple encoding is rejected.
+	require.Error(t, rt.UnmarshalCBOR(bytes.NewReader([]byte{0x83, 0x12, 0x41, 0x01, 0x00})))
+}
@@ -0,0 +1,129 @@
+package abi
+
+import (
+	"io"
+
+	cid "github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// Typed CIDs are CIDs known to reference a particular kind of object, checked on construction and decoding.
+// A typed CID cannot be assigned to a field of another type without conversion, so CIDs of different kinds
+// (e.g. a sector's sealed and unsealed commitments) cannot be confused. The zero value is the undefined CID.
+
+// The codec and multihash of the CIDs of a kind of object.
+type cidKind struct {
+	name   string
+	codec  uint64
+	mhType uint64
+}
+
+var (
+	sealedCIDKind       = cidKind{"sealed sector commitment", cid.FilCommitmentSealed, mh.POSEIDON_BLS12_381_A1_FC1}
+	unsealedCIDKind     = cidKind{"unsealed commitment", cid.FilCommitmentUnsealed, mh.SHA2_256_TRUNC254_PADDED}
+	messagesRootCIDKind = cidKind{"messages root", cid.DagCBOR, mh.BLAKE2B_MIN + 31}
+	receiptsRootCIDKind = cidKind{"receipts root", cid.DagCBOR, mh.BLAKE2B_MIN + 31}
+)
+
+func (k cidKind) validate(c cid.Cid) error {
+	if err := validateCommitment(c, k.codec, k.mhType); err != nil {
+		return xerrors.Errorf("invalid %s: %w", k.name, err)
+	}
+	return nil
+}
+
+func (k cidKind) unmarshal(r io.Reader) (cid.Cid, error) {
+	c, err := cbg.ReadCid(r)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to read %s: %w", k.name, err)
+	}
+	return c, k.validate(c)
+}
+
+// SealedCID is a sealed sector commitment (CommR).
+type SealedCID struct{ c cid.Cid }
+
+// NewSealedCID checks that c is a sealed sector commitment.
+func NewSealedCID(c cid.Cid) (SealedCID, error) {
+	return SealedCID{c}, sealedCIDKind.validate(c)
+}
+
+func (t SealedCID) Cid() cid.Cid   { return t.c }
+func (t SealedCID) Defined() bool  { return t.c.Defined() }
+func (t SealedCID) String() string { return t.c.String() }
+
+func (t SealedCID) MarshalCBOR(w io.Writer) error {
+	return cbg.WriteCid(w, t.c)
+}
+
+func (t *SealedCID) UnmarshalCBOR(r io.Reader) (err error) {
+	t.c, err = sealedCIDKind.unmarshal(r)
+	return err
+}
+
+// UnsealedCID is an unsealed sector commitment (CommD) or piece commitment (CommP).
+type UnsealedCID struct{ c cid.Cid }
+
+// NewUnsealedCID checks that c is an unsealed sector or piece commitment.
+func NewUnsealedCID(c cid.Cid) (UnsealedCID, error) {
+	return UnsealedCID{c}, unsealedCIDKind.validate(c)
+}
+
+func (t UnsealedCID) Cid() cid.Cid   { return t.c }
+func (t UnsealedCID) Defined() bool  { return t.c.Defined() }
+func (t UnsealedCID) String() string { return t.c.String() }
+
+func (t UnsealedCID) MarshalCBOR(w io.Writer) error {
+	return cbg.WriteCid(w, t.c)
+}
+
+func (t *UnsealedCID) UnmarshalCBOR(r io.Reader) (err error) {
+	t.c, err = unsealedCIDKind.unmarshal(r)
+	return err
+}
+
+// MessagesRootCID is the root of the messages included in a block: a DAG-CBOR object, hashed with
+// BLAKE2b-256, linking the block's BLS and secp256k1 messages.
+type MessagesRootCID struct{ c cid.Cid }
+
+// NewMessagesRootCID checks that c is the CID of a DAG-CBOR object hashed with BLAKE2b-256.
+func NewMessagesRootCID(c cid.Cid) (MessagesRootCID, error) {
+	return MessagesRootCID{c}, messagesRootCIDKind.validate(c)
+}
+
+func (t MessagesRootCID) Cid() cid.Cid   { return t.c }
+func (t MessagesRootCID) Defined() bool  { return t.c.Defined() }
+func (t MessagesRootCID) String() string { return t.c.String() }
+
+func (t MessagesRootCID) MarshalCBOR(w io.Writer) error {
+	return cbg.WriteCid(w, t.c)
+}
+
+func (t *MessagesRootCID) UnmarshalCBOR(r io.Reader) (err error) {
+	t.c, err = messagesRootCIDKind.unmarshal(r)
+	return err
+}
+
+// ReceiptsRootCID is the root of the AMT of message receipts of a tipset's parent, a DAG-CBOR object hashed
+// with BLAKE2b-256.
+type ReceiptsRootCID struct{ c cid.Cid }
+
+// NewReceiptsRootCID checks that c is the CID of a DAG-CBOR object hashed with BLAKE2b-256.
+func NewReceiptsRootCID(c cid.Cid) (ReceiptsRootCID, error) {
+	return ReceiptsRootCID{c}, receiptsRootCIDKind.validate(c)
+}
+
+func (t ReceiptsRootCID) Cid() cid.Cid   { return t.c }
+func (t ReceiptsRootCID) Defined() bool  { return t.c.Defined() }
+func (t ReceiptsRootCID) String() string { return t.c.String() }
+
+func (t ReceiptsRootCID) MarshalCBOR(w io.Writer) error {
+	return cbg.WriteCid(w, t.c)
+}
+
+func (t *ReceiptsRootCID) UnmarshalCBOR(r io.Reader) (err error) {
+	t.c, err = receiptsRootCIDKind.unmarshal(r)
+	return err
+}
@@ -0,0 +1,63 @@
+package abi_test
+
+import (
+	"bytes"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestTypedCIDs(t *testing.T) {
+	newCid := func(codec, hashFn uint64) cid.Cid {
+		hash, err := mh.Encode(make([]byte, 32), hashFn)
+		require.NoError(t, err)
+		return cid.NewCidV1(codec, hash)
+	}
+	sealed := newCid(cid.FilCommitmentSealed, mh.POSEIDON_BLS12_381_A1_FC1)
+	unsealed := newCid(cid.FilCommitmentUnsealed, mh.SHA2_256_TRUNC254_PADDED)
+	root := newCid(cid.DagCBOR, mh.BLAKE2B_MIN+31)
+
+	s, err := abi.NewSealedCID(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, sealed, s.Cid())
+	_, err = abi.NewSealedCID(unsealed)
+	assert.Error(t, err)
+	_, err = abi.NewSealedCID(cid.Undef)
+	assert.Error(t, err)
+
+	u, err := abi.NewUnsealedCID(unsealed)
+	require.NoError(t, err)
+	assert.Equal(t, unsealed.String(), u.String())
+	_, err = abi.NewUnsealedCID(sealed)
+	assert.Error(t, err)
+
+	m, err := abi.NewMessagesRootCID(root)
+	require.NoError(t, err)
+	assert.True(t, m.Defined())
+	_, err = abi.NewMessagesRootCID(newCid(cid.Raw, mh.BLAKE2B_MIN+31))
+	assert.Error(t, err)
+	_, err = abi.NewReceiptsRootCID(newCid(cid.DagCBOR, mh.SHA2_256))
+	assert.Error(t, err)
+	assert.False(t, abi.ReceiptsRootCID{}.Defined())
+
+	// Decoding checks the kind of the CID.
+	var buf bytes.Buffer
+	require.NoError(t, s.MarshalCBOR(&buf))
+	var decoded abi.SealedCID
+	require.NoError(t, decoded.UnmarshalCBOR(bytes.NewReader(buf.Bytes())))
+	assert.Equal(t, s, decoded)
+	var wrong abi.UnsealedCID
+	assert.Error(t, wrong.UnmarshalCBOR(bytes.NewReader(buf.Bytes())))
+
+	buf.Reset()
+	require.NoError(t, cbg.WriteCid(&buf, root))
+	var receipts abi.ReceiptsRootCID
+	require.NoError(t, receipts.UnmarshalCBOR(&buf))
+	assert.Equal(t, root, receipts.Cid())
+}
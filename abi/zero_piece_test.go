@@ -0,0 +1,34 @@
+package abi_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+func TestZeroPieceCommitment(t *testing.T) {
+	// Compute each level of the zero tree and check the table.
+	var node [32]byte
+	for size := abi.PaddedPieceSize(32); size <= abi.MaxZeroPieceSize; size *= 2 {
+		if size >= abi.MinZeroPieceSize {
+			comm, err := abi.ZeroPieceCommitmentBytes(size)
+			require.NoError(t, err)
+			assert.Equal(t, node, comm, "size %d", size)
+		}
+		node = sha256.Sum256(append(node[:], node[:]...))
+		node[31] &= 0x3f
+	}
+
+	c, err := abi.ZeroPieceCommitment(abi.PaddedPieceSize(128))
+	require.NoError(t, err)
+	assert.Equal(t, "baga6ea4seaqdomn3tgwgrh3g532zopskstnbrd2n3sxfqbze7rxt7vqn7veigmy", c.String())
+
+	for _, bad := range []abi.PaddedPieceSize{0, 32, 64, 1000, abi.MaxZeroPieceSize * 2} {
+		_, err := abi.ZeroPieceCommitment(bad)
+		assert.Error(t, err, "size %d", bad)
+	}
+}
@@ -0,0 +1,32 @@
+package actors_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/filecoin-project/go-state-types/support/nvtest"
+)
+
+func TestVersionForNetwork(t *testing.T) {
+	nvtest.RequireAllVersionsHandled(t, func(nv network.Version) error {
+		_, err := actors.VersionForNetwork(nv)
+		return err
+	})
+
+	// Actors versions never decrease.
+	last := actors.Version0
+	for _, nv := range nvtest.AllVersions() {
+		av, err := actors.VersionForNetwork(nv)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, int(av), int(last), "network version %d", nv)
+		last = av
+	}
+	assert.Equal(t, actors.Version14, last)
+
+	_, err := actors.VersionForNetwork(network.VersionCount)
+	assert.Error(t, err)
+}
@@ -0,0 +1,195 @@
+package adt
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// A minimal read-only walker over AMTs (arrays).
+//
+// The root is a 4-tuple of bit width, height, count and the root node. The original encoding (actors v0 and v2)
+// omits the bit width, which is fixed at 3. A node is a 3-tuple of a bitmap of occupied slots (a byte string,
+// least significant bit first), an array of links to child nodes (in an internal node) and an array of
+// values (in a leaf). Links and values are for the occupied slots, in order.
+
+const (
+	amtRootFields       = 4
+	amtLegacyRootFields = 3
+	amtNodeFields       = 3
+	amtLegacyBitWidth   = 3
+	// Limits the width of a node to 2^8 slots.
+	maxAmtBitWidth = 8
+)
+
+// Amt is the root of an AMT.
+type Amt struct {
+	store ipldcbor.IpldStore
+	node  []byte
+
+	// Log2 of the number of slots in a node.
+	BitWidth uint64
+	// Height of the tree, zero if the root node is a leaf.
+	Height uint64
+	// Number of values in the AMT.
+	Count uint64
+}
+
+// LoadAmt loads the root of the AMT with some CID.
+func LoadAmt(ctx context.Context, store ipldcbor.IpldStore, root cid.Cid) (*Amt, error) {
+	var raw cbg.Deferred
+	if err := store.Get(ctx, root, &raw); err != nil {
+		return nil, xerrors.Errorf("failed to load AMT root %s: %w", root, err)
+	}
+	br := bytes.NewReader(raw.Raw)
+	maj, n, err := cbg.CborReadHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if maj != cbg.MajArray || (n != amtRootFields && n != amtLegacyRootFields) {
+		return nil, xerrors.Errorf("AMT root %s: expected %d-tuple", root, amtRootFields)
+	}
+	hdr := make([]uint64, n-1)
+	for i := range hdr {
+		maj, v, err := cbg.CborReadHeader(br)
+		if err != nil {
+			return nil, err
+		}
+		if maj != cbg.MajUnsignedInt {
+			return nil, xerrors.Errorf("AMT root %s: expected unsigned integer", root)
+		}
+		hdr[i] = v
+	}
+	if n == amtLegacyRootFields {
+		hdr = append([]uint64{amtLegacyBitWidth}, hdr...)
+	}
+	a := &Amt{store: store, BitWidth: hdr[0], Height: hdr[1], Count: hdr[2]}
+	if a.BitWidth == 0 || a.BitWidth > maxAmtBitWidth {
+		return nil, xerrors.Errorf("AMT root %s: invalid bit width %d", root, a.BitWidth)
+	}
+	// Indices are 64-bit.
+	if a.Height*a.BitWidth >= 64 {
+		return nil, xerrors.Errorf("AMT root %s: height %d too large", root, a.Height)
+	}
+
+	var node cbg.Deferred
+	if err := node.UnmarshalCBOR(br); err != nil {
+		return nil, xerrors.Errorf("AMT root %s: failed to read node: %w", root, err)
+	}
+	if br.Len() != 0 {
+		return nil, xerrors.Errorf("AMT root %s: %d trailing bytes", root, br.Len())
+	}
+	a.node = node.Raw
+	return a, nil
+}
+
+// ForEach calls cb with the index and raw value of each entry of the AMT, in index order, stopping at the
+// first error.
+func (a *Amt) ForEach(ctx context.Context, cb func(i uint64, v []byte) error) error {
+	br := bytes.NewReader(a.node)
+	if err := a.walkNode(ctx, br, a.Height, 0, cb); err != nil {
+		return err
+	}
+	if br.Len() != 0 {
+		return xerrors.Errorf("AMT root node: %d trailing bytes", br.Len())
+	}
+	return nil
+}
+
+// ForEachAmtEntry calls cb with the index and raw value of each entry of the AMT with some root, in index
+// order, stopping at the first error.
+func ForEachAmtEntry(ctx context.Context, store ipldcbor.IpldStore, root cid.Cid, cb func(i uint64, v []byte) error) error {
+	a, err := LoadAmt(ctx, store, root)
+	if err != nil {
+		return err
+	}
+	return a.ForEach(ctx, cb)
+}
+
+// Reads a node whose first slot has index offset, calling cb for each value beneath it in index order.
+func (a *Amt) walkNode(ctx context.Context, br *bytes.Reader, height, offset uint64, cb func(i uint64, v []byte) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	width := uint64(1) << a.BitWidth
+	maj, n, err := cbg.CborReadHeader(br)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || n != amtNodeFields {
+		return xerrors.Errorf("expected %d-tuple node", amtNodeFields)
+	}
+	bitmap, err := cbg.ReadByteArray(br, (width+7)/8)
+	if err != nil {
+		return xerrors.Errorf("failed to read bitmap: %w", err)
+	}
+	var slots []uint64
+	for i := uint64(0); i < width && i/8 < uint64(len(bitmap)); i++ {
+		if bitmap[i/8]&(1<<(i%8)) != 0 {
+			slots = append(slots, i)
+		}
+	}
+
+	maj, nLinks, err := cbg.CborReadHeader(br)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || nLinks > width {
+		return xerrors.Errorf("expected links array of at most %d entries", width)
+	}
+	links := make([]cid.Cid, 0, nLinks)
+	for i := uint64(0); i < nLinks; i++ {
+		c, err := cbg.ReadCid(br)
+		if err != nil {
+			return xerrors.Errorf("failed to read link: %w", err)
+		}
+		links = append(links, c)
+	}
+
+	maj, nValues, err := cbg.CborReadHeader(br)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || nValues > width {
+		return xerrors.Errorf("expected values array of at most %d entries", width)
+	}
+	if height == 0 {
+		if nLinks != 0 || nValues != uint64(len(slots)) {
+			return xerrors.Errorf("leaf node has %d links and %d values for %d occupied slots", nLinks, nValues, len(slots))
+		}
+		for _, slot := range slots {
+			var v cbg.Deferred
+			if err := v.UnmarshalCBOR(br); err != nil {
+				return xerrors.Errorf("failed to read value: %w", err)
+			}
+			if err := cb(offset+slot, v.Raw); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if nValues != 0 || nLinks != uint64(len(slots)) {
+		return xerrors.Errorf("internal node has %d links and %d values for %d occupied slots", nLinks, nValues, len(slots))
+	}
+	// The number of values beneath each slot of this node.
+	slotSpan := uint64(1) << (a.BitWidth * height)
+	for i, c := range links {
+		var raw cbg.Deferred
+		if err := a.store.Get(ctx, c, &raw); err != nil {
+			return xerrors.Errorf("failed to load AMT node %s: %w", c, err)
+		}
+		child := bytes.NewReader(raw.Raw)
+		if err := a.walkNode(ctx, child, height-1, offset+slots[i]*slotSpan, cb); err != nil {
+			return err
+		}
+		if child.Len() != 0 {
+			return xerrors.Errorf("AMT node %s: %d trailing bytes", c, child.Len())
+		}
+	}
+	return nil
+}
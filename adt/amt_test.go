@@ -0,0 +1,76 @@
+package adt_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-state-types/adt"
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+type amtEntry struct {
+	i uint64
+	v uint64
+}
+
+func collectAmt(t *testing.T, store *ipld.MemStore, root cid.Cid) []amtEntry {
+	var entries []amtEntry
+	require.NoError(t, adt.ForEachAmtEntry(context.Background(), store, root, func(i uint64, v []byte) error {
+		maj, n, err := cbg.CborReadHeader(bytes.NewReader(v))
+		require.NoError(t, err)
+		require.Equal(t, byte(cbg.MajUnsignedInt), maj)
+		entries = append(entries, amtEntry{i, n})
+		return nil
+	}))
+	return entries
+}
+
+func TestAmtRootLayouts(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+
+	// The v3 layout, with an explicit bit width of 2: a root of height 1 whose slots 0 and 2 hold leaves.
+	c0 := ipld.PutBlock(t, store, ipld.AmtNode(ipld.AmtBitmap(4, 0, 3), nil, ipld.Uint(10), ipld.Uint(13)))
+	c2 := ipld.PutBlock(t, store, ipld.AmtNode(ipld.AmtBitmap(4, 1), nil, ipld.Uint(21)))
+	root := ipld.PutBlock(t, store, ipld.AmtRoot(2, 1, 3, ipld.AmtNode(ipld.AmtBitmap(4, 0, 2), []cid.Cid{c0, c2})))
+	a, err := adt.LoadAmt(ctx, store, root)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), a.BitWidth)
+	assert.Equal(t, uint64(1), a.Height)
+	assert.Equal(t, uint64(3), a.Count)
+	assert.Equal(t, []amtEntry{{0, 10}, {3, 13}, {9, 21}}, collectAmt(t, store, root))
+
+	// The legacy layout omits the bit width, which is 3.
+	c1 := ipld.PutBlock(t, store, ipld.AmtNode(ipld.AmtBitmap(8, 7), nil, ipld.Uint(17)))
+	legacy := ipld.PutBlock(t, store, ipld.Tuple(ipld.Uint(1), ipld.Uint(2), ipld.AmtNode(ipld.AmtBitmap(8, 1), []cid.Cid{c1})))
+	a, err = adt.LoadAmt(ctx, store, legacy)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), a.BitWidth)
+	assert.Equal(t, uint64(1), a.Height)
+	assert.Equal(t, uint64(2), a.Count)
+	assert.Equal(t, []amtEntry{{15, 17}}, collectAmt(t, store, legacy))
+
+	// Malformed roots.
+	empty := ipld.AmtNode(ipld.AmtBitmap(4), nil)
+	for i, b := range [][]byte{
+		ipld.AmtRoot(0, 0, 0, empty),
+		ipld.AmtRoot(9, 0, 0, empty),
+		ipld.AmtRoot(8, 8, 0, empty),
+		ipld.Tuple(ipld.Uint(1), empty),
+		ipld.Tuple(ipld.Uint(2), ipld.Uint(0), ipld.Uint(0), ipld.Uint(0), empty),
+	} {
+		bad := ipld.PutBlock(t, store, b)
+		_, err := adt.LoadAmt(ctx, store, bad)
+		assert.Error(t, err, i)
+	}
+
+	// A leaf with values for fewer slots than its bitmap occupies.
+	bad := ipld.PutBlock(t, store, ipld.AmtRoot(2, 0, 2, ipld.AmtNode(ipld.AmtBitmap(4, 0, 1), nil, ipld.Uint(1))))
+	assert.Error(t, adt.ForEachAmtEntry(ctx, store, bad, func(uint64, []byte) error { return nil }))
+}
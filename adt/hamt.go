@@ -0,0 +1,170 @@
+// Package adt provides read-only traversal of the abstract data types (HAMTs and AMTs) in which actor state is stored.
+package adt
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// A minimal read-only walker over the HAMT encodings used by the state tree and actor state.
+//
+// A node is a 2-tuple of a bitfield and an array of pointers. The original encoding (StateTreeVersion0)
+// writes the bitfield as a CBOR bignum and each pointer as a single-entry map, from "0" to a link or from "1"
+// to a bucket. The compact encoding (from StateTreeVersion1) writes the bitfield as a plain byte string and each
+// pointer directly as either a link or a bucket. A bucket is an array of key-value 2-tuples.
+// Both encodings are accepted at every node; the bitfield is not needed for a full traversal.
+
+// Number of fields of a HAMT node, in either encoding.
+const HamtNodeFields = 2
+
+// Maximum number of pointers in a node (for a bit width of 8) or entries in a bucket.
+const maxHamtNodeWidth = 256
+
+const (
+	oldPointerLinkKey   = "0"
+	oldPointerBucketKey = "1"
+)
+
+// ForEachHamtEntry calls cb with the raw key and value of each entry of the HAMT with some root, in HAMT order,
+// stopping at the first error. The bit width of the HAMT need not be known.
+func ForEachHamtEntry(ctx context.Context, store ipldcbor.IpldStore, root cid.Cid, cb func(k, v []byte) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var raw cbg.Deferred
+	if err := store.Get(ctx, root, &raw); err != nil {
+		return xerrors.Errorf("failed to load HAMT node %s: %w", root, err)
+	}
+	children, err := readHamtNode(bytes.NewReader(raw.Raw), cb)
+	if err != nil {
+		return xerrors.Errorf("failed to read HAMT node %s: %w", root, err)
+	}
+	for _, c := range children {
+		if err := ForEachHamtEntry(ctx, store, c, cb); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Reads a node, calling cb for each key-value entry in its buckets, and returns the links to child nodes.
+// Entries and links are interleaved in the node, but children are visited after this node's entries.
+func readHamtNode(br *bytes.Reader, cb func(k, v []byte) error) ([]cid.Cid, error) {
+	maj, n, err := cbg.CborReadHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if maj != cbg.MajArray || n != HamtNodeFields {
+		return nil, xerrors.Errorf("expected %d-tuple", HamtNodeFields)
+	}
+	var bitfield cbg.Deferred
+	if err := bitfield.UnmarshalCBOR(br); err != nil {
+		return nil, xerrors.Errorf("failed to read bitfield: %w", err)
+	}
+
+	maj, n, err = cbg.CborReadHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if maj != cbg.MajArray {
+		return nil, xerrors.Errorf("expected pointers array")
+	}
+	if n > maxHamtNodeWidth {
+		return nil, xerrors.Errorf("too many pointers (%d)", n)
+	}
+
+	var links []cid.Cid
+	for i := uint64(0); i < n; i++ {
+		link, isLink, err := readHamtPointer(br, cb)
+		if err != nil {
+			return nil, err
+		}
+		if isLink {
+			links = append(links, link)
+		}
+	}
+	if br.Len() != 0 {
+		return nil, xerrors.Errorf("%d trailing bytes", br.Len())
+	}
+	return links, nil
+}
+
+func readHamtPointer(br *bytes.Reader, cb func(k, v []byte) error) (cid.Cid, bool, error) {
+	first, err := br.ReadByte()
+	if err != nil {
+		return cid.Undef, false, err
+	}
+	if err := br.UnreadByte(); err != nil {
+		return cid.Undef, false, err
+	}
+
+	switch first >> 5 {
+	case cbg.MajTag:
+		c, err := cbg.ReadCid(br)
+		return c, true, err
+	case cbg.MajArray:
+		return cid.Undef, false, readHamtBucket(br, cb)
+	case cbg.MajMap:
+		_, n, err := cbg.CborReadHeader(br)
+		if err != nil {
+			return cid.Undef, false, err
+		}
+		if n != 1 {
+			return cid.Undef, false, xerrors.Errorf("expected single-entry pointer map, got %d entries", n)
+		}
+		key, err := cbg.ReadString(br)
+		if err != nil {
+			return cid.Undef, false, err
+		}
+		switch key {
+		case oldPointerLinkKey:
+			c, err := cbg.ReadCid(br)
+			return c, true, err
+		case oldPointerBucketKey:
+			return cid.Undef, false, readHamtBucket(br, cb)
+		default:
+			return cid.Undef, false, xerrors.Errorf("invalid pointer map key %q", key)
+		}
+	default:
+		return cid.Undef, false, xerrors.Errorf("invalid pointer type %d", first>>5)
+	}
+}
+
+func readHamtBucket(br *bytes.Reader, cb func(k, v []byte) error) error {
+	maj, n, err := cbg.CborReadHeader(br)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return xerrors.Errorf("expected bucket array")
+	}
+	if n > maxHamtNodeWidth {
+		return xerrors.Errorf("too many bucket entries (%d)", n)
+	}
+	for i := uint64(0); i < n; i++ {
+		maj, fields, err := cbg.CborReadHeader(br)
+		if err != nil {
+			return err
+		}
+		if maj != cbg.MajArray || fields != 2 {
+			return xerrors.Errorf("expected key-value 2-tuple")
+		}
+		key, err := cbg.ReadByteArray(br, cbg.ByteArrayMaxLen)
+		if err != nil {
+			return xerrors.Errorf("failed to read key: %w", err)
+		}
+		var value cbg.Deferred
+		if err := value.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("failed to read value: %w", err)
+		}
+		if err := cb(key, value.Raw); err != nil {
+			return err
+		}
+	}
+	return nil
+}
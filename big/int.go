@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"math/bits"
+	"sync"
 
 	cbg "github.com/whyrusleeping/cbor-gen"
 )
@@ -16,6 +18,18 @@ type Int struct {
 	*big.Int
 }
 
+// Small values used as operands and for comparisons within this package, which must never be returned to a
+// caller: the embedded big.Int is exported, so a shared value could be modified in place. The exported
+// constructors allocate.
+var smallInts = func() (s [257]*big.Int) {
+	for i := range s {
+		s[i] = big.NewInt(int64(i))
+	}
+	return s
+}()
+
+var zero = Int{smallInts[0]}
+
 func NewInt(i int64) Int {
 	return Int{big.NewInt(0).SetInt64(i)}
 }
@@ -62,12 +76,20 @@ func (bi Int) Copy() Int {
 	return Int{Int: new(big.Int).Set(bi.Int)}
 }
 
+// Product returns the product of some values, or one if there are none.
+// The product is accumulated in a single value rather than allocating one per multiplication.
 func Product(ints ...Int) Int {
-	p := NewInt(1)
-	for _, i := range ints {
-		p = Mul(p, i)
+	switch len(ints) {
+	case 0:
+		return NewInt(1)
+	case 1:
+		return ints[0].Copy()
+	}
+	p := new(big.Int).Mul(ints[0].Int, ints[1].Int)
+	for _, i := range ints[2:] {
+		p.Mul(p, i.Int)
 	}
-	return p
+	return Int{p}
 }
 
 func Mul(a, b Int) Int {
@@ -82,16 +104,67 @@ func Mod(a, b Int) Int {
 	return Int{big.NewInt(0).Mod(a.Int, b.Int)}
 }
 
+// RoundingMode specifies how the quotient of a division is rounded to an integer.
+type RoundingMode int
+
+const (
+	// Round towards zero, as by Go's integer division operator.
+	RoundTruncate RoundingMode = iota
+	// Round towards negative infinity.
+	RoundFloor
+	// Round towards positive infinity.
+	RoundCeil
+	// Round such that the remainder is non-negative, as by Div.
+	// This is floor for a positive divisor and ceiling for a negative one.
+	RoundEuclidean
+)
+
+// DivRound returns a/b rounded according to mode. It panics if b is zero.
+// Note that Div rounds euclidean (which differs from both truncation and floor for negative operands),
+// so callers for which the direction of rounding matters should use DivRound with an explicit mode.
+func DivRound(a, b Int, mode RoundingMode) Int {
+	if mode == RoundEuclidean {
+		return Div(a, b)
+	}
+	q, r := big.NewInt(0).QuoRem(a.Int, b.Int, big.NewInt(0))
+	if r.Sign() != 0 {
+		// The truncated quotient is inexact; its sign is that of the exact quotient.
+		positive := r.Sign() == b.Int.Sign()
+		switch mode {
+		case RoundTruncate:
+		case RoundFloor:
+			if !positive {
+				q.Sub(q, smallInts[1])
+			}
+		case RoundCeil:
+			if positive {
+				q.Add(q, smallInts[1])
+			}
+		default:
+			panic(fmt.Sprintf("invalid rounding mode %d", mode))
+		}
+	}
+	return Int{q}
+}
+
 func Add(a, b Int) Int {
 	return Int{big.NewInt(0).Add(a.Int, b.Int)}
 }
 
+// Sum returns the sum of some values, or zero if there are none.
+// The sum is accumulated in a single value rather than allocating one per addition.
 func Sum(ints ...Int) Int {
-	sum := Zero()
-	for _, i := range ints {
-		sum = Add(sum, i)
+	switch len(ints) {
+	case 0:
+		return Zero()
+	case 1:
+		return ints[0].Copy()
+	}
+	sum := new(big.Int).Add(ints[0].Int, ints[1].Int)
+	for _, i := range ints[2:] {
+		sum.Add(sum, i.Int)
 	}
-	return sum
+	return Int{sum}
 }
 
 func Subtract(num1 Int, ints ...Int) Int {
@@ -127,7 +200,7 @@ func BitLen(a Int) uint {
 
 func Max(x, y Int) Int {
 	// taken from max.Max()
-	if x.Equals(Zero()) && x.Equals(y) {
+	if x.Equals(zero) && x.Equals(y) {
 		if x.Sign() != 0 {
 			return y
 		}
@@ -141,7 +214,7 @@ func Max(x, y Int) Int {
 
 func Min(x, y Int) Int {
 	// taken from max.Min()
-	if x.Equals(Zero()) && x.Equals(y) {
+	if x.Equals(zero) && x.Equals(y) {
 		if x.Sign() != 0 {
 			return x
 		}
@@ -184,7 +257,7 @@ func (bi Int) Neg() Int {
 
 // Abs returns the absolute value of bi.
 func (bi Int) Abs() Int {
-	if bi.GreaterThanEqual(Zero()) {
+	if bi.GreaterThanEqual(zero) {
 		return bi.Copy()
 	}
 	return bi.Neg()
@@ -197,7 +270,6 @@ func (bi Int) Equals(o Int) bool {
 
 func (bi *Int) MarshalJSON() ([]byte, error) {
 	if bi.Int == nil {
-		zero := Zero()
 		return json.Marshal(zero)
 	}
 	return json.Marshal(bi.String())
@@ -233,6 +305,77 @@ func (bi *Int) Bytes() ([]byte, error) {
 	}
 }
 
+// AppendBytes appends the byte encoding of bi (as produced by Bytes) to dst and returns the extended slice.
+// Unlike Bytes, this writes the magnitude directly into dst and so does not allocate if dst has sufficient capacity.
+func (bi *Int) AppendBytes(dst []byte) ([]byte, error) {
+	if bi.Int == nil {
+		return dst, fmt.Errorf("failed to convert to bytes, big is nil")
+	}
+
+	switch {
+	case bi.Sign() > 0:
+		dst = append(dst, 0)
+	case bi.Sign() < 0:
+		dst = append(dst, 1)
+	default: //  bi.Sign() == 0:
+		return dst, nil
+	}
+	return appendMagnitude(dst, bi.Int), nil
+}
+
+// Appends the big-endian bytes of the absolute value of i to dst.
+func appendMagnitude(dst []byte, i *big.Int) []byte {
+	n := (i.BitLen() + 7) / 8
+	start := len(dst)
+	if cap(dst)-start < n {
+		grown := make([]byte, start, 2*cap(dst)+n)
+		copy(grown, dst)
+		dst = grown
+	}
+	dst = dst[:start+n]
+
+	// Words are little-endian, the encoding is big-endian.
+	pos := len(dst)
+	for _, w := range i.Bits() {
+		for b := 0; b < bits.UintSize/8 && pos > start; b++ {
+			pos--
+			dst[pos] = byte(w)
+			w >>= 8
+		}
+	}
+	return dst
+}
+
+// SetBytesNoCopy sets bi to the value encoded in buf (in the format produced by Bytes).
+// Unlike FromBytes, the receiver's existing big.Int is overwritten in place (if it has one), so no new
+// integer is allocated. Because copies of an Int share the underlying big.Int, this must only be used
+// on an Int which is not referenced elsewhere. The buffer is not retained.
+func (bi *Int) SetBytesNoCopy(buf []byte) error {
+	if bi.Int == nil {
+		bi.Int = new(big.Int)
+	}
+	if len(buf) == 0 {
+		bi.Int.SetInt64(0)
+		return nil
+	}
+
+	var negative bool
+	switch buf[0] {
+	case 0:
+		negative = false
+	case 1:
+		negative = true
+	default:
+		return fmt.Errorf("big int prefix should be either 0 or 1, got %d", buf[0])
+	}
+
+	bi.Int.SetBytes(buf[1:])
+	if negative {
+		bi.Int.Neg(bi.Int)
+	}
+	return nil
+}
+
 func FromBytes(buf []byte) (Int, error) {
 	if len(buf) == 0 {
 		return NewInt(0), nil
@@ -258,7 +401,6 @@ func FromBytes(buf []byte) (Int, error) {
 
 func (bi *Int) MarshalBinary() ([]byte, error) {
 	if bi.Int == nil {
-		zero := Zero()
 		return zero.Bytes()
 	}
 	return bi.Bytes()
@@ -275,24 +417,68 @@ func (bi *Int) UnmarshalBinary(buf []byte) error {
 	return nil
 }
 
-func (bi *Int) MarshalCBOR(w io.Writer) error {
+// Pool of buffers large enough to hold the CBOR encoding of any Int that may be serialized.
+// The CBOR header for a byte string of at most BigIntMaxSerializedLen bytes takes at most two bytes.
+var cborBufPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 2+BigIntMaxSerializedLen)
+		return &buf
+	},
+}
+
+// CBORLen returns the length of the CBOR encoding of bi (as written by MarshalCBOR), without encoding it.
+// For an integer too large to serialize, this is the length the encoding would have.
+func (bi Int) CBORLen() int {
 	if bi.Int == nil {
-		zero := Zero()
-		return zero.MarshalCBOR(w)
+		return 1
+	}
+	n := bi.bytesLen()
+	if n < 24 {
+		return 1 + n
+	}
+	if n < 1<<8 {
+		return 2 + n
 	}
+	// Larger encodings are never written, but use the longer header forms.
+	if n < 1<<16 {
+		return 3 + n
+	}
+	return 5 + n
+}
 
-	enc, err := bi.Bytes()
-	if err != nil {
-		return err
+// Length of the byte encoding of a non-nil bi, as produced by Bytes.
+func (bi Int) bytesLen() int {
+	if bi.Sign() == 0 {
+		return 0
+	}
+	return 1 + (bi.BitLen()+7)/8
+}
+
+// AppendCBOR appends the CBOR encoding of bi (as written by MarshalCBOR) to dst and returns the extended slice.
+func (bi *Int) AppendCBOR(dst []byte) ([]byte, error) {
+	if bi.Int == nil {
+		return append(dst, cbg.MajByteString<<5), nil
 	}
 
-	encLen := len(enc)
+	encLen := bi.bytesLen()
 	if encLen > BigIntMaxSerializedLen {
-		return fmt.Errorf("big integer byte array too long (%d bytes)", encLen)
+		return dst, fmt.Errorf("big integer byte array too long (%d bytes)", encLen)
 	}
 
-	header := cbg.CborEncodeMajorType(cbg.MajByteString, uint64(encLen))
-	if _, err := w.Write(header); err != nil {
+	if encLen < 24 {
+		dst = append(dst, cbg.MajByteString<<5|byte(encLen))
+	} else {
+		dst = append(dst, cbg.MajByteString<<5|24, byte(encLen))
+	}
+	return bi.AppendBytes(dst)
+}
+
+func (bi *Int) MarshalCBOR(w io.Writer) error {
+	bufp := cborBufPool.Get().(*[]byte)
+	defer cborBufPool.Put(bufp)
+
+	enc, err := bi.AppendCBOR((*bufp)[:0])
+	if err != nil {
 		return err
 	}
 
@@ -322,11 +508,15 @@ func (bi *Int) UnmarshalCBOR(br io.Reader) error {
 		return fmt.Errorf("big integer byte array too long (%d bytes)", extra)
 	}
 
-	buf := make([]byte, extra)
+	bufp := cborBufPool.Get().(*[]byte)
+	defer cborBufPool.Put(bufp)
+
+	buf := (*bufp)[:extra]
 	if _, err := io.ReadFull(br, buf); err != nil {
 		return err
 	}
 
+	// The buffer is not retained.
 	i, err := FromBytes(buf)
 	if err != nil {
 		return err
@@ -341,10 +531,12 @@ func (bi *Int) IsZero() bool {
 	return bi.Int.Sign() == 0
 }
 
-func (bi *Int) Nil() bool {
+// Nil reports whether the value has no underlying integer, as for a zero Int{} or a field missing from
+// decoded data. Arithmetic on such a value panics.
+func (bi Int) Nil() bool {
 	return bi.Int == nil
 }
 
-func (bi *Int) NilOrZero() bool {
+func (bi Int) NilOrZero() bool {
 	return bi.Int == nil || bi.Int.Sign() == 0
 }
@@ -116,6 +116,28 @@ func TestOperations(t *testing.T) {
 	assert.True(t, ta.Nil())
 }
 
+func TestDivRound(t *testing.T) {
+	testCases := []struct {
+		a, b                          int64
+		trunc, floor, ceil, euclidean int64
+	}{
+		{7, 2, 3, 3, 4, 3},
+		{-7, 2, -3, -4, -3, -4},
+		{7, -2, -3, -4, -3, -3},
+		{-7, -2, 3, 3, 4, 4},
+		{6, 2, 3, 3, 3, 3},
+		{-6, 2, -3, -3, -3, -3},
+		{0, 5, 0, 0, 0, 0},
+	}
+	for _, tc := range testCases {
+		a, b := NewInt(tc.a), NewInt(tc.b)
+		assert.Equal(t, NewInt(tc.trunc), DivRound(a, b, RoundTruncate), "%d / %d truncate", tc.a, tc.b)
+		assert.Equal(t, NewInt(tc.floor), DivRound(a, b, RoundFloor), "%d / %d floor", tc.a, tc.b)
+		assert.Equal(t, NewInt(tc.ceil), DivRound(a, b, RoundCeil), "%d / %d ceil", tc.a, tc.b)
+		assert.Equal(t, NewInt(tc.euclidean), DivRound(a, b, RoundEuclidean), "%d / %d euclidean", tc.a, tc.b)
+	}
+}
+
 func TestCopy(t *testing.T) {
 	b1 := NewInt(1)
 	b2 := b1.Copy()
@@ -133,6 +155,17 @@ func TestSum(t *testing.T) {
 	require.EqualValues(t, NewInt(10), Sum(b1, b2, b3, b4))
 
 	require.EqualValues(t, NewInt(20), Sum(NewInt(20)))
+	require.EqualValues(t, Zero(), Sum())
+
+	// The accumulator never modifies an argument.
+	large := NewInt(1000)
+	require.EqualValues(t, NewInt(3000), Sum(large, large, large))
+	require.EqualValues(t, NewInt(1000), large)
+
+	// Nor is the result of a single argument shared with it.
+	single := Sum(large)
+	single.Int.SetInt64(1)
+	require.EqualValues(t, NewInt(1000), large)
 }
 
 func TestProduct(t *testing.T) {
@@ -144,6 +177,15 @@ func TestProduct(t *testing.T) {
 	require.EqualValues(t, NewInt(24), Product(b1, b2, b3, b4))
 
 	require.EqualValues(t, NewInt(20), Product(NewInt(20)))
+	require.EqualValues(t, NewInt(1), Product())
+
+	large := NewInt(1000)
+	require.EqualValues(t, NewInt(1000000000), Product(large, large, large))
+	require.EqualValues(t, NewInt(1000), large)
+
+	single := Product(large)
+	single.Int.SetInt64(1)
+	require.EqualValues(t, NewInt(1000), large)
 }
 
 func TestSubtract(t *testing.T) {
@@ -230,3 +272,134 @@ func TestCBOR(t *testing.T) {
 		assert.Error(t, out.UnmarshalCBOR(&b))
 	})
 }
+
+func TestAppendBytes(t *testing.T) {
+	ints := []Int{
+		NewInt(0),
+		NewInt(-1),
+		NewInt(1),
+		NewInt(255),
+		NewInt(-256),
+		NewInt(1e18),
+		Lsh(NewInt(1), 80),
+		Sub(Lsh(NewInt(1), 8*(BigIntMaxSerializedLen-1)), NewInt(1)),
+	}
+	for _, n := range ints {
+		expected, err := n.Bytes()
+		require.NoError(t, err)
+
+		prefix := []byte{0xaa, 0xbb}
+		appended, err := n.AppendBytes(append([]byte{}, prefix...))
+		require.NoError(t, err)
+		assert.Equal(t, append(prefix, expected...), appended)
+
+		var out Int
+		require.NoError(t, out.SetBytesNoCopy(expected))
+		assert.True(t, n.Equals(out), "expected %s, got %s", n, out)
+
+		var buf bytes.Buffer
+		require.NoError(t, n.MarshalCBOR(&buf))
+		enc, err := n.AppendCBOR(nil)
+		require.NoError(t, err)
+		assert.Equal(t, buf.Bytes(), enc)
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		_, err := (&Int{}).AppendBytes(nil)
+		assert.Error(t, err)
+
+		enc, err := (&Int{}).AppendCBOR(nil)
+		require.NoError(t, err)
+		assert.Equal(t, "@", string(enc))
+	})
+
+	t.Run("set bytes reuses receiver", func(t *testing.T) {
+		n := NewInt(1234)
+		inner := n.Int
+		require.NoError(t, n.SetBytesNoCopy([]byte{1, 5}))
+		assert.Equal(t, NewInt(-5), n)
+		assert.True(t, inner == n.Int)
+		assert.Error(t, n.SetBytesNoCopy([]byte{2, 5}))
+	})
+}
+
+func TestConstructorsAllocate(t *testing.T) {
+	// Values returned by the constructors are never shared, so modifying one in place affects no other.
+	assert.False(t, Zero().Int == Zero().Int)
+	assert.False(t, NewInt(1).Int == NewIntUnsigned(1).Int)
+	Zero().Int.SetInt64(5)
+	NewInt(7).Int.SetInt64(5)
+	assert.Equal(t, int64(0), Zero().Int64())
+	assert.Equal(t, int64(7), NewInt(7).Int64())
+
+	// Nor are values decoded from zero, or the results of comparisons with zero.
+	var a, b Int
+	require.NoError(t, a.UnmarshalCBOR(bytes.NewReader([]byte{cbg.MajByteString << 5})))
+	require.NoError(t, b.UnmarshalCBOR(bytes.NewReader([]byte{cbg.MajByteString << 5})))
+	assert.False(t, a.Int == b.Int)
+	a.Int.SetInt64(3)
+	assert.Equal(t, int64(0), Max(NewInt(-1), Zero()).Int64())
+	assert.Equal(t, int64(0), Zero().Abs().Int64())
+	assert.True(t, zero.IsZero())
+
+	// Arithmetic using the cached values as operands leaves them unchanged.
+	assert.Equal(t, int64(-2), DivRound(NewInt(-7), NewInt(4), RoundFloor).Int64())
+	assert.Equal(t, int64(2), DivRound(NewInt(7), NewInt(4), RoundCeil).Int64())
+	for i, v := range smallInts {
+		assert.Equal(t, int64(i), v.Int64())
+	}
+}
+
+func BenchmarkDivRound(b *testing.B) {
+	x, y := NewInt(-7), NewInt(4)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = DivRound(x, y, RoundFloor)
+	}
+}
+
+func BenchmarkMax(b *testing.B) {
+	x, y := NewInt(300), NewInt(-1)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Max(x, y)
+	}
+}
+
+func BenchmarkZero(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = Zero()
+	}
+}
+
+func BenchmarkUnmarshalCBORZero(b *testing.B) {
+	enc := []byte{cbg.MajByteString << 5}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var n Int
+		if err := n.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestCBORLen(t *testing.T) {
+	for _, n := range []Int{
+		{},
+		NewInt(0),
+		NewInt(-1),
+		NewInt(255),
+		NewInt(1e18),
+		Lsh(NewInt(1), 8*21),
+		Mul(NewInt(-1), Lsh(NewInt(1), 8*22)),
+		Lsh(NewInt(1), 8*(BigIntMaxSerializedLen-2)),
+	} {
+		var b bytes.Buffer
+		require.NoError(t, n.MarshalCBOR(&b))
+		assert.Equal(t, b.Len(), n.CBORLen(), "%v", n)
+	}
+
+	giant := Lsh(NewInt(1), 8*(BigIntMaxSerializedLen+200))
+	assert.Equal(t, 3+BigIntMaxSerializedLen+202, giant.CBORLen())
+}
@@ -0,0 +1,45 @@
+package big
+
+import "sync"
+
+// Token quantities, in attoFIL. Each function returns a fresh copy, so callers may not corrupt the value seen
+// by others by mutating the embedded *big.Int.
+
+// Number of attoFIL in one FIL.
+const FilecoinPrecision = 1_000_000_000_000_000_000
+
+// Total number of FIL that will ever exist.
+const FilBase = 2_000_000_000
+
+var tokenConstants struct {
+	once        sync.Once
+	oneFil      Int
+	oneNanoFil  Int
+	totalSupply Int
+}
+
+func initTokenConstants() {
+	tokenConstants.once.Do(func() {
+		tokenConstants.oneFil = NewIntUnsigned(FilecoinPrecision)
+		tokenConstants.oneNanoFil = NewInt(FilecoinPrecision / 1_000_000_000)
+		tokenConstants.totalSupply = Mul(NewInt(FilBase), tokenConstants.oneFil)
+	})
+}
+
+// OneFil returns one FIL, in attoFIL.
+func OneFil() Int {
+	initTokenConstants()
+	return tokenConstants.oneFil.Copy()
+}
+
+// OneNanoFil returns one nanoFIL, in attoFIL.
+func OneNanoFil() Int {
+	initTokenConstants()
+	return tokenConstants.oneNanoFil.Copy()
+}
+
+// TotalFilecoinSupply returns the total number of FIL that will ever exist, in attoFIL.
+func TotalFilecoinSupply() Int {
+	initTokenConstants()
+	return tokenConstants.totalSupply.Copy()
+}
@@ -0,0 +1,21 @@
+package big
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenConstants(t *testing.T) {
+	assert.Equal(t, "1000000000000000000", OneFil().String())
+	assert.Equal(t, "1000000000", OneNanoFil().String())
+	assert.Equal(t, "2000000000000000000000000000", TotalFilecoinSupply().String())
+
+	// Mutating a returned value does not affect later results.
+	for _, f := range []func() Int{OneFil, OneNanoFil, TotalFilecoinSupply} {
+		expected := f().String()
+		v := f()
+		v.Int.SetInt64(7)
+		assert.Equal(t, expected, f().String())
+	}
+}
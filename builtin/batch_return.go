@@ -0,0 +1,54 @@
+package builtin
+
+import (
+	"github.com/filecoin-project/go-state-types/exitcode"
+)
+
+// BatchReturn is the result of a method acting on a batch of items, some of which may fail independently.
+// Only failures are recorded, so a batch which fully succeeds has no fail codes.
+type BatchReturn struct {
+	// Number of items that succeeded.
+	SuccessCount uint64
+	// The items that failed, in ascending index order.
+	FailCodes []FailCode
+}
+
+// FailCode is the exit code of a failed item of a batch, by its index in the batch.
+type FailCode struct {
+	Idx  uint64
+	Code exitcode.ExitCode
+}
+
+// Size returns the number of items in the batch.
+func (b BatchReturn) Size() int {
+	return int(b.SuccessCount) + len(b.FailCodes)
+}
+
+// AllOk reports whether every item succeeded.
+func (b BatchReturn) AllOk() bool {
+	return len(b.FailCodes) == 0
+}
+
+// Codes returns the exit code of each item of the batch, in order.
+func (b BatchReturn) Codes() []exitcode.ExitCode {
+	codes := make([]exitcode.ExitCode, b.Size())
+	for _, fc := range b.FailCodes {
+		if fc.Idx < uint64(len(codes)) {
+			codes[fc.Idx] = fc.Code
+		}
+	}
+	return codes
+}
+
+// CodeAt returns the exit code of the item with some index, failing if the index is out of the batch's range.
+func (b BatchReturn) CodeAt(n uint64) (exitcode.ExitCode, error) {
+	if n >= uint64(b.Size()) {
+		return exitcode.Ok, exitcode.ErrIllegalArgument.Wrapf("index %d out of range for batch of %d", n, b.Size())
+	}
+	for _, fc := range b.FailCodes {
+		if fc.Idx == n {
+			return fc.Code, nil
+		}
+	}
+	return exitcode.Ok, nil
+}
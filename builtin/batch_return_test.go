@@ -0,0 +1,38 @@
+package builtin_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/exitcode"
+)
+
+func TestBatchReturn(t *testing.T) {
+	ret := builtin.BatchReturn{
+		SuccessCount: 3,
+		FailCodes:    []builtin.FailCode{{Idx: 1, Code: exitcode.ErrForbidden}, {Idx: 4, Code: exitcode.ErrNotFound}},
+	}
+	assert.Equal(t, 5, ret.Size())
+	assert.False(t, ret.AllOk())
+	assert.Equal(t, []exitcode.ExitCode{exitcode.Ok, exitcode.ErrForbidden, exitcode.Ok, exitcode.Ok, exitcode.ErrNotFound}, ret.Codes())
+
+	code, err := ret.CodeAt(4)
+	require.NoError(t, err)
+	assert.Equal(t, exitcode.ErrNotFound, code)
+	code, err = ret.CodeAt(0)
+	require.NoError(t, err)
+	assert.Equal(t, exitcode.Ok, code)
+	_, err = ret.CodeAt(5)
+	assert.Error(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, ret.MarshalCBOR(&buf))
+	var decoded builtin.BatchReturn
+	require.NoError(t, decoded.UnmarshalCBOR(&buf))
+	assert.Equal(t, ret, decoded)
+	assert.True(t, builtin.BatchReturn{SuccessCount: 2}.AllOk())
+}
@@ -0,0 +1,117 @@
+package builtin
+
+import (
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/manifest"
+)
+
+// Up to actors version 7, builtin actor code CIDs were not the hash of any code, but fixed identifiers:
+// raw CIDs with an identity multihash of "fil/<n>/<name>". Version 0 actors use n = 1.
+// From version 8, code CIDs are defined by the manifest of the actors bundle, which must be loaded
+// with the manifest package before those code CIDs can be recognised.
+
+var legacyVersions = []actors.Version{
+	actors.Version0,
+	actors.Version2,
+	actors.Version3,
+	actors.Version4,
+	actors.Version5,
+	actors.Version6,
+	actors.Version7,
+}
+
+type actorVersionedName struct {
+	name    string
+	version actors.Version
+}
+
+// Legacy code CIDs by actors version and name, and the inverse.
+var (
+	legacyCodeIDs   = map[actors.Version]map[string]cid.Cid{}
+	legacyCodeNames = map[cid.Cid]actorVersionedName{}
+)
+
+func init() {
+	for _, av := range legacyVersions {
+		codes := make(map[string]cid.Cid)
+		for _, name := range manifest.GetBuiltinActorsKeys() {
+			c := makeLegacyCodeID(av, name)
+			codes[name] = c
+			legacyCodeNames[c] = actorVersionedName{name: name, version: av}
+		}
+		legacyCodeIDs[av] = codes
+	}
+}
+
+func makeLegacyCodeID(av actors.Version, name string) cid.Cid {
+	n := int(av)
+	if av == actors.Version0 {
+		n = 1
+	}
+	hash, err := mh.Sum([]byte(fmt.Sprintf("fil/%d/%s", n, name)), mh.IDENTITY, -1)
+	if err != nil {
+		panic(err)
+	}
+	return cid.NewCidV1(cid.Raw, hash)
+}
+
+// GetActorCodeID returns the code CID of a named builtin actor at an actors version.
+// Code CIDs for versions after actors.LastLegacyVersion are available only once the corresponding bundle
+// manifest has been loaded.
+func GetActorCodeID(av actors.Version, name string) (cid.Cid, bool) {
+	if av <= actors.LastLegacyVersion {
+		c, ok := legacyCodeIDs[av][name]
+		return c, ok
+	}
+	return manifest.GetActorCodeID(av, name)
+}
+
+// ActorNameAndVersionByCode returns the name and actors version of a builtin actor code CID.
+func ActorNameAndVersionByCode(c cid.Cid) (string, actors.Version, bool) {
+	if vn, ok := legacyCodeNames[c]; ok {
+		return vn.name, vn.version, true
+	}
+	return manifest.GetActorNameByCode(c)
+}
+
+// ActorNameByCode returns the name of the builtin actor with a code CID.
+func ActorNameByCode(c cid.Cid) (string, bool) {
+	name, _, ok := ActorNameAndVersionByCode(c)
+	return name, ok
+}
+
+// IsBuiltinActor returns whether a code CID belongs to a builtin actor of any version.
+func IsBuiltinActor(c cid.Cid) bool {
+	_, ok := ActorNameByCode(c)
+	return ok
+}
+
+// IsStorageMinerActor returns whether a code CID belongs to the storage miner actor of any version.
+func IsStorageMinerActor(c cid.Cid) bool {
+	return isActor(c, manifest.MinerKey)
+}
+
+// IsAccountActor returns whether a code CID belongs to the account actor of any version.
+func IsAccountActor(c cid.Cid) bool {
+	return isActor(c, manifest.AccountKey)
+}
+
+// IsMultisigActor returns whether a code CID belongs to the multisig actor of any version.
+func IsMultisigActor(c cid.Cid) bool {
+	return isActor(c, manifest.MultisigKey)
+}
+
+// IsPaymentChannelActor returns whether a code CID belongs to the payment channel actor of any version.
+func IsPaymentChannelActor(c cid.Cid) bool {
+	return isActor(c, manifest.PaychKey)
+}
+
+func isActor(c cid.Cid, name string) bool {
+	n, ok := ActorNameByCode(c)
+	return ok && n == name
+}
@@ -0,0 +1,73 @@
+package builtin_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/manifest"
+)
+
+func TestLegacyActorCodes(t *testing.T) {
+	account0, ok := builtin.GetActorCodeID(actors.Version0, manifest.AccountKey)
+	require.True(t, ok)
+	assert.Equal(t, "bafkqadlgnfwc6mjpmfrwg33vnz2a", account0.String())
+
+	miner7, ok := builtin.GetActorCodeID(actors.Version7, manifest.MinerKey)
+	require.True(t, ok)
+	name, av, ok := builtin.ActorNameAndVersionByCode(miner7)
+	require.True(t, ok)
+	assert.Equal(t, manifest.MinerKey, name)
+	assert.Equal(t, actors.Version7, av)
+
+	assert.True(t, builtin.IsBuiltinActor(account0))
+	assert.True(t, builtin.IsAccountActor(account0))
+	assert.False(t, builtin.IsStorageMinerActor(account0))
+	assert.True(t, builtin.IsStorageMinerActor(miner7))
+
+	_, ok = builtin.GetActorCodeID(actors.Version7, manifest.DatacapKey)
+	assert.False(t, ok)
+}
+
+func TestBundledActorCodes(t *testing.T) {
+	defer manifest.ClearManifests()
+
+	hash, err := mh.Sum([]byte("some wasm"), mh.BLAKE2B_MIN+31, -1)
+	require.NoError(t, err)
+	minerCode := cid.NewCidV1(cid.Raw, hash)
+
+	_, ok := builtin.GetActorCodeID(actors.Version8, manifest.MinerKey)
+	assert.False(t, ok)
+	assert.False(t, builtin.IsBuiltinActor(minerCode))
+
+	m := manifest.Manifest{Version: manifest.ManifestVersion}
+	require.NoError(t, m.Load(blocks{m.Data: dataBytes(t, manifest.MinerKey, minerCode)}))
+	manifest.Register(actors.Version8, cid.Undef, &m)
+
+	c, ok := builtin.GetActorCodeID(actors.Version8, manifest.MinerKey)
+	require.True(t, ok)
+	assert.Equal(t, minerCode, c)
+	assert.True(t, builtin.IsStorageMinerActor(minerCode))
+	_, av, ok := builtin.ActorNameAndVersionByCode(minerCode)
+	require.True(t, ok)
+	assert.Equal(t, actors.Version8, av)
+}
+
+type blocks map[cid.Cid][]byte
+
+func (b blocks) GetBlock(c cid.Cid) ([]byte, error) {
+	return b[c], nil
+}
+
+func dataBytes(t *testing.T, name string, code cid.Cid) []byte {
+	var buf bytes.Buffer
+	data := manifest.ManifestData{Entries: []manifest.ManifestEntry{{Name: name, Code: code}}}
+	require.NoError(t, data.MarshalCBOR(&buf))
+	return buf.Bytes()
+}
@@ -0,0 +1,27 @@
+package datacap
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// State is the state of the datacap actor.
+type State struct {
+	// The verified registry actor, the only address which may mint and burn datacap.
+	Governor addr.Address
+	Token    TokenState
+}
+
+// TokenState is the state of an FRC-46 token.
+type TokenState struct {
+	// Total supply of the token. This equals the sum of all balances.
+	Supply abi.TokenAmount
+	// HAMT of balances, keyed by holder actor ID.
+	Balances cid.Cid
+	// HAMT of HAMTs of allowances, keyed by owner then by operator actor ID.
+	Allowances cid.Cid
+	// Bit width of the balance and allowance HAMTs.
+	HamtBitWidth uint64
+}
@@ -0,0 +1,69 @@
+package datacap
+
+import (
+	addr "github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// Types of the fungible token interface (FRC-46) implemented by the datacap actor.
+// Token amounts are in atto-units with 18 decimal places, like FIL.
+
+// Identifies the type of a payload delivered to an actor's universal receiver hook.
+// Receiver types are 32-bit, but held in a uint64 for the CBOR encoder.
+type ReceiverType uint64
+
+// Receiver type of the notification delivered to a token recipient (FRC-42 hash of "FRC46").
+const FRC46TokenType = ReceiverType(2233613279)
+
+// Parameters to Transfer, moving tokens from the caller to another address.
+type TransferParams struct {
+	To     addr.Address
+	Amount abi.TokenAmount
+	// Opaque data passed through to the recipient's receiver hook.
+	OperatorData []byte
+}
+
+type TransferReturn struct {
+	FromBalance abi.TokenAmount
+	ToBalance   abi.TokenAmount
+	// Data returned by the recipient's receiver hook.
+	RecipientData []byte
+}
+
+// Parameters to TransferFrom, by which an operator moves tokens from an owner (within its allowance) to
+// another address.
+type TransferFromParams struct {
+	From   addr.Address
+	To     addr.Address
+	Amount abi.TokenAmount
+	// Opaque data passed through to the recipient's receiver hook.
+	OperatorData []byte
+}
+
+type TransferFromReturn struct {
+	FromBalance abi.TokenAmount
+	ToBalance   abi.TokenAmount
+	Allowance   abi.TokenAmount
+	// Data returned by the recipient's receiver hook.
+	RecipientData []byte
+}
+
+// Parameters to an actor's universal receiver hook.
+type UniversalReceiverParams struct {
+	// Type of the payload, e.g. FRC46TokenType.
+	Type_   ReceiverType
+	Payload []byte
+}
+
+// The payload delivered to a token recipient's receiver hook, of type FRC46TokenType.
+type FRC46TokenReceived struct {
+	From     abi.ActorID
+	To       abi.ActorID
+	Operator abi.ActorID
+	Amount   abi.TokenAmount
+	// Data provided by the operator.
+	OperatorData []byte
+	// Data provided by the token actor.
+	TokenData []byte
+}
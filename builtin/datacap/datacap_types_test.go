@@ -0,0 +1,40 @@
+package datacap_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/datacap"
+)
+
+func TestUniversalReceiverParamsRoundTrip(t *testing.T) {
+	received := datacap.FRC46TokenReceived{
+		From:         abi.ActorID(100),
+		To:           abi.ActorID(101),
+		Operator:     abi.ActorID(102),
+		Amount:       big.Mul(big.NewInt(5), big.NewInt(1e18)),
+		OperatorData: []byte("operator"),
+		TokenData:    nil,
+	}
+	var payload bytes.Buffer
+	require.NoError(t, received.MarshalCBOR(&payload))
+
+	params := datacap.UniversalReceiverParams{Type_: datacap.FRC46TokenType, Payload: payload.Bytes()}
+	var buf bytes.Buffer
+	require.NoError(t, params.MarshalCBOR(&buf))
+	// array(2), uint32 type.
+	assert.Equal(t, []byte{0x82, 0x1a, 0x85, 0x22, 0x3b, 0xdf}, buf.Bytes()[:6])
+
+	var decodedParams datacap.UniversalReceiverParams
+	require.NoError(t, decodedParams.UnmarshalCBOR(&buf))
+	assert.Equal(t, params, decodedParams)
+
+	var decoded datacap.FRC46TokenReceived
+	require.NoError(t, decoded.UnmarshalCBOR(bytes.NewReader(decodedParams.Payload)))
+	assert.Equal(t, received, decoded)
+}
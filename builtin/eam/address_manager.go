@@ -0,0 +1,139 @@
+package eam
+
+import (
+	"encoding/binary"
+
+	addr "github.com/filecoin-project/go-address"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	initact "github.com/filecoin-project/go-state-types/builtin/init"
+	"github.com/filecoin-project/go-state-types/eth"
+)
+
+// An AddressManager is an actor that assigns delegated (f4) addresses. The namespace of a delegated address is
+// the actor ID of its manager, which alone decides which sub-addresses are valid in that namespace.
+type AddressManager interface {
+	// Namespace returns the actor ID of the manager.
+	Namespace() abi.ActorID
+	// ValidateSubaddress checks that the manager may assign a sub-address.
+	ValidateSubaddress(subaddress []byte) error
+}
+
+// A Registry holds address managers by namespace, to validate delegated addresses off-chain.
+// The zero value is an empty registry.
+type Registry struct {
+	managers map[abi.ActorID]AddressManager
+}
+
+// NewRegistry returns a registry of some address managers, which must have distinct namespaces.
+func NewRegistry(managers ...AddressManager) (*Registry, error) {
+	r := &Registry{}
+	for _, m := range managers {
+		if err := r.Register(m); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds an address manager to the registry. Its namespace must not already have a manager.
+func (r *Registry) Register(m AddressManager) error {
+	ns := m.Namespace()
+	if _, found := r.managers[ns]; found {
+		return xerrors.Errorf("namespace %d already has an address manager", ns)
+	}
+	if r.managers == nil {
+		r.managers = map[abi.ActorID]AddressManager{}
+	}
+	r.managers[ns] = m
+	return nil
+}
+
+// Manager returns the address manager of a namespace, if registered.
+func (r *Registry) Manager(namespace abi.ActorID) (AddressManager, bool) {
+	m, found := r.managers[namespace]
+	return m, found
+}
+
+// NewAddress returns the delegated address for a sub-address in a namespace, checking that the namespace's
+// manager may assign it.
+func (r *Registry) NewAddress(namespace abi.ActorID, subaddress []byte) (addr.Address, error) {
+	m, found := r.managers[namespace]
+	if !found {
+		return addr.Undef, xerrors.Errorf("no address manager for namespace %d", namespace)
+	}
+	if err := m.ValidateSubaddress(subaddress); err != nil {
+		return addr.Undef, xerrors.Errorf("invalid sub-address in namespace %d: %w", namespace, err)
+	}
+	return initact.NewExec4Address(namespace, subaddress)
+}
+
+// ValidateAddress checks that an address is a delegated address that its namespace's manager may assign.
+func (r *Registry) ValidateAddress(a addr.Address) error {
+	namespace, subaddress, err := SplitDelegatedAddress(a)
+	if err != nil {
+		return err
+	}
+	m, found := r.managers[namespace]
+	if !found {
+		return xerrors.Errorf("no address manager for namespace of %s", a)
+	}
+	if err := m.ValidateSubaddress(subaddress); err != nil {
+		return xerrors.Errorf("invalid sub-address in %s: %w", a, err)
+	}
+	return nil
+}
+
+// SplitDelegatedAddress returns the namespace and sub-address of a delegated address.
+func SplitDelegatedAddress(a addr.Address) (abi.ActorID, []byte, error) {
+	if a.Protocol() != addr.Delegated {
+		return 0, nil, xerrors.Errorf("address %s is not a delegated address", a)
+	}
+	payload := a.Payload()
+	namespace, n := binary.Uvarint(payload)
+	if n <= 0 {
+		return 0, nil, xerrors.Errorf("invalid delegated address namespace in %s", a)
+	}
+	return abi.ActorID(namespace), payload[n:], nil
+}
+
+// EthereumAddressManager is the builtin Ethereum address manager, which assigns contracts the Ethereum
+// addresses the EVM would. Its sub-addresses are Ethereum addresses, excluding masked ID addresses, which
+// stand for ID addresses instead.
+type EthereumAddressManager struct{}
+
+var _ AddressManager = EthereumAddressManager{}
+
+func (EthereumAddressManager) Namespace() abi.ActorID {
+	return builtin.EthereumAddressManagerActorID
+}
+
+func (EthereumAddressManager) ValidateSubaddress(subaddress []byte) error {
+	ea, err := eth.EthAddressFromBytes(subaddress)
+	if err != nil {
+		return err
+	}
+	if _, isID := ea.ActorID(); isID {
+		return xerrors.Errorf("sub-address %s is a masked ID address", ea)
+	}
+	return nil
+}
+
+// CreateAddress returns the delegated address of a contract deployed with Create.
+func (m EthereumAddressManager) CreateAddress(sender eth.EthAddress, params *CreateParams) (addr.Address, error) {
+	return m.contractAddress(eth.NewContractAddress(sender, params.Nonce))
+}
+
+// Create2Address returns the delegated address of a contract deployed with Create2.
+func (m EthereumAddressManager) Create2Address(sender eth.EthAddress, params *Create2Params) (addr.Address, error) {
+	return m.contractAddress(eth.NewContract2Address(sender, params.Salt, params.Initcode))
+}
+
+func (m EthereumAddressManager) contractAddress(ea eth.EthAddress) (addr.Address, error) {
+	if err := m.ValidateSubaddress(ea[:]); err != nil {
+		return addr.Undef, err
+	}
+	return initact.NewExec4Address(m.Namespace(), ea[:])
+}
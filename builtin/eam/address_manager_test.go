@@ -0,0 +1,118 @@
+package eam_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/eam"
+	"github.com/filecoin-project/go-state-types/eth"
+)
+
+// An address manager accepting only 4-byte sub-addresses.
+type fixedLengthManager abi.ActorID
+
+func (m fixedLengthManager) Namespace() abi.ActorID {
+	return abi.ActorID(m)
+}
+
+func (m fixedLengthManager) ValidateSubaddress(subaddress []byte) error {
+	if len(subaddress) != 4 {
+		return xerrors.Errorf("sub-address must be 4 bytes")
+	}
+	return nil
+}
+
+func TestRegistry(t *testing.T) {
+	r, err := eam.NewRegistry(eam.EthereumAddressManager{}, fixedLengthManager(1000))
+	require.NoError(t, err)
+	assert.Error(t, r.Register(fixedLengthManager(10)))
+
+	a, err := r.NewAddress(1000, []byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	assert.Equal(t, addr.Delegated, a.Protocol())
+	require.NoError(t, r.ValidateAddress(a))
+	ns, sub, err := eam.SplitDelegatedAddress(a)
+	require.NoError(t, err)
+	assert.Equal(t, abi.ActorID(1000), ns)
+	assert.Equal(t, []byte{1, 2, 3, 4}, sub)
+
+	_, err = r.NewAddress(1000, []byte{1, 2, 3})
+	assert.Error(t, err)
+	_, err = r.NewAddress(1001, []byte{1, 2, 3, 4})
+	assert.Error(t, err)
+
+	unmanaged, err := addr.NewDelegatedAddress(1001, []byte{1, 2, 3, 4})
+	require.NoError(t, err)
+	assert.Error(t, r.ValidateAddress(unmanaged))
+	wrongLength, err := addr.NewDelegatedAddress(1000, []byte{1, 2, 3})
+	require.NoError(t, err)
+	assert.Error(t, r.ValidateAddress(wrongLength))
+	id, err := addr.NewIDAddress(1000)
+	require.NoError(t, err)
+	assert.Error(t, r.ValidateAddress(id))
+
+	var empty eam.Registry
+	_, found := empty.Manager(10)
+	assert.False(t, found)
+	require.NoError(t, empty.Register(eam.EthereumAddressManager{}))
+	_, found = empty.Manager(10)
+	assert.True(t, found)
+}
+
+func TestEthereumAddressManager(t *testing.T) {
+	m := eam.EthereumAddressManager{}
+	masked := eth.NewMaskedIDAddress(1234)
+	assert.Error(t, m.ValidateSubaddress(masked[:]))
+	assert.Error(t, m.ValidateSubaddress(make([]byte, 19)))
+	require.NoError(t, m.ValidateSubaddress(make([]byte, 20)))
+
+	sender, err := eth.ParseEthAddress("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+	require.NoError(t, err)
+	a, err := m.CreateAddress(sender, &eam.CreateParams{Nonce: 0})
+	require.NoError(t, err)
+	ea, err := eth.EthAddressFromFilecoinAddress(a)
+	require.NoError(t, err)
+	assert.Equal(t, "cd234a471b72ba2f1ccf0a70fcaba648a5eecd8d", hex.EncodeToString(ea[:]))
+
+	a2, err := m.Create2Address(sender, &eam.Create2Params{Initcode: []byte{0}})
+	require.NoError(t, err)
+	assert.Equal(t, eth.NewContract2Address(sender, [32]byte{}, []byte{0}).Bytes(), mustSub(t, a2))
+}
+
+func mustSub(t *testing.T, a addr.Address) []byte {
+	_, sub, err := eam.SplitDelegatedAddress(a)
+	require.NoError(t, err)
+	return sub
+}
+
+func TestCreateTypesRoundTrip(t *testing.T) {
+	robust, err := addr.NewActorAddress([]byte("actor"))
+	require.NoError(t, err)
+	ret := eam.CreateReturn{ActorID: 1001, RobustAddress: &robust, EthAddress: [20]byte{1, 2, 3}}
+	var buf bytes.Buffer
+	require.NoError(t, ret.MarshalCBOR(&buf))
+	var decoded eam.CreateReturn
+	require.NoError(t, decoded.UnmarshalCBOR(&buf))
+	assert.Equal(t, ret, decoded)
+
+	params := eam.Create2Params{Initcode: []byte{0x60, 0x00}, Salt: [32]byte{9}}
+	buf.Reset()
+	require.NoError(t, params.MarshalCBOR(&buf))
+	var decodedParams eam.Create2Params
+	require.NoError(t, decodedParams.UnmarshalCBOR(&buf))
+	assert.Equal(t, params, decodedParams)
+
+	ext := eam.CreateExternalParams{0x60, 0x00}
+	buf.Reset()
+	require.NoError(t, ext.MarshalCBOR(&buf))
+	var decodedExt eam.CreateExternalParams
+	require.NoError(t, decodedExt.UnmarshalCBOR(&buf))
+	assert.Equal(t, ext, decodedExt)
+}
@@ -0,0 +1,56 @@
+// Package eam holds the types of the Ethereum address manager (EAM) actor, and the rules by which it and other
+// address managers assign delegated (f4) addresses.
+package eam
+
+import (
+	"io"
+
+	addr "github.com/filecoin-project/go-address"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// Parameters to Create, deploying a contract at the address the EVM's CREATE opcode would assign.
+type CreateParams struct {
+	Initcode []byte
+	// The nonce of the creating EVM actor.
+	Nonce uint64
+}
+
+// Parameters to Create2, deploying a contract at the address the EVM's CREATE2 opcode would assign.
+type Create2Params struct {
+	Initcode []byte
+	Salt     [32]byte
+}
+
+// Parameters to CreateExternal, by which an Ethereum account deploys a contract with its init code.
+// The init code is encoded as a bare byte string.
+type CreateExternalParams []byte
+
+func (p CreateExternalParams) MarshalCBOR(w io.Writer) error {
+	if err := cbg.CborWriteHeader(w, cbg.MajByteString, uint64(len(p))); err != nil {
+		return err
+	}
+	_, err := w.Write(p)
+	return err
+}
+
+func (p *CreateExternalParams) UnmarshalCBOR(r io.Reader) error {
+	b, err := cbg.ReadByteArray(r, cbg.ByteArrayMaxLen)
+	if err != nil {
+		return err
+	}
+	*p = b
+	return nil
+}
+
+// The actor created by Create, Create2 or CreateExternal.
+type CreateReturn struct {
+	ActorID uint64
+	// The actor's robust (f2) address, if it was assigned one.
+	RobustAddress *addr.Address
+	// The actor's Ethereum address, which is also the sub-address of its delegated address.
+	EthAddress [20]byte
+}
+
+type Create2Return = CreateReturn
+type CreateExternalReturn = CreateReturn
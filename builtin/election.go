@@ -0,0 +1,178 @@
+package builtin
+
+import (
+	gobig "math/big"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// Expected consensus: the number of times a miner wins an epoch's election (its WinCount) follows a Poisson
+// distribution with rate proportional to its share of network power. The computations here use Q.256
+// fixed-point arithmetic, and are exact functions of their inputs so that all implementations agree.
+
+// The expected number of winning blocks per epoch.
+const ExpectedLeadersPerEpoch = 5
+
+// The maximum WinCount of a single election.
+const MaxWinCount = 3 * ExpectedLeadersPerEpoch
+
+// The number of fractional bits in the fixed-point numbers of the election.
+const electionPrecision = 256
+
+var (
+	// Coefficients of the numerator and denominator of a rational approximation of e^-x,
+	// highest order first, in Q.256.
+	expNumCoef  []*gobig.Int
+	expDenoCoef []*gobig.Int
+)
+
+func init() {
+	// The coefficients are given in Q.128.
+	parse := func(coefs []string) []*gobig.Int {
+		out := make([]*gobig.Int, len(coefs))
+		for i, coef := range coefs {
+			c, ok := new(gobig.Int).SetString(coef, 10)
+			if !ok {
+				panic(xerrors.Errorf("could not parse %q as a decimal integer", coef))
+			}
+			out[i] = c.Lsh(c, electionPrecision-128)
+		}
+		return out
+	}
+	expNumCoef = parse([]string{
+		"-648770010757830093818553637600",
+		"67469480939593786226847644286976",
+		"-3197587544499098424029388939001856",
+		"89244641121992890118377641805348864",
+		"-1579656163641440567800982336819953664",
+		"17685496037279256458459817590917169152",
+		"-115682590513835356866803355398940131328",
+		"340282366920938463463374607431768211456",
+	})
+	expDenoCoef = parse([]string{
+		"1225524182432722209606361",
+		"114095592300906098243859450",
+		"5665570424063336070530214243",
+		"194450132448609991765137938448",
+		"5068267641632683791026134915072",
+		"104716890604972796896895427629056",
+		"1748338658439454459487681798864896",
+		"23704654329841312470660182937960448",
+		"259380097567996910282699886670381056",
+		"2250336698853390384720606936038375424",
+		"14978272436876548034486263159246028800",
+		"72144088983913131323343765784380833792",
+		"224599776407103106596571252037123047424",
+		"340282366920938463463374607431768211456",
+	})
+}
+
+// ComputeWinCount computes the number of times a miner wins an election from the VRF proof of its election
+// ticket, its power and the total network power.
+// Following Algorand's sortition with the binomial distribution replaced by Poisson, the WinCount is the
+// number of times the hash of the proof, as a number in [0, 1), is below the upside-down CDF of the
+// Poisson distribution with rate ExpectedLeadersPerEpoch * power / totalPower.
+func ComputeWinCount(vrfProof []byte, power, totalPower abi.StoragePower) int64 {
+	h := blake2b.Sum256(vrfProof)
+	lhs := new(gobig.Int).SetBytes(h[:]) // Q.256, in [0, 1)
+
+	p, rhs := newPoisson(electionRate(power, totalPower))
+	var j int64
+	for lhs.Cmp(rhs) < 0 && j < MaxWinCount {
+		rhs = p.next()
+		j++
+	}
+	return j
+}
+
+// ElectionProbability returns the probability that a miner with some power wins at least one block in an
+// epoch, i.e. has a non-zero WinCount.
+func ElectionProbability(power, totalPower abi.StoragePower) float64 {
+	_, icdf := newPoisson(electionRate(power, totalPower))
+	return q256ToFloat(icdf)
+}
+
+// ExpectedWinCount returns the expected WinCount of a miner with some power, accounting for MaxWinCount.
+func ExpectedWinCount(power, totalPower abi.StoragePower) float64 {
+	// E[min(X, max)] = ∑ P(X > k) for k in [0, max).
+	p, icdf := newPoisson(electionRate(power, totalPower))
+	sum := new(gobig.Int).Set(icdf)
+	for k := 1; k < MaxWinCount; k++ {
+		sum.Add(sum, p.next())
+	}
+	return q256ToFloat(sum)
+}
+
+// BlockRewardShare returns the reward for a block with some WinCount, given the reward actor's reward
+// for the epoch. Each win earns an equal share of the epoch reward among the expected leaders.
+func BlockRewardShare(thisEpochReward abi.TokenAmount, winCount int64) abi.TokenAmount {
+	return big.Div(big.Mul(thisEpochReward, big.NewInt(winCount)), big.NewInt(ExpectedLeadersPerEpoch))
+}
+
+// The rate of the WinCount distribution, in Q.256.
+func electionRate(power, totalPower abi.StoragePower) *gobig.Int {
+	if !totalPower.GreaterThan(big.Zero()) || power.LessThan(big.Zero()) {
+		return new(gobig.Int)
+	}
+	lam := new(gobig.Int).Mul(power.Int, gobig.NewInt(ExpectedLeadersPerEpoch)) // Q.0
+	lam = lam.Lsh(lam, electionPrecision)                                       // Q.256
+	return lam.Div(lam, totalPower.Int)                                         // Q.256 / Q.0 => Q.256
+}
+
+// Computes e^-x for x in Q.256. It is most precise within [0, 1.725), where the error is less than 3.4e-30,
+// and over [0, 5) the error is less than 4.6e-15. The result is in Q.256.
+func expneg(x *gobig.Int) *gobig.Int {
+	num := polyval256(expNumCoef, x)      // Q.256
+	deno := polyval256(expDenoCoef, x)    // Q.256
+	num = num.Lsh(num, electionPrecision) // Q.512
+	return num.Div(num, deno)             // Q.512 / Q.256 => Q.256
+}
+
+// Evaluates a polynomial with Q.256 coefficients, highest order first, at a Q.256 point, in Q.256.
+func polyval256(p []*gobig.Int, x *gobig.Int) *gobig.Int {
+	res := new(gobig.Int).Set(p[0])
+	tmp := new(gobig.Int) // big.Int.Mul doesn't like when input is reused as output
+	for _, c := range p[1:] {
+		tmp = tmp.Mul(res, x)                 // Q.256 * Q.256 => Q.512
+		res = res.Rsh(tmp, electionPrecision) // Q.512 => Q.256
+		res = res.Add(res, c)
+	}
+	return res
+}
+
+// Incrementally computes the upside-down CDF of a Poisson distribution, 1 - P(X <= k), for increasing k.
+type poisson struct {
+	lam  *gobig.Int // Q.256
+	pmf  *gobig.Int // Q.256
+	icdf *gobig.Int // Q.256
+	k    uint64
+}
+
+// Returns the distribution with rate lam in Q.256, and its upside-down CDF at k = 0.
+func newPoisson(lam *gobig.Int) (*poisson, *gobig.Int) {
+	// pmf(0) = e^-lam
+	pmf := expneg(lam)
+	icdf := new(gobig.Int).Lsh(gobig.NewInt(1), electionPrecision)
+	icdf = icdf.Sub(icdf, pmf)
+	return &poisson{lam: lam, pmf: pmf, icdf: icdf}, icdf
+}
+
+// Increments k and returns the upside-down CDF at k, in Q.256.
+func (p *poisson) next() *gobig.Int {
+	// pmf(k) = pmf(k-1) * lam / k
+	p.k++
+	p.pmf = p.pmf.Div(p.pmf, new(gobig.Int).SetUint64(p.k)) // Q.256 / Q.0 => Q.256
+	tmp := new(gobig.Int).Mul(p.pmf, p.lam)                 // Q.256 * Q.256 => Q.512
+	p.pmf = p.pmf.Rsh(tmp, electionPrecision)               // Q.512 => Q.256
+	p.icdf = p.icdf.Sub(p.icdf, p.pmf)
+	return p.icdf
+}
+
+func q256ToFloat(x *gobig.Int) float64 {
+	f, _ := new(gobig.Float).SetMantExp(new(gobig.Float).SetInt(x), -electionPrecision).Float64()
+	return f
+}
@@ -0,0 +1,47 @@
+package builtin_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+)
+
+func TestElectionProbability(t *testing.T) {
+	total := big.NewInt(1000)
+	for _, power := range []int64{0, 1, 10, 100, 345, 1000} {
+		lam := builtin.ExpectedLeadersPerEpoch * float64(power) / 1000
+		assert.InDelta(t, 1-math.Exp(-lam), builtin.ElectionProbability(big.NewInt(power), total), 1e-14, "power %d", power)
+	}
+	assert.Equal(t, 0.0, builtin.ElectionProbability(big.NewInt(10), big.Zero()))
+
+	// With all the power, the expected WinCount is the number of expected leaders (truncated at the max).
+	assert.InDelta(t, 5, builtin.ExpectedWinCount(total, total), 1e-3)
+	assert.InDelta(t, 0.5, builtin.ExpectedWinCount(big.NewInt(100), total), 1e-12)
+}
+
+func TestComputeWinCount(t *testing.T) {
+	power, total := big.NewInt(100), big.NewInt(1000)
+	trials, wins := 2000, int64(0)
+	for i := 0; i < trials; i++ {
+		wins += builtin.ComputeWinCount([]byte{byte(i), byte(i >> 8)}, power, total)
+	}
+	// The mean WinCount is 0.5, with a standard error of about 0.016.
+	assert.InDelta(t, 0.5, float64(wins)/float64(trials), 0.08)
+
+	assert.Equal(t, int64(0), builtin.ComputeWinCount([]byte{1}, big.Zero(), total))
+	for i := 0; i < 10; i++ {
+		assert.LessOrEqual(t, builtin.ComputeWinCount([]byte{byte(i)}, big.NewInt(1e6), big.NewInt(1)), int64(builtin.MaxWinCount))
+	}
+}
+
+func TestBlockRewardShare(t *testing.T) {
+	reward := abi.NewTokenAmount(1000)
+	assert.Equal(t, abi.NewTokenAmount(200), builtin.BlockRewardShare(reward, 1))
+	assert.Equal(t, abi.NewTokenAmount(600), builtin.BlockRewardShare(reward, 3))
+	assert.Equal(t, abi.NewTokenAmount(0), builtin.BlockRewardShare(reward, 0))
+}
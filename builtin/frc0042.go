@@ -0,0 +1,58 @@
+package builtin
+
+import (
+	"encoding/binary"
+	"regexp"
+
+	mh "github.com/multiformats/go-multihash"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// Method numbers for exported methods of user-defined (and, from the FVM, builtin) actors are derived from
+// method names, as specified by FRC-0042, so that callers need only know the name of the method.
+// Method numbers below 2^24 are reserved for builtin actors' internal methods.
+
+const (
+	frc42HashPrefix         = "1|"
+	frc42FirstMethodNum     = 1 << 24
+	frc42MethodNumChunkSize = 4
+)
+
+var frc42MethodNameRegex = regexp.MustCompile(`^[A-Z_][a-zA-Z0-9_]*$`)
+
+// GenerateFRCMethodNum returns the FRC-0042 method number for a method name.
+// The name must begin with an upper-case letter or underscore and contain only letters, digits and underscores.
+func GenerateFRCMethodNum(name string) (abi.MethodNum, error) {
+	if !frc42MethodNameRegex.MatchString(name) {
+		return 0, xerrors.Errorf("invalid FRC-0042 method name %q", name)
+	}
+	hash, err := mh.Sum([]byte(frc42HashPrefix+name), mh.BLAKE2B_MAX, -1)
+	if err != nil {
+		return 0, err
+	}
+	decoded, err := mh.Decode(hash)
+	if err != nil {
+		return 0, err
+	}
+	// The method number is the first 4-byte (big-endian) chunk of the digest outside the reserved range.
+	digest := decoded.Digest
+	for i := 0; i+frc42MethodNumChunkSize <= len(digest); i += frc42MethodNumChunkSize {
+		n := binary.BigEndian.Uint32(digest[i : i+frc42MethodNumChunkSize])
+		if n >= frc42FirstMethodNum {
+			return abi.MethodNum(n), nil
+		}
+	}
+	return 0, xerrors.Errorf("no valid method number in hash of %q", name)
+}
+
+// MustGenerateFRCMethodNum returns the FRC-0042 method number for a method name, and panics if the name
+// is invalid. It is intended for declaring method number constants.
+func MustGenerateFRCMethodNum(name string) abi.MethodNum {
+	n, err := GenerateFRCMethodNum(name)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
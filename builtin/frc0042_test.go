@@ -0,0 +1,22 @@
+package builtin_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+)
+
+func TestGenerateFRCMethodNum(t *testing.T) {
+	assert.Equal(t, abi.MethodNum(3726118371), builtin.MustGenerateFRCMethodNum("Receive"))
+	assert.Equal(t, abi.MethodNum(3621052141), builtin.MustGenerateFRCMethodNum("TransferFrom"))
+	assert.Equal(t, abi.MethodNum(80475954), builtin.MustGenerateFRCMethodNum("Transfer"))
+
+	for _, name := range []string{"", "receive", "1Receive", "Re-ceive", "Receive!"} {
+		_, err := builtin.GenerateFRCMethodNum(name)
+		assert.Error(t, err, name)
+	}
+	assert.Panics(t, func() { builtin.MustGenerateFRCMethodNum("receive") })
+}
@@ -0,0 +1,42 @@
+// Package init computes the addresses assigned by the init actor, so that the address of an actor can be
+// predicted before it is created.
+package init
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	addr "github.com/filecoin-project/go-address"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// NewActorAddress returns the actor (f2) address assigned to the count'th actor created by a message with
+// some nonce from creator, which must be the sender's deterministic (non-ID) address.
+// The address is the hash of the CBOR-encoded creator address followed by the nonce and count, each as
+// 8 big-endian bytes.
+func NewActorAddress(creator addr.Address, nonce uint64, count uint64) (addr.Address, error) {
+	if creator.Protocol() == addr.ID {
+		return addr.Undef, xerrors.Errorf("creator %s must be a deterministic address, not an ID address", creator)
+	}
+	var buf bytes.Buffer
+	if err := creator.MarshalCBOR(&buf); err != nil {
+		return addr.Undef, xerrors.Errorf("failed to marshal creator address: %w", err)
+	}
+	var n [8]byte
+	binary.BigEndian.PutUint64(n[:], nonce)
+	buf.Write(n[:])
+	binary.BigEndian.PutUint64(n[:], count)
+	buf.Write(n[:])
+	return addr.NewActorAddress(buf.Bytes())
+}
+
+// NewExec4Address returns the delegated (f4) address assigned to an actor created with Exec4 by the address
+// manager with actor ID namespace, for a sub-address chosen by that manager.
+func NewExec4Address(namespace abi.ActorID, subaddress []byte) (addr.Address, error) {
+	if len(subaddress) == 0 {
+		return addr.Undef, xerrors.Errorf("exec4 sub-address must not be empty")
+	}
+	return addr.NewDelegatedAddress(uint64(namespace), subaddress)
+}
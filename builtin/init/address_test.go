@@ -0,0 +1,50 @@
+package init_test
+
+import (
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/builtin"
+	initact "github.com/filecoin-project/go-state-types/builtin/init"
+	"github.com/filecoin-project/go-state-types/eth"
+)
+
+func TestNewActorAddress(t *testing.T) {
+	creator, err := addr.NewSecp256k1Address([]byte("public key"))
+	require.NoError(t, err)
+
+	a, err := initact.NewActorAddress(creator, 3, 0)
+	require.NoError(t, err)
+	assert.Equal(t, addr.Actor, a.Protocol())
+
+	again, err := initact.NewActorAddress(creator, 3, 0)
+	require.NoError(t, err)
+	assert.Equal(t, a, again)
+
+	otherNonce, err := initact.NewActorAddress(creator, 4, 0)
+	require.NoError(t, err)
+	assert.NotEqual(t, a, otherNonce)
+	otherCount, err := initact.NewActorAddress(creator, 3, 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, a, otherCount)
+
+	id, err := addr.NewIDAddress(100)
+	require.NoError(t, err)
+	_, err = initact.NewActorAddress(id, 3, 0)
+	assert.Error(t, err)
+}
+
+func TestNewExec4Address(t *testing.T) {
+	ea := eth.NewContractAddress(eth.NewMaskedIDAddress(1000), 0)
+	a, err := initact.NewExec4Address(builtin.EthereumAddressManagerActorID, ea[:])
+	require.NoError(t, err)
+	expected, err := ea.ToFilecoinAddress()
+	require.NoError(t, err)
+	assert.Equal(t, expected, a)
+
+	_, err = initact.NewExec4Address(builtin.EthereumAddressManagerActorID, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package market
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// EpochUndefined is the value of a DealState epoch that has not been set.
+const EpochUndefined = abi.ChainEpoch(-1)
+
+// DealState is the market's record of a deal's progress once it is included in a sector.
+type DealState struct {
+	// 0 if not yet included in proven sector (0 is also a valid sector number).
+	SectorNumber abi.SectorNumber
+	// -1 if not yet included in proven sector
+	SectorStartEpoch abi.ChainEpoch
+	// -1 if deal state never updated
+	LastUpdatedEpoch abi.ChainEpoch
+	// -1 if deal never slashed
+	SlashEpoch abi.ChainEpoch
+}
+
+// NewDealState returns the state of a deal activated at some epoch in a sector.
+func NewDealState(sector abi.SectorNumber, activation abi.ChainEpoch) *DealState {
+	return &DealState{
+		SectorNumber:     sector,
+		SectorStartEpoch: activation,
+		LastUpdatedEpoch: EpochUndefined,
+		SlashEpoch:       EpochUndefined,
+	}
+}
+
+// ActivatedAt returns the epoch at which the deal's sector was proven, and whether it has been.
+func (s *DealState) ActivatedAt() (abi.ChainEpoch, bool) {
+	return s.SectorStartEpoch, s.SectorStartEpoch != EpochUndefined
+}
+
+// LastUpdatedAt returns the epoch up to which the deal's payments were last settled, and whether they
+// have been since activation.
+func (s *DealState) LastUpdatedAt() (abi.ChainEpoch, bool) {
+	return s.LastUpdatedEpoch, s.LastUpdatedEpoch != EpochUndefined
+}
+
+// SlashedAt returns the epoch at which the deal was slashed (its sector terminated early), and whether it
+// has been.
+func (s *DealState) SlashedAt() (abi.ChainEpoch, bool) {
+	return s.SlashEpoch, s.SlashEpoch != EpochUndefined
+}
+
+// PaymentStart returns the epoch from which the deal's payments are outstanding: the last update if any,
+// otherwise the activation. It returns false if the deal has not been activated.
+func (s *DealState) PaymentStart() (abi.ChainEpoch, bool) {
+	if e, ok := s.LastUpdatedAt(); ok {
+		return e, true
+	}
+	return s.ActivatedAt()
+}
+
+// IsActive returns whether the deal has been activated and not slashed.
+func (s *DealState) IsActive() bool {
+	_, activated := s.ActivatedAt()
+	_, slashed := s.SlashedAt()
+	return activated && !slashed
+}
+
+// IsExpired returns whether, at some epoch, an active deal has reached the end epoch of its proposal.
+// A slashed deal ended when it was slashed, so it has expired from that epoch.
+func (s *DealState) IsExpired(end, at abi.ChainEpoch) bool {
+	if slash, ok := s.SlashedAt(); ok && at >= slash {
+		return true
+	}
+	return at >= end
+}
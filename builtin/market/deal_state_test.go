@@ -0,0 +1,63 @@
+package market_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/market"
+)
+
+func TestDealStateEpochs(t *testing.T) {
+	pending := &market.DealState{
+		SectorStartEpoch: market.EpochUndefined,
+		LastUpdatedEpoch: market.EpochUndefined,
+		SlashEpoch:       market.EpochUndefined,
+	}
+	_, ok := pending.ActivatedAt()
+	assert.False(t, ok)
+	_, ok = pending.PaymentStart()
+	assert.False(t, ok)
+	assert.False(t, pending.IsActive())
+
+	s := market.NewDealState(7, 100)
+	e, ok := s.ActivatedAt()
+	assert.True(t, ok)
+	assert.Equal(t, abi.ChainEpoch(100), e)
+	_, ok = s.LastUpdatedAt()
+	assert.False(t, ok)
+	e, ok = s.PaymentStart()
+	assert.True(t, ok)
+	assert.Equal(t, abi.ChainEpoch(100), e)
+	assert.True(t, s.IsActive())
+	assert.False(t, s.IsExpired(1000, 999))
+	assert.True(t, s.IsExpired(1000, 1000))
+
+	s.LastUpdatedEpoch = 500
+	e, ok = s.PaymentStart()
+	assert.True(t, ok)
+	assert.Equal(t, abi.ChainEpoch(500), e)
+
+	s.SlashEpoch = 600
+	assert.False(t, s.IsActive())
+	e, ok = s.SlashedAt()
+	assert.True(t, ok)
+	assert.Equal(t, abi.ChainEpoch(600), e)
+	assert.False(t, s.IsExpired(1000, 599))
+	assert.True(t, s.IsExpired(1000, 600))
+}
+
+func TestDealStateCBOR(t *testing.T) {
+	s := market.NewDealState(7, 100)
+	var buf bytes.Buffer
+	require.NoError(t, s.MarshalCBOR(&buf))
+	// The undefined epochs encode as -1.
+	assert.Equal(t, []byte{0x84, 0x07, 0x18, 0x64, 0x20, 0x20}, buf.Bytes())
+
+	var out market.DealState
+	require.NoError(t, out.UnmarshalCBOR(&buf))
+	assert.Equal(t, *s, out)
+}
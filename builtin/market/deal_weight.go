@@ -0,0 +1,44 @@
+// Package market holds types and policy of the storage market actor.
+package market
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+)
+
+// DealWeight returns the weight of a deal: the space-time it occupies, in byte-epochs.
+func DealWeight(size abi.PaddedPieceSize, duration abi.ChainEpoch) abi.DealWeight {
+	return big.Mul(big.NewIntUnsigned(uint64(size)), big.NewInt(int64(duration)))
+}
+
+// DealSpaceTime returns the space-time a deal contributes to a sector activated at some epoch: its size over
+// the epochs from the sector's activation to the end of the deal. A deal contributes nothing to a sector
+// activated after it ends.
+func DealSpaceTime(size abi.PaddedPieceSize, dealEnd, sectorActivation abi.ChainEpoch) abi.DealWeight {
+	if dealEnd <= sectorActivation {
+		return big.Zero()
+	}
+	return DealWeight(size, dealEnd-sectorActivation)
+}
+
+// DealQAPower returns the quality-adjusted power contributed to a sector by a deal with some space-time
+// in it, given the sector's duration (from activation to expiration).
+// The quality-adjusted power of a sector is (up to rounding) the sum of that of its deals and that of the
+// space not occupied by deals, the latter being its raw size.
+func DealQAPower(dealSpaceTime abi.DealWeight, sectorDuration abi.ChainEpoch, verified bool) abi.StoragePower {
+	if sectorDuration <= 0 {
+		return big.Zero()
+	}
+	multiplier := abi.DealQualityMultiplier(verified)
+	weighted := big.Lsh(big.Mul(dealSpaceTime, big.NewInt(multiplier)), builtin.SectorQualityPrecision)
+	perEpoch := big.Div(big.Div(weighted, big.NewInt(int64(sectorDuration))), big.NewInt(builtin.QualityBaseMultiplier))
+	return big.Rsh(perEpoch, builtin.SectorQualityPrecision)
+}
+
+// SectorQAPower returns the quality-adjusted power of a sector holding deals with the given total space-time,
+// as the miner actor computes it.
+func SectorQAPower(size abi.SectorSize, duration abi.ChainEpoch, dealWeight, verifiedDealWeight abi.DealWeight) abi.StoragePower {
+	return miner.QAPowerForWeight(size, duration, dealWeight, verifiedDealWeight)
+}
@@ -0,0 +1,36 @@
+package market_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/market"
+)
+
+func TestDealWeight(t *testing.T) {
+	assert.Equal(t, big.NewInt(2048*100), market.DealWeight(2048, 100))
+	assert.Equal(t, big.NewInt(2048*60), market.DealSpaceTime(2048, 160, 100))
+	assert.Equal(t, big.Zero(), market.DealSpaceTime(2048, 100, 100))
+}
+
+func TestDealQAPower(t *testing.T) {
+	const sectorSize = abi.SectorSize(32 << 30)
+	duration := abi.ChainEpoch(180 * builtin.EpochsInDay)
+	half := market.DealWeight(abi.PaddedPieceSize(sectorSize/2), duration)
+
+	// Committed capacity and unverified deals have the sector's raw power.
+	assert.Equal(t, big.NewIntUnsigned(uint64(sectorSize)), market.SectorQAPower(sectorSize, duration, big.Zero(), big.Zero()))
+	assert.Equal(t, big.NewIntUnsigned(uint64(sectorSize)), market.SectorQAPower(sectorSize, duration, half, big.Zero()))
+	assert.Equal(t, big.NewIntUnsigned(uint64(sectorSize/2)), market.DealQAPower(half, duration, false))
+
+	// A verified deal filling half the sector has ten times its raw power.
+	verified := market.DealQAPower(half, duration, true)
+	assert.Equal(t, big.NewIntUnsigned(uint64(sectorSize/2)*10), verified)
+	assert.Equal(t, big.Add(verified, big.NewIntUnsigned(uint64(sectorSize/2))), market.SectorQAPower(sectorSize, duration, big.Zero(), half))
+
+	assert.Equal(t, big.Zero(), market.DealQAPower(half, 0, true))
+}
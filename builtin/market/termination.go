@@ -0,0 +1,109 @@
+package market
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// OnMinerSectorsTerminateParams are the parameters of the market's OnMinerSectorsTerminate method, through
+// which a miner reports the deals of its sectors terminated at an epoch.
+type OnMinerSectorsTerminateParams struct {
+	Epoch   abi.ChainEpoch
+	DealIDs []abi.DealID
+}
+
+// DealTerms are the terms of a deal proposal that determine the outcome of slashing the deal.
+type DealTerms struct {
+	Client               addr.Address
+	Provider             addr.Address
+	StartEpoch           abi.ChainEpoch
+	EndEpoch             abi.ChainEpoch
+	StoragePricePerEpoch abi.TokenAmount
+	ProviderCollateral   abi.TokenAmount
+	ClientCollateral     abi.TokenAmount
+}
+
+// DealAccessor provides the market state of deals, such as from the proposals and states AMTs of a market
+// actor's state.
+type DealAccessor interface {
+	// DealTerms returns the terms of a deal's proposal, and whether the proposal is in the state.
+	DealTerms(id abi.DealID) (*DealTerms, bool, error)
+	// DealState returns the state of a deal, and whether the deal has one (has been activated).
+	DealState(id abi.DealID) (*DealState, bool, error)
+}
+
+// SlashedDeal describes a deal slashed by the termination of its sector, and the settlement the market makes
+// for it.
+type SlashedDeal struct {
+	DealID     abi.DealID
+	Client     addr.Address
+	Provider   addr.Address
+	SlashEpoch abi.ChainEpoch
+	// Payment to the provider for storage up to the slash epoch, not yet settled.
+	ProviderPayment abi.TokenAmount
+	// Payment for storage from the slash epoch to the end of the deal, refunded to the client.
+	ClientRefund abi.TokenAmount
+	// The provider's collateral, which is burnt.
+	SlashedCollateral abi.TokenAmount
+	// The client's collateral, which is unlocked.
+	ClientCollateral abi.TokenAmount
+}
+
+// SlashedDeals returns the deals that become slashed when a provider's sectors holding the deals of params
+// terminate, as the market's OnMinerSectorsTerminate decides, in the order of params.
+// Deals no longer in the state, already past their end epoch, or already slashed are not slashed again.
+// It is an error for a deal to belong to another provider, or to have no state.
+func SlashedDeals(deals DealAccessor, provider addr.Address, params *OnMinerSectorsTerminateParams) ([]SlashedDeal, error) {
+	var slashed []SlashedDeal
+	for _, id := range params.DealIDs {
+		terms, found, err := deals.DealTerms(id)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to load proposal for deal %d: %w", id, err)
+		}
+		if !found {
+			// The deal expired and was cleaned up before its sector terminated.
+			continue
+		}
+		if terms.Provider != provider {
+			return nil, xerrors.Errorf("deal %d has provider %s, not %s", id, terms.Provider, provider)
+		}
+		if terms.EndEpoch <= params.Epoch {
+			// The deal ended, and is settled normally.
+			continue
+		}
+
+		state, found, err := deals.DealState(id)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to load state for deal %d: %w", id, err)
+		}
+		if !found {
+			return nil, xerrors.Errorf("no state for deal %d", id)
+		}
+		if _, ok := state.SlashedAt(); ok {
+			continue
+		}
+
+		paymentStart := terms.StartEpoch
+		if updated, ok := state.LastUpdatedAt(); ok && updated > paymentStart {
+			paymentStart = updated
+		}
+		paidUntil := params.Epoch
+		if paidUntil < paymentStart {
+			paidUntil = paymentStart
+		}
+		slashed = append(slashed, SlashedDeal{
+			DealID:            id,
+			Client:            terms.Client,
+			Provider:          terms.Provider,
+			SlashEpoch:        params.Epoch,
+			ProviderPayment:   big.Mul(terms.StoragePricePerEpoch, big.NewInt(int64(paidUntil-paymentStart))),
+			ClientRefund:      big.Mul(terms.StoragePricePerEpoch, big.NewInt(int64(terms.EndEpoch-paidUntil))),
+			SlashedCollateral: terms.ProviderCollateral,
+			ClientCollateral:  terms.ClientCollateral,
+		})
+	}
+	return slashed, nil
+}
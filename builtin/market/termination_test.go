@@ -0,0 +1,111 @@
+package market_test
+
+import (
+	"bytes"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/market"
+)
+
+type testDeals struct {
+	terms  map[abi.DealID]*market.DealTerms
+	states map[abi.DealID]*market.DealState
+}
+
+func (d testDeals) DealTerms(id abi.DealID) (*market.DealTerms, bool, error) {
+	if id == 99 {
+		return nil, false, xerrors.New("broken")
+	}
+	t, ok := d.terms[id]
+	return t, ok, nil
+}
+
+func (d testDeals) DealState(id abi.DealID) (*market.DealState, bool, error) {
+	s, ok := d.states[id]
+	return s, ok, nil
+}
+
+func TestSlashedDeals(t *testing.T) {
+	client, provider, other := mustID(t, 100), mustID(t, 1000), mustID(t, 1001)
+	terms := func(p addr.Address, start, end abi.ChainEpoch) *market.DealTerms {
+		return &market.DealTerms{
+			Client:               client,
+			Provider:             p,
+			StartEpoch:           start,
+			EndEpoch:             end,
+			StoragePricePerEpoch: big.NewInt(10),
+			ProviderCollateral:   big.NewInt(500),
+			ClientCollateral:     big.NewInt(50),
+		}
+	}
+	updated := market.NewDealState(1, 100)
+	updated.LastUpdatedEpoch = 400
+	slashed := market.NewDealState(1, 100)
+	slashed.SlashEpoch = 300
+	deals := testDeals{
+		terms: map[abi.DealID]*market.DealTerms{
+			1: terms(provider, 200, 2000),  // Active, settled from its start.
+			2: terms(provider, 200, 2000),  // Active, settled up to 400.
+			3: terms(provider, 200, 900),   // Ended.
+			4: terms(provider, 200, 2000),  // Already slashed.
+			5: terms(provider, 1500, 2000), // Not yet started.
+			6: terms(other, 200, 2000),
+			7: terms(provider, 200, 2000), // No state.
+		},
+		states: map[abi.DealID]*market.DealState{
+			1: market.NewDealState(1, 100),
+			2: updated,
+			3: market.NewDealState(1, 100),
+			4: slashed,
+			5: market.NewDealState(1, 100),
+		},
+	}
+
+	params := &market.OnMinerSectorsTerminateParams{Epoch: 1000, DealIDs: []abi.DealID{1, 2, 3, 4, 5, 8}}
+	result, err := market.SlashedDeals(deals, provider, params)
+	require.NoError(t, err)
+	expected := func(id abi.DealID, payment, refund int64) market.SlashedDeal {
+		return market.SlashedDeal{
+			DealID:            id,
+			Client:            client,
+			Provider:          provider,
+			SlashEpoch:        1000,
+			ProviderPayment:   big.NewInt(payment),
+			ClientRefund:      big.NewInt(refund),
+			SlashedCollateral: big.NewInt(500),
+			ClientCollateral:  big.NewInt(50),
+		}
+	}
+	assert.Equal(t, []market.SlashedDeal{
+		expected(1, 8000, 10000),
+		expected(2, 6000, 10000),
+		expected(5, 0, 5000),
+	}, result)
+
+	for _, ids := range [][]abi.DealID{{6}, {7}, {99}} {
+		_, err := market.SlashedDeals(deals, provider, &market.OnMinerSectorsTerminateParams{Epoch: 1000, DealIDs: ids})
+		assert.Error(t, err, ids)
+	}
+}
+
+func TestOnMinerSectorsTerminateParamsRoundTrip(t *testing.T) {
+	params := market.OnMinerSectorsTerminateParams{Epoch: 1000, DealIDs: []abi.DealID{1, 2, 3}}
+	var buf bytes.Buffer
+	require.NoError(t, params.MarshalCBOR(&buf))
+	var out market.OnMinerSectorsTerminateParams
+	require.NoError(t, out.UnmarshalCBOR(&buf))
+	assert.Equal(t, params, out)
+}
+
+func mustID(t *testing.T, id uint64) addr.Address {
+	a, err := addr.NewIDAddress(id)
+	require.NoError(t, err)
+	return a
+}
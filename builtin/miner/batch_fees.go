@@ -0,0 +1,65 @@
+package miner
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+// Network fees burnt when batching pre-commitments or aggregating prove-commitments (the "batch balancer").
+// Batching saves gas, and with it base fee burnt, so a fraction of the gas a single message per sector
+// would have used is charged at (at least) a fixed price instead. This keeps batching economical only
+// when the base fee is high enough that the chain is congested.
+
+// Gas a single ProveCommitSector message is estimated to use.
+const EstimatedSingleProveCommitGasUsage = 49299973
+
+// Gas a single ProveCommitSector message was estimated to use before PreCommitBatchFeeNetworkVersion.
+const EstimatedSingleProveCommitGasUsageV5 = 65733297
+
+// Gas a single PreCommitSector message is estimated to use.
+const EstimatedSinglePreCommitGasUsage = 16433324
+
+// Fraction of the estimated gas of single messages charged by the network fee.
+const (
+	BatchDiscountNum   = 1
+	BatchDiscountDenom = 20
+)
+
+// Minimum gas price at which the network fee is charged: 5 nanoFIL.
+var BatchBalancer = big.Mul(big.NewInt(5), big.OneNanoFil())
+
+// Minimum gas price at which the network fee was charged before PreCommitBatchFeeNetworkVersion: 2 nanoFIL.
+var BatchBalancerV5 = big.Mul(big.NewInt(2), big.OneNanoFil())
+
+// The network version from which pre-commit batches are charged a network fee (FIP-0024). The balancer
+// price and prove-commit gas estimate were raised at the same version.
+const PreCommitBatchFeeNetworkVersion = network.Version14
+
+// AggregateProveCommitNetworkFee returns the network fee burnt for a ProveCommitAggregate of some number of
+// sectors, at some base fee and network version. There is no fee before aggregation is available.
+func AggregateProveCommitNetworkFee(nv network.Version, aggregateSize int, baseFee abi.TokenAmount) abi.TokenAmount {
+	if nv < AggregatePoRepNetworkVersion {
+		return big.Zero()
+	}
+	if nv < PreCommitBatchFeeNetworkVersion {
+		return aggregateNetworkFee(aggregateSize, EstimatedSingleProveCommitGasUsageV5, BatchBalancerV5, baseFee)
+	}
+	return aggregateNetworkFee(aggregateSize, EstimatedSingleProveCommitGasUsage, BatchBalancer, baseFee)
+}
+
+// AggregatePreCommitNetworkFee returns the network fee burnt for a PreCommitSectorBatch of some number of
+// sectors, at some base fee and network version. Pre-commit batches were free before
+// PreCommitBatchFeeNetworkVersion.
+func AggregatePreCommitNetworkFee(nv network.Version, aggregateSize int, baseFee abi.TokenAmount) abi.TokenAmount {
+	if nv < PreCommitBatchFeeNetworkVersion {
+		return big.Zero()
+	}
+	return aggregateNetworkFee(aggregateSize, EstimatedSinglePreCommitGasUsage, BatchBalancer, baseFee)
+}
+
+func aggregateNetworkFee(aggregateSize int, gasUsage int64, balancer, baseFee abi.TokenAmount) abi.TokenAmount {
+	effectiveGasFee := big.Max(baseFee, balancer)
+	networkFeeNum := big.Product(effectiveGasFee, big.NewInt(gasUsage), big.NewInt(int64(aggregateSize)), big.NewInt(BatchDiscountNum))
+	return big.Div(networkFeeNum, big.NewInt(BatchDiscountDenom))
+}
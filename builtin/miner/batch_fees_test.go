@@ -0,0 +1,35 @@
+package miner_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestAggregateNetworkFees(t *testing.T) {
+	lowBaseFee := big.NewInt(100)
+	highBaseFee := big.Mul(big.NewInt(10), big.OneNanoFil())
+
+	// Below the balancer, the fee is charged at the balancer price.
+	expected := big.Div(big.Product(miner.BatchBalancer, big.NewInt(miner.EstimatedSingleProveCommitGasUsage), big.NewInt(10)), big.NewInt(20))
+	assert.Equal(t, expected, miner.AggregateProveCommitNetworkFee(network.Version14, 10, lowBaseFee))
+
+	expected = big.Div(big.Product(highBaseFee, big.NewInt(miner.EstimatedSingleProveCommitGasUsage), big.NewInt(10)), big.NewInt(20))
+	assert.Equal(t, expected, miner.AggregateProveCommitNetworkFee(network.Version14, 10, highBaseFee))
+	assert.Equal(t, big.Zero(), miner.AggregateProveCommitNetworkFee(network.Version12, 10, highBaseFee))
+
+	// Version 13 used a lower balancer price and a higher gas estimate.
+	expected = big.Div(big.Product(big.Mul(big.NewInt(2), big.OneNanoFil()), big.NewInt(65733297), big.NewInt(10)), big.NewInt(20))
+	assert.Equal(t, expected, miner.AggregateProveCommitNetworkFee(network.Version13, 10, lowBaseFee))
+	expected = big.Div(big.Product(highBaseFee, big.NewInt(65733297), big.NewInt(10)), big.NewInt(20))
+	assert.Equal(t, expected, miner.AggregateProveCommitNetworkFee(network.Version13, 10, highBaseFee))
+
+	// Pre-commit batches are charged from version 14.
+	assert.Equal(t, big.Zero(), miner.AggregatePreCommitNetworkFee(network.Version13, 10, highBaseFee))
+	expected = big.Div(big.Product(highBaseFee, big.NewInt(miner.EstimatedSinglePreCommitGasUsage), big.NewInt(10)), big.NewInt(20))
+	assert.Equal(t, expected, miner.AggregatePreCommitNetworkFee(network.Version14, 10, highBaseFee))
+}
@@ -0,0 +1,131 @@
+package miner
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// Number of deadlines in a Window PoSt proving period.
+const WPoStPeriodDeadlines = uint64(48)
+
+// Deadlines is the root of a miner's proving deadlines, holding the CID of the state of each deadline.
+type Deadlines struct {
+	Due [WPoStPeriodDeadlines]cid.Cid
+}
+
+// MarshalCBOR writes the deadlines as an array of CIDs.
+func (d *Deadlines) MarshalCBOR(w io.Writer) error {
+	if err := cbg.CborWriteHeader(w, cbg.MajArray, WPoStPeriodDeadlines); err != nil {
+		return err
+	}
+	for i, c := range d.Due {
+		if err := cbg.WriteCid(w, c); err != nil {
+			return xerrors.Errorf("failed to write deadline %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// UnmarshalCBOR reads the deadlines, which must be an array of WPoStPeriodDeadlines CIDs.
+func (d *Deadlines) UnmarshalCBOR(r io.Reader) error {
+	maj, n, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || n != WPoStPeriodDeadlines {
+		return xerrors.Errorf("expected array of %d deadlines", WPoStPeriodDeadlines)
+	}
+	for i := range d.Due {
+		if d.Due[i], err = cbg.ReadCid(r); err != nil {
+			return xerrors.Errorf("failed to read deadline %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// The state of a deadline and of a partition are large tuples, of which only the leading fields are needed to
+// locate sectors: a deadline begins with the AMT root of its partitions, and a partition with the bitfield of
+// all its sectors. Both layouts are common to every actors version.
+
+// LoadDeadlinePartitions returns the root of the partitions AMT of the deadline with some CID.
+func LoadDeadlinePartitions(ctx context.Context, store ipldcbor.IpldStore, deadline cid.Cid) (cid.Cid, error) {
+	var raw cbg.Deferred
+	if err := store.Get(ctx, deadline, &raw); err != nil {
+		return cid.Undef, xerrors.Errorf("failed to load deadline %s: %w", deadline, err)
+	}
+	br, err := readTupleStart(raw.Raw)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("deadline %s: %w", deadline, err)
+	}
+	return cbg.ReadCid(br)
+}
+
+// DecodePartitionSectors decodes the sectors of an encoded partition.
+func DecodePartitionSectors(partition []byte) (abi.SectorRangeSet, error) {
+	br, err := readTupleStart(partition)
+	if err != nil {
+		return abi.SectorRangeSet{}, xerrors.Errorf("partition: %w", err)
+	}
+	var sectors abi.SectorRangeSet
+	if err := sectors.UnmarshalCBOR(br); err != nil {
+		return abi.SectorRangeSet{}, xerrors.Errorf("failed to decode partition sectors: %w", err)
+	}
+	return sectors, nil
+}
+
+// LoadStateDeadlines loads the deadlines of the miner actor state with some CID. The deadlines are the
+// twelfth field of the state in actors v0, and the thirteenth from actors v2 onwards.
+func LoadStateDeadlines(ctx context.Context, store ipldcbor.IpldStore, state cid.Cid) (*Deadlines, error) {
+	var raw cbg.Deferred
+	if err := store.Get(ctx, state, &raw); err != nil {
+		return nil, xerrors.Errorf("failed to load miner state %s: %w", state, err)
+	}
+	br := bytes.NewReader(raw.Raw)
+	maj, n, err := cbg.CborReadHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if maj != cbg.MajArray || n < 13 {
+		return nil, xerrors.Errorf("miner state %s: expected tuple of at least 13 fields", state)
+	}
+	index := 12
+	if n == 13 {
+		index = 11
+	}
+	for i := 0; i < index; i++ {
+		var skip cbg.Deferred
+		if err := skip.UnmarshalCBOR(br); err != nil {
+			return nil, xerrors.Errorf("miner state %s: %w", state, err)
+		}
+	}
+	deadlinesCid, err := cbg.ReadCid(br)
+	if err != nil {
+		return nil, xerrors.Errorf("miner state %s: failed to read deadlines: %w", state, err)
+	}
+	var deadlines Deadlines
+	if err := store.Get(ctx, deadlinesCid, &deadlines); err != nil {
+		return nil, xerrors.Errorf("failed to load deadlines %s: %w", deadlinesCid, err)
+	}
+	return &deadlines, nil
+}
+
+// Returns a reader positioned at the first field of an encoded tuple.
+func readTupleStart(b []byte) (*bytes.Reader, error) {
+	br := bytes.NewReader(b)
+	maj, n, err := cbg.CborReadHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if maj != cbg.MajArray || n == 0 {
+		return nil, xerrors.Errorf("expected non-empty tuple")
+	}
+	return br, nil
+}
@@ -0,0 +1,119 @@
+package miner
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/adt"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/dline"
+	"github.com/filecoin-project/go-state-types/proof"
+)
+
+// From actors v3, Window PoSts are accepted optimistically, without verification, and may be disputed by
+// anyone for a while after the deadline closes. A successful dispute penalizes the miner and rewards the
+// disputer. A proof remains disputable until WPoStDisputeWindow epochs after the close of its deadline.
+
+// The period over which a miner's active sectors are expected to be proven via Window PoSt.
+const WPoStProvingPeriod = abi.ChainEpoch(builtin.EpochsInDay)
+
+// The period between the opening and the closing of a Window PoSt deadline.
+const WPoStChallengeWindow = abi.ChainEpoch(30 * 60 / builtin.EpochDurationSeconds)
+
+// Lookback from the deadline's challenge window opening from which to sample chain randomness for the challenge seed.
+const WPoStChallengeLookback = abi.ChainEpoch(20)
+
+// Minimum period before a deadline's challenge window opens that a fault must be declared for that deadline.
+const FaultDeclarationCutoff = WPoStChallengeLookback + 50
+
+// The period after the close of a deadline during which its optimistically accepted proofs may be disputed.
+const WPoStDisputeWindow = 2 * abi.ChainFinality
+
+// The index of the snapshot of optimistically accepted proofs in a deadline's state (from actors v3).
+const deadlineOptimisticPoStSnapshotField = 10
+
+// Parameters to DisputeWindowedPoSt, disputing a proof in the snapshot of a deadline's proofs.
+type DisputeWindowedPoStParams struct {
+	Deadline  uint64
+	PoStIndex uint64 // only one is allowed at a time to avoid loading too many sector infos.
+}
+
+// WindowedPoSt is a Window PoSt accepted optimistically, for some partitions of a deadline.
+type WindowedPoSt struct {
+	// Indices of the partitions proven.
+	Partitions abi.SectorRangeSet
+	// The proofs, one per partition group, each of the miner's Window PoSt proof type.
+	Proofs []proof.PoStProof
+}
+
+// NewDeadlineInfo returns the deadline info for a deadline of a proving period, at the current epoch.
+func NewDeadlineInfo(periodStart abi.ChainEpoch, deadlineIdx uint64, currEpoch abi.ChainEpoch) *dline.Info {
+	return dline.NewInfo(periodStart, deadlineIdx, currEpoch, WPoStPeriodDeadlines, WPoStProvingPeriod, WPoStChallengeWindow, WPoStChallengeLookback, FaultDeclarationCutoff)
+}
+
+// DisputeWindowEnd returns the first epoch at which proofs submitted to a deadline can no longer be disputed.
+func DisputeWindowEnd(dl *dline.Info) abi.ChainEpoch {
+	return dl.Close + WPoStDisputeWindow
+}
+
+// DeadlineAvailableForOptimisticPoStDispute returns whether the proofs submitted to the most recently closed
+// instance of a deadline may be disputed at the current epoch. Proofs cannot be disputed while the deadline
+// is open, since the snapshot of its proofs is taken when it closes.
+func DeadlineAvailableForOptimisticPoStDispute(provingPeriodStart abi.ChainEpoch, deadlineIdx uint64, currEpoch abi.ChainEpoch) bool {
+	if provingPeriodStart > currEpoch {
+		return false
+	}
+	dl := NewDeadlineInfo(provingPeriodStart, deadlineIdx, currEpoch).NextNotElapsed()
+	return !dl.IsOpen() && currEpoch < DisputeWindowEnd(dl)-WPoStProvingPeriod
+}
+
+// LoadOptimisticPoStSnapshot returns the proofs in the snapshot of optimistically accepted proofs of the
+// deadline with some CID (from actors v3), indexed as by DisputeWindowedPoStParams.PoStIndex.
+func LoadOptimisticPoStSnapshot(ctx context.Context, store ipldcbor.IpldStore, deadline cid.Cid) ([]WindowedPoSt, error) {
+	var raw cbg.Deferred
+	if err := store.Get(ctx, deadline, &raw); err != nil {
+		return nil, xerrors.Errorf("failed to load deadline %s: %w", deadline, err)
+	}
+	br := bytes.NewReader(raw.Raw)
+	maj, n, err := cbg.CborReadHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if maj != cbg.MajArray || n <= deadlineOptimisticPoStSnapshotField {
+		return nil, xerrors.Errorf("deadline %s has no optimistic PoSt snapshot", deadline)
+	}
+	for i := 0; i < deadlineOptimisticPoStSnapshotField; i++ {
+		var skip cbg.Deferred
+		if err := skip.UnmarshalCBOR(br); err != nil {
+			return nil, xerrors.Errorf("deadline %s: %w", deadline, err)
+		}
+	}
+	root, err := cbg.ReadCid(br)
+	if err != nil {
+		return nil, xerrors.Errorf("deadline %s: failed to read optimistic PoSt snapshot: %w", deadline, err)
+	}
+
+	var posts []WindowedPoSt
+	err = adt.ForEachAmtEntry(ctx, store, root, func(i uint64, v []byte) error {
+		if i != uint64(len(posts)) {
+			return xerrors.Errorf("optimistic PoSt snapshot has gap before index %d", i)
+		}
+		var post WindowedPoSt
+		if _, err := cbor.Unmarshal(v, &post); err != nil {
+			return xerrors.Errorf("failed to decode optimistic PoSt %d: %w", i, err)
+		}
+		posts = append(posts, post)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
@@ -0,0 +1,68 @@
+package miner_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/proof"
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+func TestDisputeWindow(t *testing.T) {
+	dl := miner.NewDeadlineInfo(1000, 2, 1000)
+	assert.Equal(t, abi.ChainEpoch(1180), dl.Close)
+	assert.Equal(t, abi.ChainEpoch(2980), miner.DisputeWindowEnd(dl))
+
+	for _, tc := range []struct {
+		epoch    abi.ChainEpoch
+		expected bool
+	}{
+		{999, false},  // before the proving period
+		{1130, false}, // the deadline is open
+		{1180, true},  // the deadline has just closed
+		{2979, true},
+		{2980, false},
+		{1000 + miner.WPoStProvingPeriod + 130, false}, // open again in the next period
+		{1000 + miner.WPoStProvingPeriod + 180, true},
+	} {
+		assert.Equal(t, tc.expected, miner.DeadlineAvailableForOptimisticPoStDispute(1000, 2, tc.epoch), "epoch %d", tc.epoch)
+	}
+}
+
+func TestLoadOptimisticPoStSnapshot(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+	posts := []miner.WindowedPoSt{
+		{Partitions: abi.SectorRangeSetFromNumbers(0, 1), Proofs: []proof.PoStProof{{PoStProof: abi.RegisteredPoStProof_StackedDrgWindow32GiBV1, ProofBytes: []byte{1, 2}}}},
+		{Partitions: abi.SectorRangeSetFromNumbers(2), Proofs: []proof.PoStProof{{PoStProof: abi.RegisteredPoStProof_StackedDrgWindow32GiBV1, ProofBytes: []byte{3}}}},
+	}
+	var values [][]byte
+	for _, p := range posts {
+		var buf bytes.Buffer
+		require.NoError(t, p.MarshalCBOR(&buf))
+		values = append(values, buf.Bytes())
+	}
+	snapshot := ipld.PutBlock(t, store, ipld.AmtRoot(3, 0, 2, ipld.AmtNode([]byte{0b11}, nil, values...)))
+
+	// A deadline with the snapshot as its eleventh field.
+	var buf bytes.Buffer
+	require.NoError(t, cbg.CborWriteHeader(&buf, cbg.MajArray, 11))
+	for i := 0; i < 10; i++ {
+		buf.WriteByte(0x00)
+	}
+	require.NoError(t, cbg.WriteCid(&buf, snapshot))
+	loaded, err := miner.LoadOptimisticPoStSnapshot(ctx, store, ipld.PutBlock(t, store, buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, posts, loaded)
+
+	// A deadline from before actors v3.
+	_, err = miner.LoadOptimisticPoStSnapshot(ctx, store, ipld.PutBlock(t, store, deadline(snapshot)))
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package miner
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/verifreg"
+)
+
+// Maximum number of sectors addressed by one message extending sector expirations.
+const AddressedSectorsMax = 25_000
+
+// SectorClaim lists the verified claims of a sector whose expiration is extended, partitioned into those
+// maintained and those dropped by the extension.
+// A claim may be dropped only once its term has ended; a sector with verified claims must account for all of
+// them, and its new expiration must remain within the terms of the maintained claims.
+type SectorClaim struct {
+	SectorNumber   abi.SectorNumber
+	MaintainClaims []verifreg.ClaimId
+	DropClaims     []verifreg.ClaimId
+}
+
+// ExpirationExtension2 extends the expiration of some of a partition's sectors.
+type ExpirationExtension2 struct {
+	// The deadline to which the sectors are assigned, in range [0..WPoStPeriodDeadlines).
+	Deadline uint64
+	// Partition index within the deadline containing the sectors.
+	Partition uint64
+	// Sectors without verified claims.
+	Sectors abi.SectorRangeSet
+	// Sectors with verified claims.
+	SectorsWithClaims []SectorClaim
+	// New expiration epoch of all the sectors.
+	NewExpiration abi.ChainEpoch
+}
+
+// Parameters to ExtendSectorExpiration2.
+type ExtendSectorExpiration2Params struct {
+	Extensions []ExpirationExtension2
+}
+
+// SectorCount returns the number of sectors addressed by the extension.
+func (e *ExpirationExtension2) SectorCount() uint64 {
+	return e.Sectors.Count() + uint64(len(e.SectorsWithClaims))
+}
+
+// Validate checks the consistency of an extension: each sector appears once, and no claim is both maintained
+// and dropped.
+func (e *ExpirationExtension2) Validate() error {
+	sectors := map[abi.SectorNumber]struct{}{}
+	claims := map[verifreg.ClaimId]struct{}{}
+	for _, sc := range e.SectorsWithClaims {
+		if e.Sectors.Has(sc.SectorNumber) {
+			return xerrors.Errorf("sector %d listed both with and without claims", sc.SectorNumber)
+		}
+		if _, ok := sectors[sc.SectorNumber]; ok {
+			return xerrors.Errorf("sector %d listed more than once", sc.SectorNumber)
+		}
+		sectors[sc.SectorNumber] = struct{}{}
+		for _, ids := range [][]verifreg.ClaimId{sc.MaintainClaims, sc.DropClaims} {
+			for _, id := range ids {
+				if _, ok := claims[id]; ok {
+					return xerrors.Errorf("claim %d of sector %d listed more than once", id, sc.SectorNumber)
+				}
+				claims[id] = struct{}{}
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks each extension and the limits on the number of declarations and sectors in one message.
+func (p *ExtendSectorExpiration2Params) Validate() error {
+	if len(p.Extensions) > DeclarationsMax {
+		return xerrors.Errorf("too many declarations: %d > %d", len(p.Extensions), DeclarationsMax)
+	}
+	var sectorCount uint64
+	for i := range p.Extensions {
+		if err := p.Extensions[i].Validate(); err != nil {
+			return xerrors.Errorf("invalid extension %d: %w", i, err)
+		}
+		sectorCount += p.Extensions[i].SectorCount()
+	}
+	if sectorCount > AddressedSectorsMax {
+		return xerrors.Errorf("too many sectors: %d > %d", sectorCount, AddressedSectorsMax)
+	}
+	return nil
+}
+
+// SplitExtendSectorExpiration2 packs extensions, in order, into as few messages as the limits on the number of
+// declarations and sectors per message allow. An extension too large for the remainder of a message is split
+// across messages.
+func SplitExtendSectorExpiration2(extensions []ExpirationExtension2) ([]*ExtendSectorExpiration2Params, error) {
+	var out []*ExtendSectorExpiration2Params
+	cur := &ExtendSectorExpiration2Params{}
+	var curSectors uint64
+	flush := func() {
+		if len(cur.Extensions) > 0 {
+			out = append(out, cur)
+		}
+		cur = &ExtendSectorExpiration2Params{}
+		curSectors = 0
+	}
+
+	for i := range extensions {
+		if err := extensions[i].Validate(); err != nil {
+			return nil, xerrors.Errorf("invalid extension %d: %w", i, err)
+		}
+		rest := extensions[i]
+		for rest.SectorCount() > 0 {
+			if len(cur.Extensions) == DeclarationsMax || curSectors == AddressedSectorsMax {
+				flush()
+			}
+			var next ExpirationExtension2
+			next, rest = splitExtension(rest, AddressedSectorsMax-curSectors)
+			cur.Extensions = append(cur.Extensions, next)
+			curSectors += next.SectorCount()
+		}
+	}
+	flush()
+	return out, nil
+}
+
+// Splits an extension into one of at most n sectors (taking sectors with claims first), and the remainder.
+func splitExtension(e ExpirationExtension2, n uint64) (ExpirationExtension2, ExpirationExtension2) {
+	head, tail := e, e
+	withClaims := uint64(len(e.SectorsWithClaims))
+	if withClaims >= n {
+		head.SectorsWithClaims, tail.SectorsWithClaims = e.SectorsWithClaims[:n], e.SectorsWithClaims[n:]
+		head.Sectors, tail.Sectors = abi.SectorRangeSet{}, e.Sectors
+		return head, tail
+	}
+	tail.SectorsWithClaims = nil
+	head.Sectors, tail.Sectors = takeSectors(e.Sectors, n-withClaims)
+	return head, tail
+}
+
+// Splits a set into its lowest n sector numbers and the rest.
+func takeSectors(s abi.SectorRangeSet, n uint64) (abi.SectorRangeSet, abi.SectorRangeSet) {
+	var taken []abi.SectorRange
+	for _, r := range s.Ranges() {
+		if n == 0 {
+			break
+		}
+		if r.Len() > n {
+			r.End = r.Start + abi.SectorNumber(n)
+		}
+		taken = append(taken, r)
+		n -= r.Len()
+	}
+	head := abi.NewSectorRangeSet(taken...)
+	return head, s.Difference(head)
+}
@@ -0,0 +1,75 @@
+package miner_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/builtin/verifreg"
+)
+
+func TestExpirationExtension2Validate(t *testing.T) {
+	ext := miner.ExpirationExtension2{
+		Sectors: abi.SectorRangeSetFromNumbers(1, 2),
+		SectorsWithClaims: []miner.SectorClaim{
+			{SectorNumber: 3, MaintainClaims: []verifreg.ClaimId{10}, DropClaims: []verifreg.ClaimId{11}},
+		},
+		NewExpiration: 1000,
+	}
+	require.NoError(t, ext.Validate())
+	assert.Equal(t, uint64(3), ext.SectorCount())
+
+	var buf bytes.Buffer
+	require.NoError(t, ext.MarshalCBOR(&buf))
+	var decoded miner.ExpirationExtension2
+	require.NoError(t, decoded.UnmarshalCBOR(&buf))
+	assert.Equal(t, ext, decoded)
+
+	dropMaintained := ext
+	dropMaintained.SectorsWithClaims = []miner.SectorClaim{
+		{SectorNumber: 3, MaintainClaims: []verifreg.ClaimId{10}, DropClaims: []verifreg.ClaimId{10}},
+	}
+	assert.Error(t, dropMaintained.Validate())
+
+	both := ext
+	both.SectorsWithClaims = []miner.SectorClaim{{SectorNumber: 2}}
+	assert.Error(t, both.Validate())
+
+	repeated := ext
+	repeated.SectorsWithClaims = []miner.SectorClaim{{SectorNumber: 3}, {SectorNumber: 3}}
+	assert.Error(t, repeated.Validate())
+}
+
+func TestSplitExtendSectorExpiration2(t *testing.T) {
+	claims := make([]miner.SectorClaim, 10)
+	for i := range claims {
+		claims[i] = miner.SectorClaim{SectorNumber: abi.SectorNumber(100_000 + i), MaintainClaims: []verifreg.ClaimId{verifreg.ClaimId(i)}}
+	}
+	extensions := []miner.ExpirationExtension2{
+		{Deadline: 0, Partition: 0, Sectors: abi.NewSectorRangeSet(abi.SectorRange{Start: 0, End: 20_000}), NewExpiration: 1000},
+		{Deadline: 1, Partition: 0, Sectors: abi.NewSectorRangeSet(abi.SectorRange{Start: 20_000, End: 30_000}), SectorsWithClaims: claims, NewExpiration: 1000},
+	}
+
+	params, err := miner.SplitExtendSectorExpiration2(extensions)
+	require.NoError(t, err)
+	require.Len(t, params, 2)
+	for _, p := range params {
+		require.NoError(t, p.Validate())
+	}
+
+	// The first message is filled with the first extension, the claimed sectors and the lowest sectors of the
+	// second extension.
+	require.Len(t, params[0].Extensions, 2)
+	assert.Equal(t, uint64(20_000), params[0].Extensions[0].SectorCount())
+	assert.Equal(t, claims, params[0].Extensions[1].SectorsWithClaims)
+	assert.Equal(t, abi.NewSectorRangeSet(abi.SectorRange{Start: 20_000, End: 24_990}), params[0].Extensions[1].Sectors)
+
+	require.Len(t, params[1].Extensions, 1)
+	assert.Equal(t, uint64(1), params[1].Extensions[0].Deadline)
+	assert.Empty(t, params[1].Extensions[0].SectorsWithClaims)
+	assert.Equal(t, abi.NewSectorRangeSet(abi.SectorRange{Start: 24_990, End: 30_000}), params[1].Extensions[0].Sectors)
+}
@@ -0,0 +1,112 @@
+package miner
+
+import (
+	"sort"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// Maximum number of fault or recovery declarations in one DeclareFaults or DeclareFaultsRecovered message.
+const DeclarationsMax = 3000
+
+// FaultDeclaration declares some of a partition's sectors faulty.
+type FaultDeclaration struct {
+	// The deadline to which the faulty sectors are assigned, in range [0..WPoStPeriodDeadlines).
+	Deadline uint64
+	// Partition index within the deadline containing the faulty sectors.
+	Partition uint64
+	// Sectors in the partition being declared faulty.
+	Sectors abi.SectorRangeSet
+}
+
+// Parameters to DeclareFaults.
+type DeclareFaultsParams struct {
+	Faults []FaultDeclaration
+}
+
+// RecoveryDeclaration declares some of a partition's faulty sectors recovered.
+type RecoveryDeclaration struct {
+	// The deadline to which the recovered sectors are assigned, in range [0..WPoStPeriodDeadlines).
+	Deadline uint64
+	// Partition index within the deadline containing the recovered sectors.
+	Partition uint64
+	// Sectors in the partition being declared recovered.
+	Sectors abi.SectorRangeSet
+}
+
+// Parameters to DeclareFaultsRecovered.
+type DeclareFaultsRecoveredParams struct {
+	Recoveries []RecoveryDeclaration
+}
+
+// PartitionSectors identifies the sectors assigned to a partition of a miner's deadline.
+type PartitionSectors struct {
+	Deadline  uint64
+	Partition uint64
+	Sectors   abi.SectorRangeSet
+}
+
+// NewDeclareFaultsParams returns the parameters to declare a set of sectors faulty, given the sectors of the
+// miner's partitions (which must include every sector to be declared).
+func NewDeclareFaultsParams(sectors abi.SectorRangeSet, partitions []PartitionSectors) (*DeclareFaultsParams, error) {
+	decls, err := partitionDeclarations(sectors, partitions)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to declare faults: %w", err)
+	}
+	params := &DeclareFaultsParams{Faults: make([]FaultDeclaration, len(decls))}
+	for i, d := range decls {
+		params.Faults[i] = FaultDeclaration(d)
+	}
+	return params, nil
+}
+
+// NewDeclareFaultsRecoveredParams returns the parameters to declare a set of sectors recovered, given the sectors
+// of the miner's partitions (which must include every sector to be declared).
+func NewDeclareFaultsRecoveredParams(sectors abi.SectorRangeSet, partitions []PartitionSectors) (*DeclareFaultsRecoveredParams, error) {
+	decls, err := partitionDeclarations(sectors, partitions)
+	if err != nil {
+		return nil, xerrors.Errorf("failed to declare recoveries: %w", err)
+	}
+	params := &DeclareFaultsRecoveredParams{Recoveries: make([]RecoveryDeclaration, len(decls))}
+	for i, d := range decls {
+		params.Recoveries[i] = RecoveryDeclaration(d)
+	}
+	return params, nil
+}
+
+// Splits sectors into one declaration per partition holding any of them, ordered by deadline and partition.
+// Partitions listed more than once are merged, since the actor rejects repeated declarations for a partition.
+func partitionDeclarations(sectors abi.SectorRangeSet, partitions []PartitionSectors) ([]PartitionSectors, error) {
+	type key struct{ deadline, partition uint64 }
+	merged := map[key]abi.SectorRangeSet{}
+	remaining := sectors
+	for _, p := range partitions {
+		declared := sectors.Intersect(p.Sectors)
+		if declared.IsEmpty() {
+			continue
+		}
+		k := key{p.Deadline, p.Partition}
+		merged[k] = merged[k].Union(declared)
+		remaining = remaining.Difference(declared)
+	}
+	if !remaining.IsEmpty() {
+		return nil, xerrors.Errorf("%d sectors not found in any partition", remaining.Count())
+	}
+	if len(merged) > DeclarationsMax {
+		return nil, xerrors.Errorf("too many declarations: %d > %d", len(merged), DeclarationsMax)
+	}
+
+	decls := make([]PartitionSectors, 0, len(merged))
+	for k, s := range merged {
+		decls = append(decls, PartitionSectors{Deadline: k.deadline, Partition: k.partition, Sectors: s})
+	}
+	sort.Slice(decls, func(i, j int) bool {
+		if decls[i].Deadline != decls[j].Deadline {
+			return decls[i].Deadline < decls[j].Deadline
+		}
+		return decls[i].Partition < decls[j].Partition
+	})
+	return decls, nil
+}
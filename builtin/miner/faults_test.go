@@ -0,0 +1,44 @@
+package miner_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+)
+
+func TestNewDeclareFaultsParams(t *testing.T) {
+	partitions := []miner.PartitionSectors{
+		{Deadline: 3, Partition: 0, Sectors: abi.NewSectorRangeSet(abi.SectorRange{Start: 0, End: 10})},
+		{Deadline: 1, Partition: 1, Sectors: abi.NewSectorRangeSet(abi.SectorRange{Start: 10, End: 20})},
+		{Deadline: 1, Partition: 0, Sectors: abi.NewSectorRangeSet(abi.SectorRange{Start: 20, End: 30})},
+		{Deadline: 1, Partition: 1, Sectors: abi.NewSectorRangeSet(abi.SectorRange{Start: 30, End: 40})},
+	}
+	faulty := abi.SectorRangeSetFromNumbers(5, 15, 35, 36)
+
+	params, err := miner.NewDeclareFaultsParams(faulty, partitions)
+	require.NoError(t, err)
+	assert.Equal(t, []miner.FaultDeclaration{
+		{Deadline: 1, Partition: 1, Sectors: abi.SectorRangeSetFromNumbers(15, 35, 36)},
+		{Deadline: 3, Partition: 0, Sectors: abi.SectorRangeSetFromNumbers(5)},
+	}, params.Faults)
+
+	var buf bytes.Buffer
+	require.NoError(t, params.MarshalCBOR(&buf))
+	var decoded miner.DeclareFaultsParams
+	require.NoError(t, decoded.UnmarshalCBOR(&buf))
+	assert.Equal(t, params, &decoded)
+
+	recovered, err := miner.NewDeclareFaultsRecoveredParams(abi.SectorRangeSetFromNumbers(25), partitions)
+	require.NoError(t, err)
+	assert.Equal(t, []miner.RecoveryDeclaration{
+		{Deadline: 1, Partition: 0, Sectors: abi.SectorRangeSetFromNumbers(25)},
+	}, recovered.Recoveries)
+
+	_, err = miner.NewDeclareFaultsParams(abi.SectorRangeSetFromNumbers(5, 50), partitions)
+	assert.Error(t, err)
+}
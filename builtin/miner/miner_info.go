@@ -0,0 +1,282 @@
+package miner
+
+import (
+	"bytes"
+	"io"
+
+	addr "github.com/filecoin-project/go-address"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// The layout of the miner info has changed at some actors versions. MinerInfo is the current layout,
+// and the earlier layouts are retained (suffixed by the first actors version using them) so that state
+// from any version may be decoded and up-converted.
+
+// MinerInfo is the static (i.e. rarely changing) information about a miner, from actors version 9.
+type MinerInfo struct {
+	// Account that owns this miner.
+	// - This address is allowed to change the worker and beneficiary addresses for the miner.
+	Owner addr.Address // Must be an ID-address.
+
+	// Worker account for this miner.
+	// The associated pubkey-type address is used to sign blocks and messages on behalf of this miner.
+	Worker addr.Address // Must be an ID-address.
+
+	// Account to which income and returned collateral are paid, within the limits of the beneficiary term.
+	Beneficiary addr.Address // Must be an ID-address.
+
+	// The quota and expiration of the beneficiary's claim on withdrawals.
+	BeneficiaryTerm BeneficiaryTerm
+
+	// A proposed change of beneficiary, which must be approved by the parties to it.
+	PendingBeneficiaryTerm *PendingBeneficiaryChange
+
+	// Additional addresses that are permitted to submit messages controlling this actor (optional).
+	ControlAddresses []addr.Address // Must all be ID addresses.
+
+	PendingWorkerKey *WorkerKeyChange
+
+	// Byte array representing a Libp2p identity that should be used when connecting to this miner.
+	PeerId abi.PeerID
+
+	// Slice of byte arrays representing Libp2p multi-addresses used for establishing a connection with this miner.
+	Multiaddrs []abi.Multiaddrs
+
+	// The proof type used for Window PoSt for this miner.
+	// A miner may commit sectors with different seal proof types (but compatible sector size and
+	// corresponding PoSt proof types).
+	WindowPoStProofType abi.RegisteredPoStProof
+
+	// Amount of space in each sector committed by this miner.
+	// This is computed from the proof type and represented here redundantly.
+	SectorSize abi.SectorSize
+
+	// The number of sectors in each Window PoSt partition (proof).
+	// This is computed from the proof type and represented here redundantly.
+	WindowPoStPartitionSectors uint64
+
+	// The next epoch this miner is eligible for certain permissioned actor methods
+	// and winning block elections as a result of being reported for a consensus fault.
+	ConsensusFaultElapsed abi.ChainEpoch
+
+	// A proposed new owner account for this miner.
+	// Must be confirmed by a message from the pending address itself.
+	PendingOwnerAddress *addr.Address
+}
+
+// MinerInfo3 is the layout of the miner info from actors version 3 to 8, which lacks the beneficiary fields.
+type MinerInfo3 struct {
+	Owner                      addr.Address
+	Worker                     addr.Address
+	ControlAddresses           []addr.Address
+	PendingWorkerKey           *WorkerKeyChange
+	PeerId                     abi.PeerID
+	Multiaddrs                 []abi.Multiaddrs
+	WindowPoStProofType        abi.RegisteredPoStProof
+	SectorSize                 abi.SectorSize
+	WindowPoStPartitionSectors uint64
+	ConsensusFaultElapsed      abi.ChainEpoch
+	PendingOwnerAddress        *addr.Address
+}
+
+// MinerInfo2 is the layout of the miner info at actors version 2, which records the seal proof type
+// rather than the Window PoSt proof type.
+type MinerInfo2 struct {
+	Owner                      addr.Address
+	Worker                     addr.Address
+	ControlAddresses           []addr.Address
+	PendingWorkerKey           *WorkerKeyChange
+	PeerId                     abi.PeerID
+	Multiaddrs                 []abi.Multiaddrs
+	SealProofType              abi.RegisteredSealProof
+	SectorSize                 abi.SectorSize
+	WindowPoStPartitionSectors uint64
+	ConsensusFaultElapsed      abi.ChainEpoch
+	PendingOwnerAddress        *addr.Address
+}
+
+// MinerInfo0 is the layout of the miner info at actors version 0, which lacks the consensus fault and
+// pending owner fields.
+type MinerInfo0 struct {
+	Owner                      addr.Address
+	Worker                     addr.Address
+	ControlAddresses           []addr.Address
+	PendingWorkerKey           *WorkerKeyChange
+	PeerId                     abi.PeerID
+	Multiaddrs                 []abi.Multiaddrs
+	SealProofType              abi.RegisteredSealProof
+	SectorSize                 abi.SectorSize
+	WindowPoStPartitionSectors uint64
+}
+
+// A pending change to a miner's worker key, which takes effect at some epoch.
+type WorkerKeyChange struct {
+	NewWorker   addr.Address // Must be an ID address
+	EffectiveAt abi.ChainEpoch
+}
+
+// The terms on which a miner's beneficiary may withdraw funds.
+type BeneficiaryTerm struct {
+	// The total amount the beneficiary may withdraw.
+	Quota abi.TokenAmount
+	// The amount the beneficiary has withdrawn so far.
+	UsedQuota abi.TokenAmount
+	// The epoch at which the term expires, after which withdrawals are paid to the owner.
+	Expiration abi.ChainEpoch
+}
+
+// A proposed change of a miner's beneficiary, which takes effect once approved by both the current
+// beneficiary (if not the owner) and the nominee.
+type PendingBeneficiaryChange struct {
+	NewBeneficiary        addr.Address
+	NewQuota              abi.TokenAmount
+	NewExpiration         abi.ChainEpoch
+	ApprovedByBeneficiary bool
+	ApprovedByNominee     bool
+}
+
+// ToMinerInfo2 converts a version 0 miner info to the version 2 layout, as by the version 2 migration.
+func (m *MinerInfo0) ToMinerInfo2() *MinerInfo2 {
+	return &MinerInfo2{
+		Owner:                      m.Owner,
+		Worker:                     m.Worker,
+		ControlAddresses:           m.ControlAddresses,
+		PendingWorkerKey:           m.PendingWorkerKey,
+		PeerId:                     m.PeerId,
+		Multiaddrs:                 m.Multiaddrs,
+		SealProofType:              m.SealProofType,
+		SectorSize:                 m.SectorSize,
+		WindowPoStPartitionSectors: m.WindowPoStPartitionSectors,
+		ConsensusFaultElapsed:      abi.ChainEpoch(-1),
+		PendingOwnerAddress:        nil,
+	}
+}
+
+// ToMinerInfo3 converts a version 2 miner info to the version 3 layout, as by the version 3 migration.
+func (m *MinerInfo2) ToMinerInfo3() (*MinerInfo3, error) {
+	wpp, err := m.SealProofType.RegisteredWindowPoStProof()
+	if err != nil {
+		return nil, xerrors.Errorf("failed to convert seal proof type %v: %w", m.SealProofType, err)
+	}
+	return &MinerInfo3{
+		Owner:                      m.Owner,
+		Worker:                     m.Worker,
+		ControlAddresses:           m.ControlAddresses,
+		PendingWorkerKey:           m.PendingWorkerKey,
+		PeerId:                     m.PeerId,
+		Multiaddrs:                 m.Multiaddrs,
+		WindowPoStProofType:        wpp,
+		SectorSize:                 m.SectorSize,
+		WindowPoStPartitionSectors: m.WindowPoStPartitionSectors,
+		ConsensusFaultElapsed:      m.ConsensusFaultElapsed,
+		PendingOwnerAddress:        m.PendingOwnerAddress,
+	}, nil
+}
+
+// ToMinerInfo converts a version 3 miner info to the current layout, as by the version 9 migration.
+// The owner becomes the beneficiary, with an empty term.
+func (m *MinerInfo3) ToMinerInfo() *MinerInfo {
+	return &MinerInfo{
+		Owner:       m.Owner,
+		Worker:      m.Worker,
+		Beneficiary: m.Owner,
+		BeneficiaryTerm: BeneficiaryTerm{
+			Quota:      big.Zero(),
+			UsedQuota:  big.Zero(),
+			Expiration: 0,
+		},
+		PendingBeneficiaryTerm:     nil,
+		ControlAddresses:           m.ControlAddresses,
+		PendingWorkerKey:           m.PendingWorkerKey,
+		PeerId:                     m.PeerId,
+		Multiaddrs:                 m.Multiaddrs,
+		WindowPoStProofType:        m.WindowPoStProofType,
+		SectorSize:                 m.SectorSize,
+		WindowPoStPartitionSectors: m.WindowPoStPartitionSectors,
+		ConsensusFaultElapsed:      m.ConsensusFaultElapsed,
+		PendingOwnerAddress:        m.PendingOwnerAddress,
+	}
+}
+
+// DecodeMinerInfo decodes a miner info in the layout of some actors version and converts it to the current layout.
+// The peer ID is checked against its length bound, which the generated decoders do not apply.
+func DecodeMinerInfo(av actors.Version, r io.Reader) (*MinerInfo, error) {
+	info, err := decodeMinerInfo(av, r)
+	if err != nil {
+		return nil, err
+	}
+	if err := info.PeerId.Validate(); err != nil {
+		return nil, xerrors.Errorf("invalid miner info: %w", err)
+	}
+	return info, nil
+}
+
+func decodeMinerInfo(av actors.Version, r io.Reader) (*MinerInfo, error) {
+	switch {
+	case av == actors.Version0:
+		var info0 MinerInfo0
+		if err := info0.UnmarshalCBOR(r); err != nil {
+			return nil, err
+		}
+		info3, err := info0.ToMinerInfo2().ToMinerInfo3()
+		if err != nil {
+			return nil, err
+		}
+		return info3.ToMinerInfo(), nil
+	case av == actors.Version2:
+		var info2 MinerInfo2
+		if err := info2.UnmarshalCBOR(r); err != nil {
+			return nil, err
+		}
+		info3, err := info2.ToMinerInfo3()
+		if err != nil {
+			return nil, err
+		}
+		return info3.ToMinerInfo(), nil
+	case av >= actors.Version3 && av <= actors.Version8:
+		var info3 MinerInfo3
+		if err := info3.UnmarshalCBOR(r); err != nil {
+			return nil, err
+		}
+		return info3.ToMinerInfo(), nil
+	case av >= actors.Version9 && av <= actors.Version14:
+		var info MinerInfo
+		if err := info.UnmarshalCBOR(r); err != nil {
+			return nil, err
+		}
+		return &info, nil
+	default:
+		return nil, xerrors.Errorf("unsupported miner info version %d", av)
+	}
+}
+
+// DecodeMinerInfoBytes decodes a serialized miner info as by DecodeMinerInfo.
+func DecodeMinerInfoBytes(av actors.Version, b []byte) (*MinerInfo, error) {
+	return DecodeMinerInfo(av, bytes.NewReader(b))
+}
+
+// IsController returns whether an address is the owner, worker, or one of the control addresses of the miner.
+// The address must be an ID address to match.
+func (m *MinerInfo) IsController(a addr.Address) bool {
+	if a == m.Owner || a == m.Worker {
+		return true
+	}
+	for _, c := range m.ControlAddresses {
+		if a == c {
+			return true
+		}
+	}
+	return false
+}
+
+// AllControlAddresses returns the worker address followed by the control addresses, being the addresses
+// from which the miner's operational messages may be sent.
+func (m *MinerInfo) AllControlAddresses() []addr.Address {
+	out := make([]addr.Address, 0, 1+len(m.ControlAddresses))
+	out = append(out, m.Worker)
+	return append(out, m.ControlAddresses...)
+}
@@ -0,0 +1,110 @@
+package miner_test
+
+import (
+	"bytes"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+)
+
+func TestDecodeMinerInfo(t *testing.T) {
+	owner := mustIDAddr(t, 100)
+	worker := mustIDAddr(t, 101)
+	control := mustIDAddr(t, 102)
+
+	info0 := miner.MinerInfo0{
+		Owner:                      owner,
+		Worker:                     worker,
+		ControlAddresses:           []addr.Address{control},
+		PendingWorkerKey:           &miner.WorkerKeyChange{NewWorker: control, EffectiveAt: 10},
+		PeerId:                     abi.PeerID("peer"),
+		Multiaddrs:                 []abi.Multiaddrs{[]byte("addr")},
+		SealProofType:              abi.RegisteredSealProof_StackedDrg32GiBV1,
+		SectorSize:                 abi.SectorSize32GiB,
+		WindowPoStPartitionSectors: 2349,
+	}
+	var buf bytes.Buffer
+	require.NoError(t, info0.MarshalCBOR(&buf))
+
+	info, err := miner.DecodeMinerInfoBytes(actors.Version0, buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, abi.RegisteredPoStProof_StackedDrgWindow32GiBV1, info.WindowPoStProofType)
+	assert.Equal(t, abi.ChainEpoch(-1), info.ConsensusFaultElapsed)
+	assert.Nil(t, info.PendingOwnerAddress)
+	assert.Equal(t, info0.PendingWorkerKey, info.PendingWorkerKey)
+	assert.Equal(t, info0.Multiaddrs, info.Multiaddrs)
+	// The owner becomes the beneficiary.
+	assert.Equal(t, owner, info.Beneficiary)
+	assert.Equal(t, miner.BeneficiaryTerm{Quota: big.Zero(), UsedQuota: big.Zero()}, info.BeneficiaryTerm)
+	assert.Nil(t, info.PendingBeneficiaryTerm)
+
+	// The version 3 layout is up-converted likewise.
+	info3 := miner.MinerInfo3{
+		Owner:                      owner,
+		Worker:                     worker,
+		PeerId:                     abi.PeerID("peer"),
+		WindowPoStProofType:        abi.RegisteredPoStProof_StackedDrgWindow32GiBV1,
+		SectorSize:                 abi.SectorSize32GiB,
+		WindowPoStPartitionSectors: 2349,
+		ConsensusFaultElapsed:      20,
+		PendingOwnerAddress:        &control,
+	}
+	buf.Reset()
+	require.NoError(t, info3.MarshalCBOR(&buf))
+	decoded, err := miner.DecodeMinerInfoBytes(actors.Version8, buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, owner, decoded.Beneficiary)
+	assert.Equal(t, abi.ChainEpoch(20), decoded.ConsensusFaultElapsed)
+	assert.Equal(t, &control, decoded.PendingOwnerAddress)
+
+	// The current layout round-trips.
+	info.Beneficiary = worker
+	info.BeneficiaryTerm = miner.BeneficiaryTerm{Quota: big.NewInt(1000), UsedQuota: big.NewInt(10), Expiration: 500}
+	info.PendingBeneficiaryTerm = &miner.PendingBeneficiaryChange{
+		NewBeneficiary:    control,
+		NewQuota:          big.NewInt(2000),
+		NewExpiration:     600,
+		ApprovedByNominee: true,
+	}
+	buf.Reset()
+	require.NoError(t, info.MarshalCBOR(&buf))
+	decoded, err = miner.DecodeMinerInfoBytes(actors.Version9, buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, info, decoded)
+	decoded, err = miner.DecodeMinerInfoBytes(actors.Version14, buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, info, decoded)
+
+	// The layouts are not interchangeable.
+	_, err = miner.DecodeMinerInfoBytes(actors.Version0, buf.Bytes())
+	assert.Error(t, err)
+	_, err = miner.DecodeMinerInfoBytes(actors.Version8, buf.Bytes())
+	assert.Error(t, err)
+	_, err = miner.DecodeMinerInfoBytes(actors.Version14+1, buf.Bytes())
+	assert.Error(t, err)
+
+	assert.True(t, info.IsController(owner))
+	assert.True(t, info.IsController(control))
+	assert.False(t, info.IsController(mustIDAddr(t, 103)))
+	assert.Equal(t, []addr.Address{worker, control}, info.AllControlAddresses())
+
+	// An over-long peer ID is rejected.
+	info0.PeerId = make(abi.PeerID, abi.MaxPeerIDLength+1)
+	buf.Reset()
+	require.NoError(t, info0.MarshalCBOR(&buf))
+	_, err = miner.DecodeMinerInfoBytes(actors.Version0, buf.Bytes())
+	assert.Error(t, err)
+}
+
+func mustIDAddr(t *testing.T, id uint64) addr.Address {
+	a, err := addr.NewIDAddress(id)
+	require.NoError(t, err)
+	return a
+}
@@ -0,0 +1,25 @@
+package miner
+
+import (
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+// Parameters to ReportConsensusFault, by which anyone may report a miner's consensus fault for a reward.
+// The block headers are serialized, in the layout described by crypto.ConsensusFaultEvidence.
+type ReportConsensusFaultParams struct {
+	BlockHeader1     []byte
+	BlockHeader2     []byte
+	BlockHeaderExtra []byte
+}
+
+// NewReportConsensusFaultParams returns the parameters to report a consensus fault with some evidence.
+func NewReportConsensusFaultParams(e *crypto.ConsensusFaultEvidence) (*ReportConsensusFaultParams, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return &ReportConsensusFaultParams{
+		BlockHeader1:     e.BlockHeader1,
+		BlockHeader2:     e.BlockHeader2,
+		BlockHeaderExtra: e.BlockHeaderExtra,
+	}, nil
+}
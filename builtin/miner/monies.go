@@ -0,0 +1,190 @@
+package miner
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/smoothing"
+	"github.com/filecoin-project/go-state-types/math"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+// Deposit and pledge requirements, and penalties, as computed by the miner actor. Each is a projection of the
+// block reward a sector would earn, given the reward and power actors' smoothed estimates of the per-epoch
+// reward and the network's quality-adjusted power.
+
+// Projection period of expected sector block reward for deposit required to pre-commit a sector.
+// This deposit is lost if the pre-commitment is not timely followed up by a commitment proof.
+const PreCommitDepositFactor = 20
+const PreCommitDepositProjectionPeriod = abi.ChainEpoch(PreCommitDepositFactor) * builtin.EpochsInDay
+
+// Projection period of expected sector block rewards for storage pledge required to commit a sector.
+// This pledge is lost if a sector is terminated before its full committed lifetime.
+const InitialPledgeFactor = 20
+const InitialPledgeProjectionPeriod = abi.ChainEpoch(InitialPledgeFactor) * builtin.EpochsInDay
+
+// Cap on initial pledge requirement for sectors: 1 FIL per 32GiB.
+var InitialPledgeMaxPerByte = big.Div(big.NewInt(1e18), big.NewInt(32<<30))
+
+// Multiplier of share of circulating money supply for consensus pledge required to commit a sector.
+// This pledge is lost if a sector is terminated before its full committed lifetime.
+const (
+	InitialPledgeLockTargetNum   = 3
+	InitialPledgeLockTargetDenom = 10
+)
+
+// Projection period of expected daily sector block reward penalised when a fault is continued after initial detection.
+// This guarantees that a miner pays back at least the expected block reward earned since the last successful PoSt.
+// The network conservatively assumes the sector was faulty since the last time it was proven.
+const (
+	ContinuedFaultFactorNum   = 351
+	ContinuedFaultFactorDenom = 100
+)
+const ContinuedFaultProjectionPeriod = abi.ChainEpoch((builtin.EpochsInDay * ContinuedFaultFactorNum) / ContinuedFaultFactorDenom)
+
+// Projection period of expected sector block reward which is the lower bound of the termination penalty.
+const TerminationPenaltyLowerBoundProjectionPeriod = abi.ChainEpoch((builtin.EpochsInDay * 35) / 10)
+
+// Fraction of assumed block reward penalized when a sector is terminated (from actors v2).
+const (
+	TerminationRewardFactorNum   = 1
+	TerminationRewardFactorDenom = 2
+)
+
+// Maximum number of lifetime days penalized when a sector is terminated (from actors v2).
+const TerminationLifetimeCap = 140
+
+// Maximum number of lifetime days penalized when a sector is terminated, with actors v0, which penalized the
+// whole assumed block reward.
+const TerminationLifetimeCapV0 = 70
+
+// ExpectedRewardForPower returns the projected block reward a sector would earn over some period.
+// Also known as "BR(t)".
+// BR(t) = ProjectedRewardFraction(t) * SectorQualityAdjustedPower
+// ProjectedRewardFraction(t) is the sum of estimated reward over estimated total power
+// over all epochs in the projection period [t t+projectionDuration]
+func ExpectedRewardForPower(rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower, projectionDuration abi.ChainEpoch) abi.TokenAmount {
+	networkQAPowerSmoothed := networkQAPowerEstimate.Estimate()
+	if networkQAPowerSmoothed.IsZero() {
+		return rewardEstimate.Estimate()
+	}
+	expectedRewardForProvingPeriod := smoothing.ExtrapolatedCumSumOfRatio(projectionDuration, 0, rewardEstimate, networkQAPowerEstimate)
+	br128 := big.Mul(qaSectorPower, expectedRewardForProvingPeriod) // Q.0 * Q.128 => Q.128
+	br := big.Rsh(br128, math.Precision128)
+	return big.Max(br, big.Zero())
+}
+
+// ExpectedRewardForPowerClampedAtAttoFIL is ExpectedRewardForPower, but with zero values clamped at 1 attoFIL.
+// From actors v4, the pre-commit deposit and initial pledge use it, so that they are always strictly positive.
+func ExpectedRewardForPowerClampedAtAttoFIL(rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower, projectionDuration abi.ChainEpoch) abi.TokenAmount {
+	br := ExpectedRewardForPower(rewardEstimate, networkQAPowerEstimate, qaSectorPower, projectionDuration)
+	if br.LessThanEqual(big.Zero()) {
+		br = abi.NewTokenAmount(1)
+	}
+	return br
+}
+
+// PledgePenaltyForContinuedFault returns the penalty for a sector continuing faulty for another proving period.
+// It is a projection of the expected reward earned by the sector.
+// Also known as "FF(t)"
+func PledgePenaltyForContinuedFault(rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower) abi.TokenAmount {
+	return ExpectedRewardForPower(rewardEstimate, networkQAPowerEstimate, qaSectorPower, ContinuedFaultProjectionPeriod)
+}
+
+// PledgePenaltyForTerminationLowerBound returns the lower bound of the penalty for terminating a sector.
+// Also known as "SP(t)".
+func PledgePenaltyForTerminationLowerBound(rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower) abi.TokenAmount {
+	return ExpectedRewardForPower(rewardEstimate, networkQAPowerEstimate, qaSectorPower, TerminationPenaltyLowerBoundProjectionPeriod)
+}
+
+// PledgePenaltyForTermination returns the penalty to locked pledge collateral for the termination of a sector
+// before scheduled expiry, at a network version.
+// SectorAge is the time between the sector's activation and termination.
+// replacedDayReward and replacedSectorAge are the day reward and age of the replaced sector in a capacity upgrade.
+// They must be zero if no upgrade occurred, and are ignored with actors v0.
+func PledgePenaltyForTermination(nv network.Version, dayReward abi.TokenAmount, sectorAge abi.ChainEpoch,
+	twentyDayRewardAtActivation abi.TokenAmount, networkQAPowerEstimate smoothing.FilterEstimate,
+	qaSectorPower abi.StoragePower, rewardEstimate smoothing.FilterEstimate, replacedDayReward abi.TokenAmount,
+	replacedSectorAge abi.ChainEpoch) abi.TokenAmount {
+	lowerBound := PledgePenaltyForTerminationLowerBound(rewardEstimate, networkQAPowerEstimate, qaSectorPower)
+
+	if nv < network.Version4 {
+		// max(SP(t), BR(StartEpoch, 20d) + BR(StartEpoch, 1d)*min(SectorAgeInDays, 70))
+		cappedSectorAge := minEpoch(sectorAge, TerminationLifetimeCapV0*builtin.EpochsInDay)
+		return big.Max(lowerBound, big.Add(
+			twentyDayRewardAtActivation,
+			big.Div(big.Mul(dayReward, big.NewInt(int64(cappedSectorAge))), big.NewInt(builtin.EpochsInDay))))
+	}
+
+	// max(SP(t), BR(StartEpoch, 20d) + BR(StartEpoch, 1d) * terminationRewardFactor * min(SectorAgeInDays, 140))
+	// and sectorAgeInDays = sectorAge / EpochsInDay
+	lifetimeCap := abi.ChainEpoch(TerminationLifetimeCap) * builtin.EpochsInDay
+	cappedSectorAge := minEpoch(sectorAge, lifetimeCap)
+	// expected reward for lifetime of new sector (epochs*AttoFIL/day)
+	expectedReward := big.Mul(dayReward, big.NewInt(int64(cappedSectorAge)))
+	// if lifetime under cap and this sector replaced capacity, add expected reward for old sector's lifetime up to cap
+	relevantReplacedAge := minEpoch(replacedSectorAge, lifetimeCap-cappedSectorAge)
+	expectedReward = big.Add(expectedReward, big.Mul(replacedDayReward, big.NewInt(int64(relevantReplacedAge))))
+
+	penalizedReward := big.Mul(expectedReward, big.NewInt(TerminationRewardFactorNum))
+
+	return big.Max(lowerBound, big.Add(
+		twentyDayRewardAtActivation,
+		big.Div(
+			penalizedReward,
+			big.NewInt(builtin.EpochsInDay*TerminationRewardFactorDenom)))) // (epochs*AttoFIL/day -> AttoFIL)
+}
+
+// PreCommitDepositForPower computes the PreCommit deposit given sector qa weight and current network conditions.
+// PreCommit Deposit = BR(PreCommitDepositProjectionPeriod)
+func PreCommitDepositForPower(rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower) abi.TokenAmount {
+	return ExpectedRewardForPower(rewardEstimate, networkQAPowerEstimate, qaSectorPower, PreCommitDepositProjectionPeriod)
+}
+
+// InitialPledgeForPower computes the pledge requirement for committing new quality-adjusted power to the network,
+// given the current network total and baseline power, per-epoch reward, and circulating token supply.
+// The pledge comprises two parts:
+// - storage pledge, aka IP base: a multiple of the reward expected to be earned by newly-committed power
+// - consensus pledge, aka additional IP: a pro-rata fraction of the circulating money supply
+//
+// IP = IPBase(t) + AdditionalIP(t)
+// IPBase(t) = BR(t, InitialPledgeProjectionPeriod)
+// AdditionalIP(t) = LockTarget(t)*PledgeShare(t)
+// LockTarget = (LockTargetFactorNum / LockTargetFactorDenom) * FILCirculatingSupply(t)
+// PledgeShare(t) = sectorQAPower / max(BaselinePower(t), NetworkQAPower(t))
+func InitialPledgeForPower(qaPower, baselinePower abi.StoragePower, rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, circulatingSupply abi.TokenAmount) abi.TokenAmount {
+	ipBase := ExpectedRewardForPower(rewardEstimate, networkQAPowerEstimate, qaPower, InitialPledgeProjectionPeriod)
+	return initialPledge(ipBase, qaPower, baselinePower, networkQAPowerEstimate, circulatingSupply)
+}
+
+// InitialPledgeForPowerAt is InitialPledgeForPower at a network version. From network version 12 (actors v4),
+// the storage pledge is at least 1 attoFIL.
+func InitialPledgeForPowerAt(nv network.Version, qaPower, baselinePower abi.StoragePower, rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, circulatingSupply abi.TokenAmount) abi.TokenAmount {
+	if nv < network.Version12 {
+		return InitialPledgeForPower(qaPower, baselinePower, rewardEstimate, networkQAPowerEstimate, circulatingSupply)
+	}
+	ipBase := ExpectedRewardForPowerClampedAtAttoFIL(rewardEstimate, networkQAPowerEstimate, qaPower, InitialPledgeProjectionPeriod)
+	return initialPledge(ipBase, qaPower, baselinePower, networkQAPowerEstimate, circulatingSupply)
+}
+
+func initialPledge(ipBase abi.TokenAmount, qaPower, baselinePower abi.StoragePower, networkQAPowerEstimate smoothing.FilterEstimate, circulatingSupply abi.TokenAmount) abi.TokenAmount {
+	lockTargetNum := big.Mul(big.NewInt(InitialPledgeLockTargetNum), circulatingSupply)
+	lockTargetDenom := big.NewInt(InitialPledgeLockTargetDenom)
+	pledgeShareNum := qaPower
+	networkQAPower := networkQAPowerEstimate.Estimate()
+	pledgeShareDenom := big.Max(big.Max(networkQAPower, baselinePower), qaPower) // use qaPower in case others are 0
+	additionalIPNum := big.Mul(lockTargetNum, pledgeShareNum)
+	additionalIPDenom := big.Mul(lockTargetDenom, pledgeShareDenom)
+	additionalIP := big.Div(additionalIPNum, additionalIPDenom)
+
+	nominalPledge := big.Add(ipBase, additionalIP)
+	spaceRacePledgeCap := big.Mul(InitialPledgeMaxPerByte, qaPower)
+	return big.Min(nominalPledge, spaceRacePledgeCap)
+}
+
+func minEpoch(a, b abi.ChainEpoch) abi.ChainEpoch {
+	if a < b {
+		return a
+	}
+	return b
+}
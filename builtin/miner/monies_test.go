@@ -0,0 +1,86 @@
+package miner_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/builtin/smoothing"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestExpectedRewardForPower(t *testing.T) {
+	epochReward := big.NewInt(1e18)
+	networkPower := abi.NewStoragePower(1 << 50)
+	sectorPower := abi.NewStoragePower(32 << 30)
+	rewardEstimate := smoothing.NewEstimate(epochReward, big.Zero())
+	powerEstimate := smoothing.NewEstimate(networkPower, big.Zero())
+
+	// With constant estimates, the expected reward is the sector's share of the reward over the period.
+	br := miner.ExpectedRewardForPower(rewardEstimate, powerEstimate, sectorPower, builtin.EpochsInDay)
+	expected := big.Div(big.Product(epochReward, sectorPower, big.NewInt(builtin.EpochsInDay)), networkPower)
+	assert.Equal(t, expected, br)
+	assert.Equal(t, big.Mul(expected, big.NewInt(miner.PreCommitDepositFactor)), miner.PreCommitDepositForPower(rewardEstimate, powerEstimate, sectorPower))
+
+	// Without network power, the expected reward is the per-epoch reward.
+	assert.Equal(t, epochReward, miner.ExpectedRewardForPower(rewardEstimate, smoothing.NewEstimate(big.Zero(), big.Zero()), sectorPower, builtin.EpochsInDay))
+
+	// Growing network power reduces the expected reward.
+	growing := smoothing.NewEstimate(networkPower, abi.NewStoragePower(1<<30))
+	assert.True(t, miner.ExpectedRewardForPower(rewardEstimate, growing, sectorPower, builtin.EpochsInDay).LessThan(br))
+}
+
+func TestInitialPledgeForPower(t *testing.T) {
+	rewardEstimate := smoothing.NewEstimate(big.NewInt(1e15), big.Zero())
+	powerEstimate := smoothing.NewEstimate(abi.NewStoragePower(1<<50), big.Zero())
+	sectorPower := abi.NewStoragePower(32 << 30)
+
+	// Storage pledge plus the sector's share of 30% of circulating supply.
+	supply := big.Mul(big.NewInt(100), big.NewInt(1e18))
+	pledge := miner.InitialPledgeForPower(sectorPower, abi.NewStoragePower(1<<40), rewardEstimate, powerEstimate, supply)
+	storagePledge := miner.ExpectedRewardForPower(rewardEstimate, powerEstimate, sectorPower, miner.InitialPledgeProjectionPeriod)
+	consensusPledge := big.Div(big.Product(supply, big.NewInt(3), sectorPower), big.Mul(big.NewInt(10), abi.NewStoragePower(1<<50)))
+	assert.Equal(t, big.Add(storagePledge, consensusPledge), pledge)
+
+	// Capped at 1 FIL per 32GiB.
+	pledge = miner.InitialPledgeForPower(sectorPower, abi.NewStoragePower(1<<40), rewardEstimate, powerEstimate, big.Mul(supply, big.NewInt(1e6)))
+	assert.Equal(t, big.Mul(miner.InitialPledgeMaxPerByte, sectorPower), pledge)
+
+	// From network version 12, a zero storage pledge is clamped at 1 attoFIL.
+	noReward := smoothing.NewEstimate(big.Zero(), big.Zero())
+	pledge = miner.InitialPledgeForPowerAt(network.Version11, sectorPower, abi.NewStoragePower(1<<40), noReward, powerEstimate, supply)
+	assert.Equal(t, consensusPledge, pledge)
+	pledge = miner.InitialPledgeForPowerAt(network.Version12, sectorPower, abi.NewStoragePower(1<<40), noReward, powerEstimate, supply)
+	assert.Equal(t, big.Add(consensusPledge, big.NewInt(1)), pledge)
+}
+
+func TestPledgePenaltyForTermination(t *testing.T) {
+	rewardEstimate := smoothing.NewEstimate(big.NewInt(1e15), big.Zero())
+	powerEstimate := smoothing.NewEstimate(abi.NewStoragePower(1<<50), big.Zero())
+	sectorPower := abi.NewStoragePower(32 << 30)
+	dayReward := miner.ExpectedRewardForPower(rewardEstimate, powerEstimate, sectorPower, builtin.EpochsInDay)
+	twentyDayReward := miner.ExpectedRewardForPower(rewardEstimate, powerEstimate, sectorPower, miner.InitialPledgeProjectionPeriod)
+	penalty := func(nv network.Version, age abi.ChainEpoch, replacedAge abi.ChainEpoch) abi.TokenAmount {
+		return miner.PledgePenaltyForTermination(nv, dayReward, age, twentyDayReward, powerEstimate, sectorPower, rewardEstimate, dayReward, replacedAge)
+	}
+	days := func(n int64) abi.ChainEpoch { return abi.ChainEpoch(n * builtin.EpochsInDay) }
+
+	// A new sector pays the twenty-day reward, which exceeds the lower bound.
+	lowerBound := miner.PledgePenaltyForTerminationLowerBound(rewardEstimate, powerEstimate, sectorPower)
+	assert.True(t, lowerBound.LessThan(twentyDayReward))
+	assert.Equal(t, twentyDayReward, penalty(network.Version10, 0, 0))
+	assert.Equal(t, lowerBound, miner.PledgePenaltyForTermination(network.Version10, dayReward, 0, big.Zero(), powerEstimate, sectorPower, rewardEstimate, big.Zero(), 0))
+
+	// Half a day's reward per day of age, up to the cap, including a replaced sector's age.
+	assert.Equal(t, big.Add(twentyDayReward, big.Mul(dayReward, big.NewInt(5))), penalty(network.Version10, days(10), 0))
+	assert.Equal(t, big.Add(twentyDayReward, big.Mul(dayReward, big.NewInt(10))), penalty(network.Version10, days(10), days(10)))
+	assert.Equal(t, big.Add(twentyDayReward, big.Mul(dayReward, big.NewInt(70))), penalty(network.Version10, days(200), days(10)))
+
+	// Actors v0 penalized a whole day's reward per day of age, up to 70 days, ignoring replaced sectors.
+	assert.Equal(t, big.Add(twentyDayReward, big.Mul(dayReward, big.NewInt(10))), penalty(network.Version3, days(10), days(10)))
+	assert.Equal(t, big.Add(twentyDayReward, big.Mul(dayReward, big.NewInt(70))), penalty(network.Version3, days(200), 0))
+}
@@ -0,0 +1,205 @@
+package miner
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/verifreg"
+)
+
+// Types for onboarding data into sectors, either by committing new sectors or by updating committed-capacity
+// sectors with new data (snap deals). The data of each sector is described by a manifest of pieces, each of
+// which may claim a verified allocation and notify other actors (such as the storage market) of its activation.
+
+// VerifiedAllocationKey identifies a verified allocation to be claimed by a piece.
+type VerifiedAllocationKey struct {
+	Client abi.ActorID
+	ID     verifreg.AllocationId
+}
+
+// DataActivationNotification asks for an actor to be notified when a piece is activated.
+type DataActivationNotification struct {
+	// The actor to notify.
+	Address addr.Address
+	// Data sent in the notification, such as a deal ID.
+	Payload []byte
+}
+
+// PieceActivationManifest describes a piece of data in a sector.
+type PieceActivationManifest struct {
+	// Piece data commitment.
+	CID cid.Cid
+	// Piece size.
+	Size abi.PaddedPieceSize
+	// The verified allocation to claim for the piece, if any.
+	VerifiedAllocationKey *VerifiedAllocationKey
+	// Notifications to send when the piece is activated.
+	Notify []DataActivationNotification
+}
+
+// SectorUpdateManifest describes the update of a committed-capacity sector with new data.
+type SectorUpdateManifest struct {
+	// The sector to update.
+	Sector abi.SectorNumber
+	// The deadline and partition to which the sector is assigned.
+	Deadline  uint64
+	Partition uint64
+	// CommR of the updated replica.
+	NewSealedCID cid.Cid
+	// The pieces of the new data, in order.
+	Pieces []PieceActivationManifest
+}
+
+// Parameters to ProveReplicaUpdates3.
+type ProveReplicaUpdates3Params struct {
+	SectorUpdates []SectorUpdateManifest
+	// Proofs of the updates, one per sector. Must be empty if AggregateProof is provided.
+	SectorProofs [][]byte
+	// An aggregate proof of all the updates. Must be empty if SectorProofs are provided.
+	AggregateProof   []byte
+	UpdateProofsType abi.RegisteredUpdateProof
+	// The aggregation proof type, if the updates are aggregated.
+	AggregateProofType abi.OptionalAggregationProof
+	// Whether to abort the whole batch if any update fails.
+	RequireActivationSuccess bool
+	// Whether to abort the whole batch if any notification is rejected.
+	RequireNotificationSuccess bool
+}
+
+// Return value of ProveReplicaUpdates3: the result of each update.
+type ProveReplicaUpdates3Return = builtin.BatchReturn
+
+// SectorActivationManifest describes the data of a new sector being proven.
+type SectorActivationManifest struct {
+	// The sector, which must have been pre-committed.
+	SectorNumber abi.SectorNumber
+	// The pieces of the sector's data, in order.
+	Pieces []PieceActivationManifest
+}
+
+// Parameters to ProveCommitSectors3.
+type ProveCommitSectors3Params struct {
+	SectorActivations []SectorActivationManifest
+	// Proofs of the sectors, one per sector. Must be empty if AggregateProof is provided.
+	SectorProofs [][]byte
+	// An aggregate proof of all the sectors. Must be empty if SectorProofs are provided.
+	AggregateProof []byte
+	// The aggregation proof type, if the proofs are aggregated.
+	AggregateProofType abi.OptionalAggregationProof
+	// Whether to abort the whole batch if any sector fails to activate.
+	RequireActivationSuccess bool
+	// Whether to abort the whole batch if any notification is rejected.
+	RequireNotificationSuccess bool
+}
+
+// Return value of ProveCommitSectors3: the result of each activation.
+type ProveCommitSectors3Return = builtin.BatchReturn
+
+// Maximum size of the payload of a data activation notification accepted by ValidatePieceManifests.
+// Notifications are sent within the proving message, so large payloads make onboarding messages expensive
+// and risk exceeding the message size limit.
+const MaxNotificationPayloadSize = 1 << 10
+
+// Maximum number of notifications per piece accepted by ValidatePieceManifests.
+const MaxNotificationsPerPiece = 16
+
+// ValidatePieceManifests checks that piece sizes are valid, that each verified allocation is claimed at most
+// once, and that notifications are within the size and count limits.
+func ValidatePieceManifests(pieces []PieceActivationManifest) error {
+	for i, p := range pieces {
+		if err := p.Size.Validate(); err != nil {
+			return xerrors.Errorf("invalid size of piece %d: %w", i, err)
+		}
+		if !p.CID.Defined() {
+			return xerrors.Errorf("piece %d has no CID", i)
+		}
+		if len(p.Notify) > MaxNotificationsPerPiece {
+			return xerrors.Errorf("piece %d has too many notifications: %d > %d", i, len(p.Notify), MaxNotificationsPerPiece)
+		}
+		for j, n := range p.Notify {
+			if len(n.Payload) > MaxNotificationPayloadSize {
+				return xerrors.Errorf("notification %d of piece %d has payload of %d bytes, exceeding %d", j, i, len(n.Payload), MaxNotificationPayloadSize)
+			}
+		}
+	}
+	claimed := map[VerifiedAllocationKey]struct{}{}
+	for i, p := range pieces {
+		if p.VerifiedAllocationKey == nil {
+			continue
+		}
+		if _, ok := claimed[*p.VerifiedAllocationKey]; ok {
+			return xerrors.Errorf("piece %d claims allocation %d of client %d more than once", i, p.VerifiedAllocationKey.ID, p.VerifiedAllocationKey.Client)
+		}
+		claimed[*p.VerifiedAllocationKey] = struct{}{}
+	}
+	return nil
+}
+
+// Validate checks the pieces of every sector, and that the sectors are proven either individually (one proof
+// per sector) or by an aggregate proof of a specified type.
+func (p *ProveCommitSectors3Params) Validate() error {
+	if len(p.SectorActivations) == 0 {
+		return xerrors.Errorf("no sectors to activate")
+	}
+	if err := validateProofs(len(p.SectorActivations), p.SectorProofs, p.AggregateProof, p.AggregateProofType.IsSome()); err != nil {
+		return err
+	}
+	sectors := map[abi.SectorNumber]struct{}{}
+	for _, a := range p.SectorActivations {
+		if _, ok := sectors[a.SectorNumber]; ok {
+			return xerrors.Errorf("sector %d activated more than once", a.SectorNumber)
+		}
+		sectors[a.SectorNumber] = struct{}{}
+		if err := ValidatePieceManifests(a.Pieces); err != nil {
+			return xerrors.Errorf("invalid manifest for sector %d: %w", a.SectorNumber, err)
+		}
+	}
+	return nil
+}
+
+// Validate checks the pieces of every update, and that the updates are proven either individually (one proof
+// per sector) or by an aggregate proof of a specified type.
+func (p *ProveReplicaUpdates3Params) Validate() error {
+	if len(p.SectorUpdates) == 0 {
+		return xerrors.Errorf("no sectors to update")
+	}
+	if err := validateProofs(len(p.SectorUpdates), p.SectorProofs, p.AggregateProof, p.AggregateProofType.IsSome()); err != nil {
+		return err
+	}
+	sectors := map[abi.SectorNumber]struct{}{}
+	for _, u := range p.SectorUpdates {
+		if _, ok := sectors[u.Sector]; ok {
+			return xerrors.Errorf("sector %d updated more than once", u.Sector)
+		}
+		sectors[u.Sector] = struct{}{}
+		if err := ValidatePieceManifests(u.Pieces); err != nil {
+			return xerrors.Errorf("invalid manifest for sector %d: %w", u.Sector, err)
+		}
+	}
+	return nil
+}
+
+func validateProofs(sectorCount int, sectorProofs [][]byte, aggregateProof []byte, hasAggregateProofType bool) error {
+	if len(aggregateProof) == 0 {
+		if hasAggregateProofType {
+			return xerrors.Errorf("aggregate proof type given without aggregate proof")
+		}
+		if len(sectorProofs) != sectorCount {
+			return xerrors.Errorf("expected %d sector proofs, got %d", sectorCount, len(sectorProofs))
+		}
+		return nil
+	}
+	if len(sectorProofs) != 0 {
+		return xerrors.Errorf("both sector proofs and an aggregate proof given")
+	}
+	if !hasAggregateProofType {
+		return xerrors.Errorf("aggregate proof given without a type")
+	}
+	if len(aggregateProof) > MaxAggregateProofSize {
+		return xerrors.Errorf("aggregate proof of %d bytes exceeds maximum %d", len(aggregateProof), MaxAggregateProofSize)
+	}
+	return nil
+}
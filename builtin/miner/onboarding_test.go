@@ -0,0 +1,104 @@
+package miner_test
+
+import (
+	"bytes"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/builtin/verifreg"
+)
+
+func TestProveReplicaUpdates3ParamsEncoding(t *testing.T) {
+	market, err := addr.NewIDAddress(5)
+	require.NoError(t, err)
+	commR, err := abi.CidBuilder.Sum([]byte("commr"))
+	require.NoError(t, err)
+	commP, err := abi.CidBuilder.Sum([]byte("commp"))
+	require.NoError(t, err)
+
+	params := miner.ProveReplicaUpdates3Params{
+		SectorUpdates: []miner.SectorUpdateManifest{{
+			Sector:       7,
+			Deadline:     2,
+			Partition:    1,
+			NewSealedCID: commR,
+			Pieces: []miner.PieceActivationManifest{
+				{CID: commP, Size: 1 << 30, VerifiedAllocationKey: &miner.VerifiedAllocationKey{Client: 1000, ID: verifreg.AllocationId(12)}},
+				{CID: commP, Size: 1 << 30, Notify: []miner.DataActivationNotification{{Address: market, Payload: []byte{0x19, 0x01}}}},
+			},
+		}},
+		SectorProofs:             [][]byte{{1, 2, 3}},
+		UpdateProofsType:         abi.RegisteredUpdateProof_StackedDrg32GiBV1,
+		RequireActivationSuccess: true,
+	}
+
+	roundTrip := func(p miner.ProveReplicaUpdates3Params) {
+		var buf bytes.Buffer
+		require.NoError(t, p.MarshalCBOR(&buf))
+		var decoded miner.ProveReplicaUpdates3Params
+		require.NoError(t, decoded.UnmarshalCBOR(&buf))
+		assert.Equal(t, p, decoded)
+	}
+	roundTrip(params)
+
+	aggregated := params
+	aggregated.SectorProofs = nil
+	aggregated.AggregateProof = []byte{4, 5, 6}
+	aggregated.AggregateProofType = abi.SomeAggregationProof(abi.RegisteredAggregationProof_SnarkPackV2)
+	roundTrip(aggregated)
+}
+
+func TestProveCommitSectors3ParamsValidate(t *testing.T) {
+	market, err := addr.NewIDAddress(5)
+	require.NoError(t, err)
+	commP, err := abi.CidBuilder.Sum([]byte("commp"))
+	require.NoError(t, err)
+	alloc := &miner.VerifiedAllocationKey{Client: 1000, ID: 1}
+
+	params := miner.ProveCommitSectors3Params{
+		SectorActivations: []miner.SectorActivationManifest{
+			{SectorNumber: 1, Pieces: []miner.PieceActivationManifest{{CID: commP, Size: 1 << 30, VerifiedAllocationKey: alloc}}},
+			{SectorNumber: 2, Pieces: []miner.PieceActivationManifest{{CID: commP, Size: 1 << 30, Notify: []miner.DataActivationNotification{{Address: market, Payload: []byte{1}}}}}},
+		},
+		SectorProofs: [][]byte{{1}, {2}},
+	}
+	require.NoError(t, params.Validate())
+
+	var buf bytes.Buffer
+	require.NoError(t, params.MarshalCBOR(&buf))
+	var decoded miner.ProveCommitSectors3Params
+	require.NoError(t, decoded.UnmarshalCBOR(&buf))
+	assert.Equal(t, params, decoded)
+
+	// Proofs must be either individual or aggregated.
+	bad := params
+	bad.SectorProofs = [][]byte{{1}}
+	assert.Error(t, bad.Validate())
+	bad.AggregateProof = []byte{1}
+	assert.Error(t, bad.Validate())
+	bad.SectorProofs = nil
+	assert.Error(t, bad.Validate())
+	bad.AggregateProofType = abi.SomeAggregationProof(abi.RegisteredAggregationProof_SnarkPackV2)
+	assert.NoError(t, bad.Validate())
+
+	// Oversized notification payloads and invalid piece sizes are rejected.
+	bad = params
+	bad.SectorActivations = []miner.SectorActivationManifest{
+		{SectorNumber: 1, Pieces: []miner.PieceActivationManifest{{CID: commP, Size: 1 << 30, Notify: []miner.DataActivationNotification{{Address: market, Payload: make([]byte, miner.MaxNotificationPayloadSize+1)}}}}},
+	}
+	bad.SectorProofs = [][]byte{{1}}
+	assert.Error(t, bad.Validate())
+	bad.SectorActivations[0].Pieces = []miner.PieceActivationManifest{{CID: commP, Size: 1000}}
+	assert.Error(t, bad.Validate())
+
+	// An allocation may be claimed only once.
+	assert.Error(t, miner.ValidatePieceManifests([]miner.PieceActivationManifest{
+		{CID: commP, Size: 1 << 30, VerifiedAllocationKey: alloc},
+		{CID: commP, Size: 1 << 30, VerifiedAllocationKey: &miner.VerifiedAllocationKey{Client: 1000, ID: 1}},
+	}))
+}
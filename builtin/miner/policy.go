@@ -0,0 +1,64 @@
+package miner
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/filecoin-project/go-state-types/proof"
+)
+
+// Minimum and maximum number of sectors whose interactive PoReps may be aggregated in one ProveCommitAggregate.
+const (
+	MinAggregatedSectors = 4
+	MaxAggregatedSectors = 819
+)
+
+// Minimum and maximum number of sectors whose non-interactive PoReps may be aggregated in one message.
+const (
+	MinAggregatedSectorsNI = 1
+	MaxAggregatedSectorsNI = 65
+)
+
+// Maximum size of an aggregate proof, in bytes.
+const MaxAggregateProofSize = proof.MaxAggregateProofBytes
+
+// The network version from which interactive PoReps may be aggregated.
+const AggregatePoRepNetworkVersion = network.Version13
+
+// The network version from which non-interactive PoReps may be submitted.
+const NIPoRepNetworkVersion = network.Version23
+
+// AggregateSectorLimits returns the minimum and maximum number of sectors whose PoReps may be aggregated in one
+// message at a network version, for a seal proof type. It fails if aggregation of that type is not yet
+// available at the network version.
+func AggregateSectorLimits(nv network.Version, sealProof abi.RegisteredSealProof) (min, max int, err error) {
+	if _, ok := abi.SealProofInfos[sealProof]; !ok {
+		return 0, 0, xerrors.Errorf("unsupported seal proof type %v", sealProof)
+	}
+	if sealProof.IsNonInteractive() {
+		if nv < NIPoRepNetworkVersion {
+			return 0, 0, xerrors.Errorf("non-interactive PoRep is not available before network version %v", NIPoRepNetworkVersion)
+		}
+		return MinAggregatedSectorsNI, MaxAggregatedSectorsNI, nil
+	}
+	if nv < AggregatePoRepNetworkVersion {
+		return 0, 0, xerrors.Errorf("PoRep aggregation is not available before network version %v", AggregatePoRepNetworkVersion)
+	}
+	return MinAggregatedSectors, MaxAggregatedSectors, nil
+}
+
+// QualityForWeight returns the quality of a sector, as a fixed-point number with builtin.SectorQualityPrecision
+// fractional bits, given its size, duration (from activation to expiration) and the space-time of its deals.
+// It is abi.QualityForWeight.
+func QualityForWeight(size abi.SectorSize, duration abi.ChainEpoch, dealWeight, verifiedWeight abi.DealWeight) abi.SectorQuality {
+	return abi.QualityForWeight(size, duration, dealWeight, verifiedWeight)
+}
+
+// QAPowerForWeight returns the quality-adjusted power of a sector, given its size, duration and deal weights.
+func QAPowerForWeight(size abi.SectorSize, duration abi.ChainEpoch, dealWeight, verifiedWeight abi.DealWeight) abi.StoragePower {
+	quality := QualityForWeight(size, duration, dealWeight, verifiedWeight)
+	return big.Rsh(big.Mul(big.NewIntUnsigned(uint64(size)), quality), builtin.SectorQualityPrecision)
+}
@@ -0,0 +1,34 @@
+package miner_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestAggregateSectorLimits(t *testing.T) {
+	ni := abi.RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep
+	interactive := abi.RegisteredSealProof_StackedDrg32GiBV2
+
+	min, max, err := miner.AggregateSectorLimits(network.Version23, ni)
+	require.NoError(t, err)
+	assert.Equal(t, miner.MinAggregatedSectorsNI, min)
+	assert.Equal(t, miner.MaxAggregatedSectorsNI, max)
+
+	min, max, err = miner.AggregateSectorLimits(network.Version23, interactive)
+	require.NoError(t, err)
+	assert.Equal(t, miner.MinAggregatedSectors, min)
+	assert.Equal(t, miner.MaxAggregatedSectors, max)
+
+	_, _, err = miner.AggregateSectorLimits(network.Version22, ni)
+	assert.Error(t, err)
+	_, _, err = miner.AggregateSectorLimits(network.Version12, interactive)
+	assert.Error(t, err)
+	_, _, err = miner.AggregateSectorLimits(network.Version23, abi.RegisteredSealProof(17))
+	assert.Error(t, err)
+}
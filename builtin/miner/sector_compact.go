@@ -0,0 +1,253 @@
+package miner
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// Indexers exporting every sector on the network need far less than the full on-chain sector information, and
+// cannot afford a big integer allocation per amount. A CompactSectorInfo is a flat, fixed-width summary, and a
+// batch of them is encoded column by column, which keeps similar values together for compression.
+
+// Maximum number of sectors in an encoded batch.
+const MaxCompactSectorBatch = 1 << 20
+
+// Version of the batch encoding, written as its first byte.
+const compactSectorBatchVersion = 1
+
+// Amount128 is a non-negative token amount or deal weight of at most 128 bits.
+type Amount128 struct {
+	Hi uint64
+	Lo uint64
+}
+
+// NewAmount128 returns the 128-bit form of an amount, which must be non-negative and less than 2^128.
+// A nil amount is zero.
+func NewAmount128(a big.Int) (Amount128, error) {
+	if a.Int == nil {
+		return Amount128{}, nil
+	}
+	if a.Sign() < 0 {
+		return Amount128{}, xerrors.Errorf("amount %s is negative", a)
+	}
+	if a.BitLen() > 128 {
+		return Amount128{}, xerrors.Errorf("amount %s exceeds 128 bits", a)
+	}
+	var b [16]byte
+	a.FillBytes(b[:])
+	return Amount128{Hi: binary.BigEndian.Uint64(b[:8]), Lo: binary.BigEndian.Uint64(b[8:])}, nil
+}
+
+// Int returns the amount as a big integer.
+func (a Amount128) Int() big.Int {
+	var b [16]byte
+	binary.BigEndian.PutUint64(b[:8], a.Hi)
+	binary.BigEndian.PutUint64(b[8:], a.Lo)
+	return big.PositiveFromUnsignedBytes(b[:])
+}
+
+// CompactSectorInfo is a flat summary of a SectorOnChainInfo.
+// Sector commitments are held as their raw values, and the deal IDs only as their count.
+type CompactSectorInfo struct {
+	SectorNumber abi.SectorNumber
+	SealProof    abi.RegisteredSealProof
+	CommR        abi.Commitment
+	// The original CommR of an updated sector, valid only if HasSectorKey.
+	SectorKey             abi.Commitment
+	HasSectorKey          bool
+	DealCount             uint32
+	Activation            abi.ChainEpoch
+	Expiration            abi.ChainEpoch
+	PowerBaseEpoch        abi.ChainEpoch
+	Flags                 SectorOnChainInfoFlags
+	DealWeight            Amount128
+	VerifiedDealWeight    Amount128
+	InitialPledge         Amount128
+	ExpectedDayReward     Amount128
+	ExpectedStoragePledge Amount128
+	ReplacedDayReward     Amount128
+}
+
+// NewCompactSectorInfo returns the compact summary of a sector's information.
+func NewCompactSectorInfo(s *SectorOnChainInfo) (CompactSectorInfo, error) {
+	c := CompactSectorInfo{
+		SectorNumber:   s.SectorNumber,
+		SealProof:      s.SealProof,
+		Activation:     s.Activation,
+		Expiration:     s.Expiration,
+		PowerBaseEpoch: s.PowerBaseEpoch,
+		Flags:          s.Flags,
+	}
+	if uint64(len(s.DealIDs)) > uint64(^uint32(0)) {
+		return c, xerrors.Errorf("sector %d has too many deals: %d", s.SectorNumber, len(s.DealIDs))
+	}
+	c.DealCount = uint32(len(s.DealIDs))
+
+	var err error
+	if c.CommR, err = abi.SealedCommitment(s.SealedCID, s.SealProof); err != nil {
+		return c, xerrors.Errorf("sector %d: %w", s.SectorNumber, err)
+	}
+	if s.SectorKeyCID != nil {
+		if c.SectorKey, err = abi.SealedCommitment(*s.SectorKeyCID, s.SealProof); err != nil {
+			return c, xerrors.Errorf("sector %d key: %w", s.SectorNumber, err)
+		}
+		c.HasSectorKey = true
+	}
+	for _, a := range []struct {
+		dst  *Amount128
+		src  big.Int
+		name string
+	}{
+		{&c.DealWeight, s.DealWeight, "deal weight"},
+		{&c.VerifiedDealWeight, s.VerifiedDealWeight, "verified deal weight"},
+		{&c.InitialPledge, s.InitialPledge, "initial pledge"},
+		{&c.ExpectedDayReward, s.ExpectedDayReward, "expected day reward"},
+		{&c.ExpectedStoragePledge, s.ExpectedStoragePledge, "expected storage pledge"},
+		{&c.ReplacedDayReward, s.ReplacedDayReward, "replaced day reward"},
+	} {
+		if *a.dst, err = NewAmount128(a.src); err != nil {
+			return c, xerrors.Errorf("sector %d %s: %w", s.SectorNumber, a.name, err)
+		}
+	}
+	return c, nil
+}
+
+// A column of the batch encoding: a fixed-width big-endian field of each sector.
+type compactSectorColumn struct {
+	width int
+	put   func(b []byte, s *CompactSectorInfo)
+	get   func(b []byte, s *CompactSectorInfo)
+}
+
+func uint64Column(f func(s *CompactSectorInfo) *uint64) compactSectorColumn {
+	return compactSectorColumn{
+		width: 8,
+		put:   func(b []byte, s *CompactSectorInfo) { binary.BigEndian.PutUint64(b, *f(s)) },
+		get:   func(b []byte, s *CompactSectorInfo) { *f(s) = binary.BigEndian.Uint64(b) },
+	}
+}
+
+func int64Column(f func(s *CompactSectorInfo) *abi.ChainEpoch) compactSectorColumn {
+	return compactSectorColumn{
+		width: 8,
+		put:   func(b []byte, s *CompactSectorInfo) { binary.BigEndian.PutUint64(b, uint64(*f(s))) },
+		get:   func(b []byte, s *CompactSectorInfo) { *f(s) = abi.ChainEpoch(binary.BigEndian.Uint64(b)) },
+	}
+}
+
+func digestColumn(f func(s *CompactSectorInfo) *abi.Commitment) compactSectorColumn {
+	return compactSectorColumn{
+		width: abi.CommitmentBytesLen,
+		put:   func(b []byte, s *CompactSectorInfo) { copy(b, f(s)[:]) },
+		get:   func(b []byte, s *CompactSectorInfo) { copy(f(s)[:], b) },
+	}
+}
+
+func amountColumn(f func(s *CompactSectorInfo) *Amount128) compactSectorColumn {
+	return compactSectorColumn{
+		width: 16,
+		put: func(b []byte, s *CompactSectorInfo) {
+			binary.BigEndian.PutUint64(b[:8], f(s).Hi)
+			binary.BigEndian.PutUint64(b[8:], f(s).Lo)
+		},
+		get: func(b []byte, s *CompactSectorInfo) {
+			*f(s) = Amount128{Hi: binary.BigEndian.Uint64(b[:8]), Lo: binary.BigEndian.Uint64(b[8:])}
+		},
+	}
+}
+
+var compactSectorColumns = []compactSectorColumn{
+	uint64Column(func(s *CompactSectorInfo) *uint64 { return (*uint64)(&s.SectorNumber) }),
+	{
+		width: 8,
+		put:   func(b []byte, s *CompactSectorInfo) { binary.BigEndian.PutUint64(b, uint64(s.SealProof)) },
+		get: func(b []byte, s *CompactSectorInfo) {
+			s.SealProof = abi.RegisteredSealProof(binary.BigEndian.Uint64(b))
+		},
+	},
+	digestColumn(func(s *CompactSectorInfo) *abi.Commitment { return &s.CommR }),
+	{
+		width: 1,
+		put: func(b []byte, s *CompactSectorInfo) {
+			b[0] = 0
+			if s.HasSectorKey {
+				b[0] = 1
+			}
+		},
+		get: func(b []byte, s *CompactSectorInfo) { s.HasSectorKey = b[0] != 0 },
+	},
+	digestColumn(func(s *CompactSectorInfo) *abi.Commitment { return &s.SectorKey }),
+	{
+		width: 4,
+		put:   func(b []byte, s *CompactSectorInfo) { binary.BigEndian.PutUint32(b, s.DealCount) },
+		get:   func(b []byte, s *CompactSectorInfo) { s.DealCount = binary.BigEndian.Uint32(b) },
+	},
+	int64Column(func(s *CompactSectorInfo) *abi.ChainEpoch { return &s.Activation }),
+	int64Column(func(s *CompactSectorInfo) *abi.ChainEpoch { return &s.Expiration }),
+	int64Column(func(s *CompactSectorInfo) *abi.ChainEpoch { return &s.PowerBaseEpoch }),
+	uint64Column(func(s *CompactSectorInfo) *uint64 { return (*uint64)(&s.Flags) }),
+	amountColumn(func(s *CompactSectorInfo) *Amount128 { return &s.DealWeight }),
+	amountColumn(func(s *CompactSectorInfo) *Amount128 { return &s.VerifiedDealWeight }),
+	amountColumn(func(s *CompactSectorInfo) *Amount128 { return &s.InitialPledge }),
+	amountColumn(func(s *CompactSectorInfo) *Amount128 { return &s.ExpectedDayReward }),
+	amountColumn(func(s *CompactSectorInfo) *Amount128 { return &s.ExpectedStoragePledge }),
+	amountColumn(func(s *CompactSectorInfo) *Amount128 { return &s.ReplacedDayReward }),
+}
+
+// EncodeCompactSectorBatch writes a batch of at most MaxCompactSectorBatch sectors: a version byte and
+// 4-byte big-endian count, followed by each field of every sector in turn.
+func EncodeCompactSectorBatch(w io.Writer, sectors []CompactSectorInfo) error {
+	if len(sectors) > MaxCompactSectorBatch {
+		return xerrors.Errorf("batch of %d sectors exceeds maximum %d", len(sectors), MaxCompactSectorBatch)
+	}
+	bw := bufio.NewWriter(w)
+	var header [5]byte
+	header[0] = compactSectorBatchVersion
+	binary.BigEndian.PutUint32(header[1:], uint32(len(sectors)))
+	if _, err := bw.Write(header[:]); err != nil {
+		return err
+	}
+	var buf [abi.CommitmentBytesLen]byte
+	for _, col := range compactSectorColumns {
+		for i := range sectors {
+			col.put(buf[:col.width], &sectors[i])
+			if _, err := bw.Write(buf[:col.width]); err != nil {
+				return err
+			}
+		}
+	}
+	return bw.Flush()
+}
+
+// DecodeCompactSectorBatch reads a batch written by EncodeCompactSectorBatch.
+func DecodeCompactSectorBatch(r io.Reader) ([]CompactSectorInfo, error) {
+	br := bufio.NewReader(r)
+	var header [5]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, xerrors.Errorf("failed to read batch header: %w", err)
+	}
+	if header[0] != compactSectorBatchVersion {
+		return nil, xerrors.Errorf("unsupported batch version %d", header[0])
+	}
+	count := binary.BigEndian.Uint32(header[1:])
+	if count > MaxCompactSectorBatch {
+		return nil, xerrors.Errorf("batch of %d sectors exceeds maximum %d", count, MaxCompactSectorBatch)
+	}
+	sectors := make([]CompactSectorInfo, count)
+	var buf [abi.CommitmentBytesLen]byte
+	for ci, col := range compactSectorColumns {
+		for i := range sectors {
+			if _, err := io.ReadFull(br, buf[:col.width]); err != nil {
+				return nil, xerrors.Errorf("failed to read column %d of sector %d: %w", ci, i, err)
+			}
+			col.get(buf[:col.width], &sectors[i])
+		}
+	}
+	return sectors, nil
+}
@@ -0,0 +1,125 @@
+package miner_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+)
+
+func sealedCID(t *testing.T, b byte) cid.Cid {
+	hash, err := mh.Encode(bytes.Repeat([]byte{b}, abi.CommitmentBytesLen), mh.POSEIDON_BLS12_381_A1_FC1)
+	require.NoError(t, err)
+	return cid.NewCidV1(cid.FilCommitmentSealed, hash)
+}
+
+func testSectorInfo(t *testing.T, n abi.SectorNumber) *miner.SectorOnChainInfo {
+	key := sealedCID(t, 2)
+	return &miner.SectorOnChainInfo{
+		SectorNumber:          n,
+		SealProof:             abi.RegisteredSealProof_StackedDrg32GiBV2,
+		SealedCID:             sealedCID(t, 1),
+		DealIDs:               []abi.DealID{7, 8},
+		Activation:            100,
+		Expiration:            200000,
+		DealWeight:            big.NewInt(1 << 40),
+		VerifiedDealWeight:    big.Zero(),
+		InitialPledge:         big.MustFromString("200000000000000000"),
+		ExpectedDayReward:     big.NewInt(5000),
+		ExpectedStoragePledge: big.NewInt(100000),
+		PowerBaseEpoch:        150,
+		ReplacedDayReward:     big.Zero(),
+		SectorKeyCID:          &key,
+		Flags:                 miner.SimpleQAPower,
+	}
+}
+
+func TestSectorOnChainInfoCBOR(t *testing.T) {
+	info := testSectorInfo(t, 12)
+	var buf bytes.Buffer
+	require.NoError(t, info.MarshalCBOR(&buf))
+	var out miner.SectorOnChainInfo
+	require.NoError(t, out.UnmarshalCBOR(&buf))
+	assert.Equal(t, *info, out)
+	assert.True(t, out.HasSimpleQAPower())
+}
+
+func TestNewCompactSectorInfo(t *testing.T) {
+	info := testSectorInfo(t, 12)
+	c, err := miner.NewCompactSectorInfo(info)
+	require.NoError(t, err)
+	assert.Equal(t, abi.SectorNumber(12), c.SectorNumber)
+	assert.Equal(t, uint32(2), c.DealCount)
+	assert.Equal(t, byte(1), c.CommR[0])
+	assert.True(t, c.HasSectorKey)
+	assert.Equal(t, byte(2), c.SectorKey[0])
+	assert.Equal(t, info.InitialPledge, c.InitialPledge.Int())
+	assert.Equal(t, info.DealWeight, c.DealWeight.Int())
+
+	info.SectorKeyCID = nil
+	c, err = miner.NewCompactSectorInfo(info)
+	require.NoError(t, err)
+	assert.False(t, c.HasSectorKey)
+
+	bad := testSectorInfo(t, 12)
+	bad.SealedCID = cid.NewCidV1(cid.Raw, bad.SealedCID.Hash())
+	_, err = miner.NewCompactSectorInfo(bad)
+	assert.Error(t, err)
+
+	bad = testSectorInfo(t, 12)
+	bad.InitialPledge = big.NewInt(-1)
+	_, err = miner.NewCompactSectorInfo(bad)
+	assert.Error(t, err)
+}
+
+func TestAmount128(t *testing.T) {
+	max := big.Sub(big.Lsh(big.NewInt(1), 128), big.NewInt(1))
+	a, err := miner.NewAmount128(max)
+	require.NoError(t, err)
+	assert.Equal(t, miner.Amount128{Hi: ^uint64(0), Lo: ^uint64(0)}, a)
+	assert.Equal(t, max, a.Int())
+
+	_, err = miner.NewAmount128(big.Add(max, big.NewInt(1)))
+	assert.Error(t, err)
+
+	zero, err := miner.NewAmount128(big.Int{})
+	require.NoError(t, err)
+	assert.Equal(t, miner.Amount128{}, zero)
+}
+
+func TestCompactSectorBatch(t *testing.T) {
+	var sectors []miner.CompactSectorInfo
+	for n := abi.SectorNumber(0); n < 5; n++ {
+		info := testSectorInfo(t, n)
+		info.Activation = -1 // Epochs are signed.
+		c, err := miner.NewCompactSectorInfo(info)
+		require.NoError(t, err)
+		sectors = append(sectors, c)
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, miner.EncodeCompactSectorBatch(&buf, sectors))
+	enc := buf.Bytes()
+	out, err := miner.DecodeCompactSectorBatch(bytes.NewReader(enc))
+	require.NoError(t, err)
+	assert.Equal(t, sectors, out)
+
+	// The first column is the sector numbers.
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 0, 0, 1}, enc[5+8:5+16])
+
+	_, err = miner.DecodeCompactSectorBatch(bytes.NewReader(enc[:len(enc)-1]))
+	assert.Error(t, err)
+
+	empty := bytes.Buffer{}
+	require.NoError(t, miner.EncodeCompactSectorBatch(&empty, nil))
+	out, err = miner.DecodeCompactSectorBatch(&empty)
+	require.NoError(t, err)
+	assert.Empty(t, out)
+}
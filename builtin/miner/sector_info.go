@@ -0,0 +1,48 @@
+package miner
+
+import (
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// SectorOnChainInfoFlags are flags recorded on a sector's on-chain information.
+type SectorOnChainInfoFlags uint64
+
+const (
+	// The sector's quality-adjusted power is computed from its verified deal weight alone, without
+	// time-weighting, as for sectors activated with ProveCommitSectors3.
+	SimpleQAPower SectorOnChainInfoFlags = 1 << iota
+)
+
+// SectorOnChainInfo is the information a miner records for each proven sector.
+type SectorOnChainInfo struct {
+	SectorNumber abi.SectorNumber
+	SealProof    abi.RegisteredSealProof // The seal proof type implies the PoSt proof/s
+	SealedCID    cid.Cid                 `checked:"true"` // CommR
+	DealIDs      []abi.DealID
+	Activation   abi.ChainEpoch // Epoch during which the sector proof was accepted
+	Expiration   abi.ChainEpoch // Epoch during which the sector expires
+	// Integral of active deals over sector lifetime.
+	DealWeight abi.DealWeight
+	// Integral of active verified deals over sector lifetime.
+	VerifiedDealWeight abi.DealWeight
+	// Pledge collected to commit this sector.
+	InitialPledge abi.TokenAmount
+	// Expected one day projection of reward for sector computed at activation time.
+	ExpectedDayReward abi.TokenAmount
+	// Expected twenty day projection of reward for sector computed at activation time.
+	ExpectedStoragePledge abi.TokenAmount
+	// Epoch at which this sector's power was most recently updated.
+	PowerBaseEpoch abi.ChainEpoch
+	// Day reward of this sector before its power was most recently updated.
+	ReplacedDayReward abi.TokenAmount
+	// The original SealedCID, only gets set on the first ReplicaUpdate.
+	SectorKeyCID *cid.Cid
+	Flags        SectorOnChainInfoFlags
+}
+
+// HasSimpleQAPower returns whether the sector's power is computed without time-weighting.
+func (s *SectorOnChainInfo) HasSimpleQAPower() bool {
+	return s.Flags&SimpleQAPower != 0
+}
@@ -0,0 +1,92 @@
+package miner
+
+import (
+	"context"
+
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/adt"
+)
+
+// SectorLocation is the deadline and partition to which a sector is assigned.
+type SectorLocation struct {
+	Deadline  uint64
+	Partition uint64
+}
+
+// SectorLocator indexes the location of each of a miner's sectors. It is built by scanning the miner's
+// deadlines once, and may then be kept up to date incrementally as partitions change.
+// A SectorLocator is not safe for concurrent modification.
+type SectorLocator struct {
+	locations map[abi.SectorNumber]SectorLocation
+}
+
+// NewSectorLocator returns an empty locator.
+func NewSectorLocator() *SectorLocator {
+	return &SectorLocator{locations: map[abi.SectorNumber]SectorLocation{}}
+}
+
+// LoadSectorLocator builds a locator for all sectors in a miner's deadlines.
+func LoadSectorLocator(ctx context.Context, store ipldcbor.IpldStore, deadlines *Deadlines) (*SectorLocator, error) {
+	l := NewSectorLocator()
+	for dlIdx, dl := range deadlines.Due {
+		partitions, err := LoadDeadlinePartitions(ctx, store, dl)
+		if err != nil {
+			return nil, err
+		}
+		if err := adt.ForEachAmtEntry(ctx, store, partitions, func(partIdx uint64, v []byte) error {
+			sectors, err := DecodePartitionSectors(v)
+			if err != nil {
+				return xerrors.Errorf("deadline %d partition %d: %w", dlIdx, partIdx, err)
+			}
+			l.SetPartition(uint64(dlIdx), partIdx, sectors)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Locate returns the location of a sector, if it is assigned to a partition.
+func (l *SectorLocator) Locate(sector abi.SectorNumber) (SectorLocation, bool) {
+	loc, ok := l.locations[sector]
+	return loc, ok
+}
+
+// Len returns the number of sectors located.
+func (l *SectorLocator) Len() int {
+	return len(l.locations)
+}
+
+// SetPartition records sectors as assigned to a partition, moving any already located elsewhere (as when
+// partitions are compacted). Sectors previously in the partition but not given remain recorded there.
+func (l *SectorLocator) SetPartition(deadline, partition uint64, sectors abi.SectorRangeSet) {
+	loc := SectorLocation{Deadline: deadline, Partition: partition}
+	_ = sectors.ForEach(func(n abi.SectorNumber) error {
+		l.locations[n] = loc
+		return nil
+	})
+}
+
+// Remove forgets the location of sectors, as when they are terminated and removed from their partitions.
+func (l *SectorLocator) Remove(sectors abi.SectorRangeSet) {
+	_ = sectors.ForEach(func(n abi.SectorNumber) error {
+		delete(l.locations, n)
+		return nil
+	})
+}
+
+// Sectors returns the sectors located in a partition.
+func (l *SectorLocator) Sectors(deadline, partition uint64) abi.SectorRangeSet {
+	want := SectorLocation{Deadline: deadline, Partition: partition}
+	var nums []abi.SectorNumber
+	for n, loc := range l.locations {
+		if loc == want {
+			nums = append(nums, n)
+		}
+	}
+	return abi.SectorRangeSetFromNumbers(nums...)
+}
@@ -0,0 +1,80 @@
+package miner_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+func TestLoadSectorLocator(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+
+	// An AMT of bit width 1 and height 1, with partitions at indices 0, 1 and 3.
+	p0 := partition(t, abi.SectorRangeSetFromNumbers(1, 2))
+	p1 := partition(t, abi.SectorRangeSetFromNumbers(5))
+	p3 := partition(t, abi.SectorRangeSetFromNumbers(9, 10))
+	left := ipld.PutBlock(t, store, ipld.AmtNode([]byte{0b11}, nil, p0, p1))
+	right := ipld.PutBlock(t, store, ipld.AmtNode([]byte{0b10}, nil, p3))
+	partitions := ipld.PutBlock(t, store, ipld.AmtRoot(1, 1, 3, ipld.AmtNode([]byte{0b11}, []cid.Cid{left, right})))
+
+	// An empty partitions AMT.
+	empty := ipld.PutBlock(t, store, ipld.AmtRoot(6, 0, 0, ipld.AmtNode([]byte{0}, nil)))
+
+	var deadlines miner.Deadlines
+	for i := range deadlines.Due {
+		deadlines.Due[i] = ipld.PutBlock(t, store, deadline(empty))
+	}
+	deadlines.Due[7] = ipld.PutBlock(t, store, deadline(partitions))
+
+	var buf bytes.Buffer
+	require.NoError(t, deadlines.MarshalCBOR(&buf))
+	var decoded miner.Deadlines
+	require.NoError(t, decoded.UnmarshalCBOR(&buf))
+	require.Equal(t, deadlines, decoded)
+
+	l, err := miner.LoadSectorLocator(ctx, store, &decoded)
+	require.NoError(t, err)
+	assert.Equal(t, 5, l.Len())
+	for n, expected := range map[abi.SectorNumber]miner.SectorLocation{
+		1: {7, 0}, 2: {7, 0}, 5: {7, 1}, 9: {7, 3}, 10: {7, 3},
+	} {
+		loc, ok := l.Locate(n)
+		require.True(t, ok, "sector %d", n)
+		assert.Equal(t, expected, loc, "sector %d", n)
+	}
+	_, ok := l.Locate(3)
+	assert.False(t, ok)
+
+	// Incremental updates.
+	l.SetPartition(8, 0, abi.SectorRangeSetFromNumbers(5, 11))
+	loc, _ := l.Locate(5)
+	assert.Equal(t, miner.SectorLocation{Deadline: 8, Partition: 0}, loc)
+	l.Remove(abi.SectorRangeSetFromNumbers(1, 11))
+	assert.Equal(t, abi.SectorRangeSetFromNumbers(2), l.Sectors(7, 0))
+	assert.Equal(t, abi.SectorRangeSetFromNumbers(5), l.Sectors(8, 0))
+	assert.Equal(t, 4, l.Len())
+}
+
+// A partition, with the sectors bitfield followed by a placeholder for the remaining fields.
+func partition(t *testing.T, sectors abi.SectorRangeSet) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, cbg.CborWriteHeader(&buf, cbg.MajArray, 2))
+	require.NoError(t, sectors.MarshalCBOR(&buf))
+	buf.WriteByte(0x80)
+	return buf.Bytes()
+}
+
+// A deadline, with the partitions root followed by a placeholder for the remaining fields.
+func deadline(partitions cid.Cid) []byte {
+	return ipld.Tuple(ipld.Link(partitions), []byte{0x00})
+}
@@ -0,0 +1,37 @@
+package miner
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/smoothing"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+// TerminationPenalty estimates the fee for terminating a sector of some age and quality-adjusted power at a
+// network version, given the current reward and network power estimates.
+// The fee depends on the day and twenty-day rewards projected when the sector was activated, which are assumed
+// to equal those projected by the current estimates. For the fee of a sector on chain, which records those
+// projections, see SectorTerminationPenalty.
+func TerminationPenalty(sectorAge abi.ChainEpoch, qaSectorPower abi.StoragePower, rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate, nv network.Version) abi.TokenAmount {
+	dayReward := ExpectedRewardForPower(rewardEstimate, networkQAPowerEstimate, qaSectorPower, builtin.EpochsInDay)
+	twentyDayReward := ExpectedRewardForPower(rewardEstimate, networkQAPowerEstimate, qaSectorPower, InitialPledgeProjectionPeriod)
+	return PledgePenaltyForTermination(nv, dayReward, sectorAge, twentyDayReward, networkQAPowerEstimate,
+		qaSectorPower, rewardEstimate, big.Zero(), 0)
+}
+
+// QAPowerForSector returns the quality-adjusted power of a sector on chain, which is weighted over the sector's
+// life from its most recent power update.
+func QAPowerForSector(size abi.SectorSize, sector *SectorOnChainInfo) abi.StoragePower {
+	duration := sector.Expiration - sector.PowerBaseEpoch
+	return QAPowerForWeight(size, duration, sector.DealWeight, sector.VerifiedDealWeight)
+}
+
+// SectorTerminationPenalty returns the fee for terminating a sector on chain at an epoch, as the miner actor
+// charges it from actors v7 (network version 15): the sector's age is counted from its most recent power update,
+// and a replaced sector's age from its activation to that update.
+func SectorTerminationPenalty(nv network.Version, sector *SectorOnChainInfo, size abi.SectorSize, currEpoch abi.ChainEpoch, rewardEstimate, networkQAPowerEstimate smoothing.FilterEstimate) abi.TokenAmount {
+	return PledgePenaltyForTermination(nv, sector.ExpectedDayReward, currEpoch-sector.PowerBaseEpoch,
+		sector.ExpectedStoragePledge, networkQAPowerEstimate, QAPowerForSector(size, sector), rewardEstimate,
+		sector.ReplacedDayReward, sector.PowerBaseEpoch-sector.Activation)
+}
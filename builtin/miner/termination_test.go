@@ -0,0 +1,47 @@
+package miner_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/builtin/smoothing"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestTerminationPenalty(t *testing.T) {
+	rewardEstimate := smoothing.NewEstimate(big.NewInt(1e15), big.Zero())
+	powerEstimate := smoothing.NewEstimate(abi.NewStoragePower(1<<50), big.Zero())
+	sectorPower := abi.NewStoragePower(32 << 30)
+	dayReward := miner.ExpectedRewardForPower(rewardEstimate, powerEstimate, sectorPower, builtin.EpochsInDay)
+	twentyDayReward := miner.ExpectedRewardForPower(rewardEstimate, powerEstimate, sectorPower, miner.InitialPledgeProjectionPeriod)
+	days := func(n int64) abi.ChainEpoch { return abi.ChainEpoch(n * builtin.EpochsInDay) }
+
+	assert.Equal(t, twentyDayReward, miner.TerminationPenalty(0, sectorPower, rewardEstimate, powerEstimate, network.Version21))
+	assert.Equal(t, big.Add(twentyDayReward, big.Mul(dayReward, big.NewInt(5))),
+		miner.TerminationPenalty(days(10), sectorPower, rewardEstimate, powerEstimate, network.Version21))
+	assert.Equal(t, big.Add(twentyDayReward, big.Mul(dayReward, big.NewInt(10))),
+		miner.TerminationPenalty(days(10), sectorPower, rewardEstimate, powerEstimate, network.Version3))
+	assert.Equal(t, big.Add(twentyDayReward, big.Mul(dayReward, big.NewInt(70))),
+		miner.TerminationPenalty(days(1000), sectorPower, rewardEstimate, powerEstimate, network.Version21))
+
+	// A committed-capacity sector of 32GiB, updated 30 days after activation.
+	sector := miner.SectorOnChainInfo{
+		Activation:            100,
+		Expiration:            100 + days(540),
+		DealWeight:            big.Zero(),
+		VerifiedDealWeight:    big.Zero(),
+		ExpectedDayReward:     dayReward,
+		ExpectedStoragePledge: twentyDayReward,
+		PowerBaseEpoch:        100 + days(30),
+		ReplacedDayReward:     dayReward,
+	}
+	assert.Equal(t, sectorPower, miner.QAPowerForSector(abi.SectorSize32GiB, &sector))
+	// Ten days after the update, ten days of the sector and thirty of the replaced sector are penalized.
+	assert.Equal(t, big.Add(twentyDayReward, big.Mul(dayReward, big.NewInt(20))),
+		miner.SectorTerminationPenalty(network.Version21, &sector, abi.SectorSize32GiB, sector.PowerBaseEpoch+days(10), rewardEstimate, powerEstimate))
+}
@@ -0,0 +1,73 @@
+package multisig
+
+import (
+	addr "github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// ApprovalStatus summarises what remains for a pending transaction to execute, from the point of view of a
+// signer considering approving it.
+type ApprovalStatus struct {
+	// Number of further approvals needed to reach the threshold.
+	RemainingApprovals uint64
+	// Whether the signer may approve the transaction: it is a signer and has not already approved.
+	CanApprove bool
+	// Whether an approval by the signer would reach the threshold, and so attempt to execute the transaction.
+	ExecutesOnApproval bool
+	// The earliest epoch, at or after the current epoch, from which enough of the balance is unlocked to pay
+	// the transaction's value. Valid only if FundsSufficient.
+	UnlockedEpoch abi.ChainEpoch
+	// Whether the balance is ever sufficient to pay the transaction's value.
+	FundsSufficient bool
+}
+
+// SimulateApproval reports the status of a pending transaction for a signer considering approving it at the
+// current epoch, given the multisig's balance (assumed not to change). It does not modify the state.
+// A proposer has already approved their own transaction, so cannot approve it again.
+func SimulateApproval(st *State, txn *Transaction, signer addr.Address, balance abi.TokenAmount, currEpoch abi.ChainEpoch) ApprovalStatus {
+	var status ApprovalStatus
+	if approvals := uint64(len(txn.Approved)); approvals < st.NumApprovalsThreshold {
+		status.RemainingApprovals = st.NumApprovalsThreshold - approvals
+	}
+	status.CanApprove = st.IsSigner(signer) && !hasApproved(txn, signer)
+	status.ExecutesOnApproval = status.CanApprove && status.RemainingApprovals <= 1
+	status.UnlockedEpoch, status.FundsSufficient = UnlockedEpoch(st, balance, txn.Value, currEpoch)
+	return status
+}
+
+// UnlockedEpoch returns the earliest epoch, at or after the current epoch, at which a balance less the amount
+// still locked is at least some value. Returns false if the balance is never sufficient.
+func UnlockedEpoch(st *State, balance, value abi.TokenAmount, currEpoch abi.ChainEpoch) (abi.ChainEpoch, bool) {
+	available := big.Sub(balance, value)
+	if available.LessThan(big.Zero()) {
+		return 0, false
+	}
+	sufficient := func(epoch abi.ChainEpoch) bool {
+		return st.AmountLocked(epoch - st.StartEpoch).LessThanEqual(available)
+	}
+	if sufficient(currEpoch) {
+		return currEpoch, true
+	}
+	// The locked amount decreases monotonically to zero at the end of the unlock, when the balance suffices.
+	lo, hi := currEpoch, st.StartEpoch+st.UnlockDuration
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		if sufficient(mid) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi, true
+}
+
+func hasApproved(txn *Transaction, signer addr.Address) bool {
+	for _, a := range txn.Approved {
+		if a == signer {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package multisig_test
+
+import (
+	"bytes"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/multisig"
+)
+
+func TestSimulateApproval(t *testing.T) {
+	a, b, c, outsider := idAddr(t, 100), idAddr(t, 101), idAddr(t, 102), idAddr(t, 103)
+	st := &multisig.State{
+		Signers:               []addr.Address{a, b, c},
+		NumApprovalsThreshold: 2,
+		InitialBalance:        abi.NewTokenAmount(1000),
+		StartEpoch:            100,
+		UnlockDuration:        1000,
+	}
+	txn := &multisig.Transaction{To: outsider, Value: abi.NewTokenAmount(500), Approved: []addr.Address{a}}
+	balance := abi.NewTokenAmount(1000)
+
+	// The proposer has already approved.
+	status := multisig.SimulateApproval(st, txn, a, balance, 0)
+	assert.Equal(t, uint64(1), status.RemainingApprovals)
+	assert.False(t, status.CanApprove)
+	assert.False(t, status.ExecutesOnApproval)
+
+	// Half the initial balance is unlocked at epoch 600.
+	status = multisig.SimulateApproval(st, txn, b, balance, 0)
+	assert.Equal(t, multisig.ApprovalStatus{RemainingApprovals: 1, CanApprove: true, ExecutesOnApproval: true, UnlockedEpoch: 600, FundsSufficient: true}, status)
+
+	status = multisig.SimulateApproval(st, txn, outsider, balance, 700)
+	assert.False(t, status.CanApprove)
+	assert.Equal(t, abi.ChainEpoch(700), status.UnlockedEpoch)
+
+	// The balance never suffices for more than itself.
+	txn.Value = abi.NewTokenAmount(1001)
+	status = multisig.SimulateApproval(st, txn, c, balance, 0)
+	assert.False(t, status.FundsSufficient)
+}
+
+func TestAmountLocked(t *testing.T) {
+	st := &multisig.State{InitialBalance: abi.NewTokenAmount(10), UnlockDuration: 3}
+	assert.Equal(t, abi.NewTokenAmount(10), st.AmountLocked(-1))
+	assert.Equal(t, abi.NewTokenAmount(7), st.AmountLocked(1)) // Rounded up.
+	assert.Equal(t, abi.NewTokenAmount(4), st.AmountLocked(2))
+	assert.Equal(t, big.Zero(), st.AmountLocked(3))
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	st := multisig.State{
+		Signers:               []addr.Address{idAddr(t, 100), idAddr(t, 101)},
+		NumApprovalsThreshold: 2,
+		NextTxnID:             3,
+		InitialBalance:        abi.NewTokenAmount(1000),
+		StartEpoch:            -1,
+		UnlockDuration:        1000,
+	}
+	st.PendingTxns, _ = abi.CidBuilder.Sum([]byte{0x80})
+	var buf bytes.Buffer
+	require.NoError(t, st.MarshalCBOR(&buf))
+	var out multisig.State
+	require.NoError(t, out.UnmarshalCBOR(&buf))
+	assert.Equal(t, st, out)
+}
+
+func idAddr(t *testing.T, id uint64) addr.Address {
+	a, err := addr.NewIDAddress(id)
+	require.NoError(t, err)
+	return a
+}
@@ -0,0 +1,72 @@
+package multisig
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+)
+
+// TxnID identifies a pending transaction of a multisig.
+type TxnID int64
+
+// State is the state of a multisig actor.
+type State struct {
+	// The addresses which may propose and approve transactions.
+	Signers []addr.Address
+	// The number of approvals, including the proposer's, required to execute a transaction.
+	NumApprovalsThreshold uint64
+	// The ID of the next transaction to be proposed.
+	NextTxnID TxnID
+
+	// Linear unlock of the initial balance: InitialBalance unlocks evenly over UnlockDuration epochs,
+	// beginning at StartEpoch.
+	InitialBalance abi.TokenAmount
+	StartEpoch     abi.ChainEpoch
+	UnlockDuration abi.ChainEpoch
+
+	// HAMT of pending transactions, keyed by TxnID.
+	PendingTxns cid.Cid
+}
+
+// Transaction is a pending transaction of a multisig.
+type Transaction struct {
+	To     addr.Address
+	Value  abi.TokenAmount
+	Method abi.MethodNum
+	Params []byte
+
+	// The signers which have approved the transaction, beginning with its proposer.
+	Approved []addr.Address
+}
+
+// IsSigner returns whether an address is a signer of the multisig. Signers are compared as given, so an
+// address must be resolved to the same protocol as the signers (usually ID addresses) before the call.
+func (st *State) IsSigner(a addr.Address) bool {
+	for _, s := range st.Signers {
+		if s == a {
+			return true
+		}
+	}
+	return false
+}
+
+// AmountLocked returns the amount of the initial balance still locked some number of epochs after the
+// start of the unlock.
+func (st *State) AmountLocked(elapsedEpoch abi.ChainEpoch) abi.TokenAmount {
+	if elapsedEpoch >= st.UnlockDuration {
+		return abi.NewTokenAmount(0)
+	}
+	if elapsedEpoch <= 0 {
+		return st.InitialBalance
+	}
+	// The locked amount is rounded up, so that no more than the unlocked proportion is ever spendable.
+	numerator := big.Mul(st.InitialBalance, big.NewInt(int64(st.UnlockDuration-elapsedEpoch)))
+	denominator := big.NewInt(int64(st.UnlockDuration))
+	quot := big.Div(numerator, denominator)
+	if rem := big.Mod(numerator, denominator); !rem.IsZero() {
+		quot = big.Add(quot, big.NewInt(1))
+	}
+	return quot
+}
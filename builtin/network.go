@@ -0,0 +1,15 @@
+package builtin
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// The duration of a chain epoch, in seconds.
+// This is long enough for a block to propagate, and for all supported miners to compute a WinningPoSt in time.
+const EpochDurationSeconds = abi.EpochDurationSeconds
+
+const SecondsInHour = 60 * 60
+const SecondsInDay = 24 * SecondsInHour
+const EpochsInHour = SecondsInHour / EpochDurationSeconds
+const EpochsInDay = 24 * EpochsInHour
+const EpochsInYear = 365 * EpochsInDay
@@ -0,0 +1,49 @@
+package power
+
+import (
+	addr "github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/proof"
+)
+
+// Maximum number of prove-commits each miner can submit in one epoch.
+//
+// This limits the number of proof partitions we may need to load in the cron call path.
+// Onboarding 1EiB/year requires at least 32 prove-commits per epoch.
+const MaxMinerProveCommitsPerEpoch = 200
+
+// The power actor's cron event queue holds, for each epoch, the events to be delivered to miners at the
+// end of that epoch. The queue is a multimap from epoch to CronEvent.
+type CronEvent struct {
+	MinerAddr       addr.Address
+	CallbackPayload []byte
+}
+
+// Parameters to EnrollCronEvent, by which a miner schedules a callback at a future epoch.
+// The payload is returned verbatim to the miner when the event is delivered.
+type EnrollCronEventParams struct {
+	EventEpoch abi.ChainEpoch
+	Payload    []byte
+}
+
+// PoRep proofs submitted by miners (by SubmitPoRepForBulkVerify) are not verified immediately, but queued
+// in the power actor's proof validation batch: a map from miner address to an array of proof.SealVerifyInfo.
+// The batch is verified in bulk, and successful sectors confirmed to each miner, in the power actor's cron
+// handler at the end of the epoch. Each miner may have at most MaxMinerProveCommitsPerEpoch proofs queued.
+
+// Parameters to SubmitPoRepForBulkVerify: the proof to queue for the calling miner.
+type SubmitPoRepForBulkVerifyParams = proof.SealVerifyInfo
+
+// ProofValidationBatchEntry is the entry of one miner in the proof validation batch: the proofs it has queued
+// this epoch, in the order they were submitted.
+type ProofValidationBatchEntry struct {
+	Miner  addr.Address
+	Proofs []proof.SealVerifyInfo
+}
+
+// The result of verification of the queued proofs of one miner, delivered by the power actor to the miner
+// (as ConfirmSectorProofsValid) at the end of the epoch.
+type ConfirmSectorProofsParams struct {
+	Sectors []abi.SectorNumber
+}
@@ -0,0 +1,77 @@
+package power
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/builtin/smoothing"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+// Projections of network power and pledge extrapolate the reward and power actors' smoothed estimates
+// linearly from the epoch at which they were taken. The baseline power and circulating supply are held at
+// their given values.
+
+// ProjectionInputs are the network conditions from which projections are made.
+type ProjectionInputs struct {
+	// The reward actor's estimate of the per-epoch reward.
+	RewardEstimate smoothing.FilterEstimate
+	// The power actor's estimate of the network's quality-adjusted power.
+	NetworkQAPowerEstimate smoothing.FilterEstimate
+	BaselinePower          abi.StoragePower
+	CirculatingSupply      abi.TokenAmount
+}
+
+// Projection is the projected state of the network some epochs after the estimates were taken.
+type Projection struct {
+	// Epochs after the estimates were taken.
+	Delta          abi.ChainEpoch
+	NetworkQAPower abi.StoragePower
+	// The initial pledge of a sector with the projected quality-adjusted power.
+	SectorPledge abi.TokenAmount
+}
+
+// ProjectNetworkQAPower returns the network's quality-adjusted power projected delta epochs ahead.
+// The projection does not fall below zero.
+func ProjectNetworkQAPower(networkQAPowerEstimate smoothing.FilterEstimate, delta abi.ChainEpoch) abi.StoragePower {
+	projected := networkQAPowerEstimate.Extrapolate(delta)
+	return big.Max(projected.Estimate(), big.Zero())
+}
+
+// ProjectSectorPledge returns the initial pledge of a sector with some quality-adjusted power, committed
+// delta epochs ahead, with the formula in effect at a network version.
+func ProjectSectorPledge(nv network.Version, in ProjectionInputs, qaSectorPower abi.StoragePower, delta abi.ChainEpoch) (abi.TokenAmount, error) {
+	if nv < network.Version4 {
+		return big.Zero(), xerrors.Errorf("pledge projections are not supported before network version 4")
+	}
+	reward := in.RewardEstimate.Extrapolate(delta)
+	power := in.NetworkQAPowerEstimate.Extrapolate(delta)
+	if power.PositionEstimate.LessThan(big.Zero()) {
+		power.PositionEstimate = big.Zero()
+	}
+	return miner.InitialPledgeForPowerAt(nv, qaSectorPower, in.BaselinePower, reward, power, in.CirculatingSupply), nil
+}
+
+// Project returns projections for count epochs, step epochs apart, beginning at the epoch the estimates
+// were taken.
+func Project(nv network.Version, in ProjectionInputs, qaSectorPower abi.StoragePower, step abi.ChainEpoch, count int) ([]Projection, error) {
+	if step <= 0 {
+		return nil, xerrors.Errorf("projection step must be positive, got %d", step)
+	}
+	out := make([]Projection, 0, count)
+	for i := 0; i < count; i++ {
+		delta := step * abi.ChainEpoch(i)
+		pledge, err := ProjectSectorPledge(nv, in, qaSectorPower, delta)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Projection{
+			Delta:          delta,
+			NetworkQAPower: ProjectNetworkQAPower(in.NetworkQAPowerEstimate, delta),
+			SectorPledge:   pledge,
+		})
+	}
+	return out, nil
+}
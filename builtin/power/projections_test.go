@@ -0,0 +1,49 @@
+package power_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/builtin/power"
+	"github.com/filecoin-project/go-state-types/builtin/smoothing"
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+func TestProjectNetworkQAPower(t *testing.T) {
+	estimate := smoothing.NewEstimate(abi.NewStoragePower(1<<50), abi.NewStoragePower(-1<<40))
+	assert.Equal(t, abi.NewStoragePower(1<<50), power.ProjectNetworkQAPower(estimate, 0))
+	assert.Equal(t, abi.NewStoragePower(1<<50-100<<40), power.ProjectNetworkQAPower(estimate, 100))
+	// The projection stops at zero.
+	assert.Equal(t, big.Zero(), power.ProjectNetworkQAPower(estimate, 1<<11))
+}
+
+func TestProject(t *testing.T) {
+	in := power.ProjectionInputs{
+		RewardEstimate:         smoothing.NewEstimate(big.NewInt(1e15), big.Zero()),
+		NetworkQAPowerEstimate: smoothing.NewEstimate(abi.NewStoragePower(1<<50), abi.NewStoragePower(1<<30)),
+		BaselinePower:          abi.NewStoragePower(1 << 40),
+		CirculatingSupply:      big.Mul(big.NewInt(100), big.NewInt(1e18)),
+	}
+	sectorPower := abi.NewStoragePower(32 << 30)
+
+	projections, err := power.Project(network.Version21, in, sectorPower, builtin.EpochsInDay, 3)
+	require.NoError(t, err)
+	require.Len(t, projections, 3)
+	assert.Equal(t, abi.ChainEpoch(0), projections[0].Delta)
+	assert.Equal(t, abi.ChainEpoch(2*builtin.EpochsInDay), projections[2].Delta)
+	assert.Equal(t, miner.InitialPledgeForPower(sectorPower, in.BaselinePower, in.RewardEstimate, in.NetworkQAPowerEstimate, in.CirculatingSupply), projections[0].SectorPledge)
+	// Growing power increases the network power and reduces the pledge of a sector.
+	assert.True(t, projections[1].NetworkQAPower.GreaterThan(projections[0].NetworkQAPower))
+	assert.True(t, projections[1].SectorPledge.LessThan(projections[0].SectorPledge))
+
+	_, err = power.Project(network.Version3, in, sectorPower, builtin.EpochsInDay, 3)
+	assert.Error(t, err)
+	_, err = power.Project(network.Version21, in, sectorPower, 0, 3)
+	assert.Error(t, err)
+}
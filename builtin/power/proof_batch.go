@@ -0,0 +1,58 @@
+package power
+
+import (
+	"bytes"
+	"context"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/adt"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/proof"
+)
+
+// LoadProofValidationBatch loads the power actor's proof validation batch, given the root of its HAMT of miner
+// addresses to AMTs of queued proofs. Miners are returned in HAMT order.
+func LoadProofValidationBatch(ctx context.Context, store ipldcbor.IpldStore, batch cid.Cid) ([]ProofValidationBatchEntry, error) {
+	var out []ProofValidationBatchEntry
+	err := adt.ForEachHamtEntry(ctx, store, batch, func(k, v []byte) error {
+		miner, err := addr.NewFromBytes(k)
+		if err != nil {
+			return xerrors.Errorf("invalid miner key %x: %w", k, err)
+		}
+		proofs, err := cbg.ReadCid(bytes.NewReader(v))
+		if err != nil {
+			return xerrors.Errorf("invalid proofs root for miner %s: %w", miner, err)
+		}
+		entry := ProofValidationBatchEntry{Miner: miner}
+		if err := adt.ForEachAmtEntry(ctx, store, proofs, func(i uint64, v []byte) error {
+			var info proof.SealVerifyInfo
+			if _, err := cbor.Unmarshal(v, &info); err != nil {
+				return xerrors.Errorf("failed to decode proof %d of miner %s: %w", i, miner, err)
+			}
+			entry.Proofs = append(entry.Proofs, info)
+			return nil
+		}); err != nil {
+			return err
+		}
+		out = append(out, entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QueuedProofCount returns the total number of proofs queued in the batch.
+func QueuedProofCount(batch []ProofValidationBatchEntry) int {
+	n := 0
+	for _, e := range batch {
+		n += len(e.Proofs)
+	}
+	return n
+}
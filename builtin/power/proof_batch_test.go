@@ -0,0 +1,97 @@
+package power_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/power"
+	"github.com/filecoin-project/go-state-types/proof"
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+func TestProofValidationBatchEntryRoundTrip(t *testing.T) {
+	entry := power.ProofValidationBatchEntry{
+		Miner:  mustIDAddr(t, 1000),
+		Proofs: []proof.SealVerifyInfo{sealVerifyInfo(t, 1000, 1), sealVerifyInfo(t, 1000, 2)},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, entry.MarshalCBOR(&buf))
+	var out power.ProofValidationBatchEntry
+	require.NoError(t, out.UnmarshalCBOR(&buf))
+	assert.Equal(t, entry, out)
+
+	params := power.SubmitPoRepForBulkVerifyParams(sealVerifyInfo(t, 1000, 3))
+	buf.Reset()
+	require.NoError(t, params.MarshalCBOR(&buf))
+	var outParams power.SubmitPoRepForBulkVerifyParams
+	require.NoError(t, outParams.UnmarshalCBOR(&buf))
+	assert.Equal(t, params, outParams)
+}
+
+func TestLoadProofValidationBatch(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+
+	p1, p2, p3 := sealVerifyInfo(t, 1000, 1), sealVerifyInfo(t, 1000, 2), sealVerifyInfo(t, 1001, 7)
+	proofs1000 := ipld.PutBlock(t, store, amtOf(t, p1, p2))
+	proofs1001 := ipld.PutBlock(t, store, amtOf(t, p3))
+	root := ipld.PutBlock(t, store, ipld.HamtBucketNode(minerEntry(t, 1000, proofs1000), minerEntry(t, 1001, proofs1001)))
+
+	batch, err := power.LoadProofValidationBatch(ctx, store, root)
+	require.NoError(t, err)
+	assert.Equal(t, []power.ProofValidationBatchEntry{
+		{Miner: mustIDAddr(t, 1000), Proofs: []proof.SealVerifyInfo{p1, p2}},
+		{Miner: mustIDAddr(t, 1001), Proofs: []proof.SealVerifyInfo{p3}},
+	}, batch)
+	assert.Equal(t, 3, power.QueuedProofCount(batch))
+
+	// A proofs root that is not a link.
+	bad := ipld.PutBlock(t, store, ipld.HamtBucketNode(ipld.HamtEntry(mustIDAddr(t, 1000).Bytes(), []byte{0x01})))
+	_, err = power.LoadProofValidationBatch(ctx, store, bad)
+	assert.Error(t, err)
+}
+
+func sealVerifyInfo(t *testing.T, miner abi.ActorID, sector abi.SectorNumber) proof.SealVerifyInfo {
+	sealed, err := abi.CidBuilder.Sum([]byte("sealed"))
+	require.NoError(t, err)
+	unsealed, err := abi.CidBuilder.Sum([]byte("unsealed"))
+	require.NoError(t, err)
+	return proof.SealVerifyInfo{
+		SealProof:             abi.RegisteredSealProof_StackedDrg32GiBV1,
+		SectorID:              abi.SectorID{Miner: miner, Number: sector},
+		DealIDs:               []abi.DealID{5},
+		Randomness:            abi.SealRandomness{1, 2},
+		InteractiveRandomness: abi.InteractiveSealRandomness{3, 4},
+		Proof:                 []byte{5, 6},
+		SealedCID:             sealed,
+		UnsealedCID:           unsealed,
+	}
+}
+
+func minerEntry(t *testing.T, miner abi.ActorID, proofs cid.Cid) []byte {
+	return ipld.HamtEntry(mustIDAddr(t, uint64(miner)).Bytes(), ipld.Link(proofs))
+}
+
+// Encodes an AMT of height 0 and bit width 3 holding the values at indices from 0.
+func amtOf(t *testing.T, values ...proof.SealVerifyInfo) []byte {
+	encoded := make([][]byte, len(values))
+	for i := range values {
+		var buf bytes.Buffer
+		require.NoError(t, values[i].MarshalCBOR(&buf))
+		encoded[i] = buf.Bytes()
+	}
+	return ipld.AmtRoot(3, 0, uint64(len(values)), ipld.AmtNode([]byte{byte(1<<len(values) - 1)}, nil, encoded...))
+}
+
+func mustIDAddr(t *testing.T, id uint64) addr.Address {
+	a, err := addr.NewIDAddress(id)
+	require.NoError(t, err)
+	return a
+}
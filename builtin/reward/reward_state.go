@@ -0,0 +1,115 @@
+// Package reward holds the state of the reward actor, which mints the block reward each epoch, and helpers
+// projecting the rewards a sector can expect to earn from its smoothed estimates.
+package reward
+
+import (
+	"io"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/builtin/smoothing"
+)
+
+// State of the reward actor, from actors v2.
+type State struct {
+	// Target cumulative sum of baseline power, in byte-epochs.
+	CumsumBaseline big.Int
+	// Cumulative sum of network power capped at the baseline, in byte-epochs.
+	CumsumRealized big.Int
+	// The epoch at which the baseline cumulative sum reaches the realized cumulative sum, which determines
+	// the baseline share of the reward.
+	EffectiveNetworkTime   abi.ChainEpoch
+	EffectiveBaselinePower abi.StoragePower
+
+	// The reward minted this epoch, to be shared among the blocks of the next epoch.
+	ThisEpochReward abi.TokenAmount
+	// Smoothed estimate of the per-epoch reward.
+	ThisEpochRewardSmoothed smoothing.FilterEstimate
+	ThisEpochBaselinePower  abi.StoragePower
+
+	// The epoch of the state.
+	Epoch abi.ChainEpoch
+
+	// Total reward paid to miners, and the totals of the simple and baseline minting schedules.
+	TotalStoragePowerReward abi.TokenAmount
+	SimpleTotal             abi.TokenAmount
+	BaselineTotal           abi.TokenAmount
+}
+
+// State of the reward actor with actors v0, which had no record of the minting totals and no smoothed reward
+// estimate until the first epoch was processed.
+type StateV0 struct {
+	CumsumBaseline          big.Int
+	CumsumRealized          big.Int
+	EffectiveNetworkTime    abi.ChainEpoch
+	EffectiveBaselinePower  abi.StoragePower
+	ThisEpochReward         abi.TokenAmount
+	ThisEpochRewardSmoothed *smoothing.FilterEstimate
+	ThisEpochBaselinePower  abi.StoragePower
+	Epoch                   abi.ChainEpoch
+	TotalMined              abi.TokenAmount
+}
+
+// Estimates are the reward actor's estimates at an epoch, which are the same in every layout of its state.
+type Estimates struct {
+	Epoch                   abi.ChainEpoch
+	ThisEpochReward         abi.TokenAmount
+	ThisEpochRewardSmoothed smoothing.FilterEstimate
+	ThisEpochBaselinePower  abi.StoragePower
+}
+
+func (st *State) Estimates() Estimates {
+	return Estimates{
+		Epoch:                   st.Epoch,
+		ThisEpochReward:         st.ThisEpochReward,
+		ThisEpochRewardSmoothed: st.ThisEpochRewardSmoothed,
+		ThisEpochBaselinePower:  st.ThisEpochBaselinePower,
+	}
+}
+
+// Estimates returns the estimates of the state, failing if the smoothed reward estimate has not been made.
+func (st *StateV0) Estimates() (Estimates, error) {
+	if st.ThisEpochRewardSmoothed == nil {
+		return Estimates{}, xerrors.Errorf("reward state at epoch %d has no smoothed reward estimate", st.Epoch)
+	}
+	return Estimates{
+		Epoch:                   st.Epoch,
+		ThisEpochReward:         st.ThisEpochReward,
+		ThisEpochRewardSmoothed: *st.ThisEpochRewardSmoothed,
+		ThisEpochBaselinePower:  st.ThisEpochBaselinePower,
+	}, nil
+}
+
+// DecodeEstimates reads the estimates from a reward actor state in the layout of an actors version.
+func DecodeEstimates(av actors.Version, r io.Reader) (Estimates, error) {
+	if av == actors.Version0 {
+		var st StateV0
+		if err := st.UnmarshalCBOR(r); err != nil {
+			return Estimates{}, xerrors.Errorf("failed to decode reward state: %w", err)
+		}
+		return st.Estimates()
+	}
+	var st State
+	if err := st.UnmarshalCBOR(r); err != nil {
+		return Estimates{}, xerrors.Errorf("failed to decode reward state: %w", err)
+	}
+	return st.Estimates(), nil
+}
+
+// ExpectedDayReward returns the block reward a sector with some quality-adjusted power is expected to earn
+// over the next day, given the power actor's smoothed estimate of network quality-adjusted power.
+// This is the "day reward" the miner actor records for a sector at activation.
+func (e Estimates) ExpectedDayReward(networkQAPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower) abi.TokenAmount {
+	return miner.ExpectedRewardForPower(e.ThisEpochRewardSmoothed, networkQAPowerEstimate, qaSectorPower, builtin.EpochsInDay)
+}
+
+// ExpectedReward returns the block reward a sector with some quality-adjusted power is expected to earn over
+// some number of days.
+func (e Estimates) ExpectedReward(networkQAPowerEstimate smoothing.FilterEstimate, qaSectorPower abi.StoragePower, days int64) abi.TokenAmount {
+	return miner.ExpectedRewardForPower(e.ThisEpochRewardSmoothed, networkQAPowerEstimate, qaSectorPower, abi.ChainEpoch(days)*builtin.EpochsInDay)
+}
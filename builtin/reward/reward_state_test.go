@@ -0,0 +1,77 @@
+package reward_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/builtin/reward"
+	"github.com/filecoin-project/go-state-types/builtin/smoothing"
+)
+
+func TestDecodeEstimates(t *testing.T) {
+	smoothed := smoothing.NewEstimate(big.NewInt(5e18), big.Zero())
+	st := reward.State{
+		CumsumBaseline:          big.NewInt(1),
+		CumsumRealized:          big.NewInt(2),
+		EffectiveNetworkTime:    3,
+		EffectiveBaselinePower:  big.NewInt(4),
+		ThisEpochReward:         big.NewInt(5e18),
+		ThisEpochRewardSmoothed: smoothed,
+		ThisEpochBaselinePower:  big.NewInt(5),
+		Epoch:                   1000,
+		TotalStoragePowerReward: big.NewInt(6),
+		SimpleTotal:             big.NewInt(7),
+		BaselineTotal:           big.NewInt(8),
+	}
+	var buf bytes.Buffer
+	require.NoError(t, st.MarshalCBOR(&buf))
+	e, err := reward.DecodeEstimates(actors.Version12, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, st.Estimates(), e)
+	assert.Equal(t, abi.ChainEpoch(1000), e.Epoch)
+
+	v0 := reward.StateV0{
+		CumsumBaseline:         big.NewInt(1),
+		CumsumRealized:         big.NewInt(2),
+		EffectiveBaselinePower: big.NewInt(4),
+		ThisEpochReward:        big.NewInt(5e18),
+		ThisEpochBaselinePower: big.NewInt(5),
+		Epoch:                  10,
+		TotalMined:             big.NewInt(6),
+	}
+	buf.Reset()
+	require.NoError(t, v0.MarshalCBOR(&buf))
+	_, err = reward.DecodeEstimates(actors.Version0, bytes.NewReader(buf.Bytes()))
+	assert.Error(t, err)
+
+	v0.ThisEpochRewardSmoothed = &smoothed
+	buf.Reset()
+	require.NoError(t, v0.MarshalCBOR(&buf))
+	e, err = reward.DecodeEstimates(actors.Version0, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	assert.Equal(t, smoothed, e.ThisEpochRewardSmoothed)
+
+	// A v0 state does not decode in the later layout.
+	_, err = reward.DecodeEstimates(actors.Version2, bytes.NewReader(buf.Bytes()))
+	assert.Error(t, err)
+}
+
+func TestExpectedReward(t *testing.T) {
+	// Constant reward of 5 FIL per epoch shared by a constant network power of 1 EiB.
+	e := reward.Estimates{ThisEpochRewardSmoothed: smoothing.NewEstimate(big.NewInt(5e18), big.Zero())}
+	networkPower := smoothing.NewEstimate(big.NewInt(1<<60), big.Zero())
+	sectorPower := abi.NewStoragePower(32 << 30)
+
+	day := e.ExpectedDayReward(networkPower, sectorPower)
+	assert.Equal(t, big.Div(big.Product(big.NewInt(5e18), big.NewInt(2880), sectorPower), big.NewInt(1<<60)), day)
+	assert.Equal(t, miner.ExpectedRewardForPower(e.ThisEpochRewardSmoothed, networkPower, sectorPower, 180*builtin.EpochsInDay),
+		e.ExpectedReward(networkPower, sectorPower, 180))
+}
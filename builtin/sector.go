@@ -0,0 +1,13 @@
+package builtin
+
+import "github.com/filecoin-project/go-state-types/abi"
+
+// Quality multipliers for committed capacity, unverified deals and verified deals, as defined in abi.
+const (
+	QualityBaseMultiplier        = abi.QualityBaseMultiplier
+	DealWeightMultiplier         = abi.DealWeightMultiplier
+	VerifiedDealWeightMultiplier = abi.VerifiedDealWeightMultiplier
+)
+
+// Precision used for making quality-adjusted power calculations, as defined in abi.
+const SectorQualityPrecision = abi.SectorQualityPrecision
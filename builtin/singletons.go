@@ -0,0 +1,43 @@
+package builtin
+
+import (
+	addr "github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// IDs of the singleton builtin actors.
+const (
+	SystemActorID                 abi.ActorID = 0
+	InitActorID                   abi.ActorID = 1
+	RewardActorID                 abi.ActorID = 2
+	CronActorID                   abi.ActorID = 3
+	StoragePowerActorID           abi.ActorID = 4
+	StorageMarketActorID          abi.ActorID = 5
+	VerifiedRegistryActorID       abi.ActorID = 6
+	DatacapActorID                abi.ActorID = 7
+	EthereumAddressManagerActorID abi.ActorID = 10
+	BurntFundsActorID             abi.ActorID = 99
+)
+
+// Addresses of the singleton builtin actors.
+var (
+	SystemActorAddr                 = mustMakeAddress(SystemActorID)
+	InitActorAddr                   = mustMakeAddress(InitActorID)
+	RewardActorAddr                 = mustMakeAddress(RewardActorID)
+	CronActorAddr                   = mustMakeAddress(CronActorID)
+	StoragePowerActorAddr           = mustMakeAddress(StoragePowerActorID)
+	StorageMarketActorAddr          = mustMakeAddress(StorageMarketActorID)
+	VerifiedRegistryActorAddr       = mustMakeAddress(VerifiedRegistryActorID)
+	DatacapActorAddr                = mustMakeAddress(DatacapActorID)
+	EthereumAddressManagerActorAddr = mustMakeAddress(EthereumAddressManagerActorID)
+	BurntFundsActorAddr             = mustMakeAddress(BurntFundsActorID)
+)
+
+func mustMakeAddress(id abi.ActorID) addr.Address {
+	a, err := addr.NewIDAddress(uint64(id))
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
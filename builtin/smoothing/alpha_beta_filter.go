@@ -0,0 +1,96 @@
+package smoothing
+
+import (
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/math"
+)
+
+// The reward and power actors track the network's per-epoch reward and total quality-adjusted power with an
+// alpha-beta filter, smoothing out short-term noise. Other actors project the filtered estimates forward to
+// price deposits and penalties.
+
+// A filter's estimate of a value and its rate of change per epoch.
+type FilterEstimate struct {
+	PositionEstimate big.Int // Q.128
+	VelocityEstimate big.Int // Q.128
+}
+
+// Values of the squared velocity below this threshold (2^-50 in Q.128) are treated as zero when extrapolating.
+var extrapolatedCumSumRatioEpsilon = big.NewFromGo(math.Parse([]string{"302231454903657293676544"})[0])
+
+// NewEstimate returns an estimate with integral (Q.0) position and velocity.
+func NewEstimate(position, velocity big.Int) FilterEstimate {
+	return FilterEstimate{
+		PositionEstimate: big.Lsh(position, math.Precision128), // Q.0 => Q.128
+		VelocityEstimate: big.Lsh(velocity, math.Precision128), // Q.0 => Q.128
+	}
+}
+
+// Estimate returns the integral part of the position estimate.
+func (fe *FilterEstimate) Estimate() big.Int {
+	return big.Rsh(fe.PositionEstimate, math.Precision128) // Q.128 => Q.0
+}
+
+// Extrapolate returns the estimate linearly extrapolated delta epochs ahead, with the same velocity.
+func (fe *FilterEstimate) Extrapolate(delta abi.ChainEpoch) FilterEstimate {
+	deltaT := big.Lsh(big.NewInt(int64(delta)), math.Precision128) // Q.0 => Q.128
+	extrapolation := big.Mul(fe.VelocityEstimate, deltaT)          // Q.128 * Q.128 => Q.256
+	extrapolation = big.Rsh(extrapolation, math.Precision128)      // Q.256 => Q.128
+	return FilterEstimate{
+		PositionEstimate: big.Sum(fe.PositionEstimate, extrapolation),
+		VelocityEstimate: fe.VelocityEstimate,
+	}
+}
+
+// ExtrapolatedCumSumOfRatio extrapolates the sum over delta epochs, from relativeStart epochs after the
+// estimates were made, of the ratio of two linearly extrapolated estimates. The result is Q.128.
+// For the cumulative reward per unit of power, for example, the numerator is the reward estimate and the
+// denominator the network power estimate.
+func ExtrapolatedCumSumOfRatio(delta abi.ChainEpoch, relativeStart abi.ChainEpoch, estimateNum, estimateDenom FilterEstimate) big.Int {
+	deltaT := big.Lsh(big.NewInt(int64(delta)), math.Precision128)     // Q.0 => Q.128
+	t0 := big.Lsh(big.NewInt(int64(relativeStart)), math.Precision128) // Q.0 => Q.128
+	// Renaming for ease of following spec and clarity
+	position1 := estimateNum.PositionEstimate
+	position2 := estimateDenom.PositionEstimate
+	velocity1 := estimateNum.VelocityEstimate
+	velocity2 := estimateDenom.VelocityEstimate
+
+	squaredVelocity2 := big.Mul(velocity2, velocity2)               // Q.128 * Q.128 => Q.256
+	squaredVelocity2 = big.Rsh(squaredVelocity2, math.Precision128) // Q.256 => Q.128
+
+	if squaredVelocity2.GreaterThan(extrapolatedCumSumRatioEpsilon) {
+		x2a := big.Mul(t0, velocity2)         // Q.128 * Q.128 => Q.256
+		x2a = big.Rsh(x2a, math.Precision128) // Q.256 => Q.128
+		x2a = big.Sum(position2, x2a)
+
+		x2b := big.Mul(deltaT, velocity2)     // Q.128 * Q.128 => Q.256
+		x2b = big.Rsh(x2b, math.Precision128) // Q.256 => Q.128
+		x2b = big.Sum(x2a, x2b)
+
+		x2a = math.Ln(x2a) // Q.128
+		x2b = math.Ln(x2b) // Q.128
+
+		m1 := big.Sub(x2b, x2a)
+		m1 = big.Mul(velocity2, big.Mul(position1, m1)) // Q.128 * Q.128 * Q.128 => Q.384
+		m1 = big.Rsh(m1, math.Precision128)             // Q.384 => Q.256
+
+		m2L := big.Sub(x2a, x2b)
+		m2L = big.Mul(position2, m2L)     // Q.128 * Q.128 => Q.256
+		m2R := big.Mul(velocity2, deltaT) // Q.128 * Q.128 => Q.256
+		m2 := big.Sum(m2L, m2R)
+		m2 = big.Mul(velocity1, m2)         // Q.256 => Q.384
+		m2 = big.Rsh(m2, math.Precision128) // Q.384 => Q.256
+
+		return big.Div(big.Sum(m1, m2), squaredVelocity2) // Q.256 / Q.128 => Q.128
+	}
+
+	halfDeltaT := big.Rsh(deltaT, 1)                   // Q.128 / Q.0 => Q.128
+	x1m := big.Mul(velocity1, big.Sum(t0, halfDeltaT)) // Q.128 * Q.128 => Q.256
+	x1m = big.Rsh(x1m, math.Precision128)              // Q.256 => Q.128
+	x1m = big.Add(position1, x1m)
+
+	cumsumRatio := big.Mul(x1m, deltaT)           // Q.128 * Q.128 => Q.256
+	cumsumRatio = big.Div(cumsumRatio, position2) // Q.256 / Q.128 => Q.128
+	return cumsumRatio
+}
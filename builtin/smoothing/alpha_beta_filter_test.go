@@ -0,0 +1,31 @@
+package smoothing_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/smoothing"
+	"github.com/filecoin-project/go-state-types/math"
+)
+
+func TestExtrapolatedCumSumOfRatio(t *testing.T) {
+	// With constant estimates, the ratio is constant.
+	num := smoothing.NewEstimate(big.NewInt(6), big.Zero())
+	denom := smoothing.NewEstimate(big.NewInt(3), big.Zero())
+	assert.Equal(t, big.NewInt(6), num.Estimate())
+	sum := smoothing.ExtrapolatedCumSumOfRatio(10, 0, num, denom)
+	assert.Equal(t, big.Lsh(big.NewInt(20), math.Precision128), sum)
+
+	// A linearly growing numerator sums to its value at the midpoint.
+	num = smoothing.NewEstimate(big.NewInt(6), big.NewInt(3))
+	sum = smoothing.ExtrapolatedCumSumOfRatio(10, 0, num, denom)
+	assert.Equal(t, big.Lsh(big.NewInt(10*(6+15)/3), math.Precision128), sum)
+
+	// A growing denominator reduces the sum.
+	denom = smoothing.NewEstimate(big.NewInt(3), big.NewInt(1))
+	grown := smoothing.ExtrapolatedCumSumOfRatio(10, 0, num, denom)
+	assert.True(t, grown.LessThan(sum))
+	assert.True(t, grown.GreaterThan(big.Zero()))
+}
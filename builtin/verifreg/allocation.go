@@ -0,0 +1,120 @@
+package verifreg
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sort"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/adt"
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+// Allocation is a client's allocation of data cap to a provider for some data. The provider may claim it by
+// committing the data in a sector before the allocation expires.
+type Allocation struct {
+	// The verified client which allocated the data cap.
+	Client abi.ActorID
+	// The provider (miner actor) which may claim the allocation.
+	Provider abi.ActorID
+	// Identifier of the data to be committed.
+	Data cid.Cid
+	// The (padded) size of the data.
+	Size abi.PaddedPieceSize
+	// The minimum duration which the provider must commit to storing the piece to avoid early-termination
+	// penalties (epochs).
+	TermMin abi.ChainEpoch
+	// The maximum period for which a provider can earn quality-adjusted power for the piece (epochs).
+	TermMax abi.ChainEpoch
+	// The latest epoch by which a provider must commit data before the allocation expires.
+	Expiration abi.ChainEpoch
+}
+
+// Term returns the term of the allocation.
+func (a *Allocation) Term() abi.ClaimTerm {
+	return abi.ClaimTerm{TermMin: a.TermMin, TermMax: a.TermMax, Expiration: a.Expiration}
+}
+
+// AllocationEntry is an allocation with its identifier.
+type AllocationEntry struct {
+	ID AllocationId
+	Allocation
+}
+
+// IndexKey returns a compact key for the allocation, ordered by client and then allocation ID, for use in
+// off-chain indexes.
+func (e *AllocationEntry) IndexKey() string {
+	var id [8]byte
+	binary.BigEndian.PutUint64(id[:], uint64(e.ID))
+	return string(append(abi.AppendCompactActorIDKey(make([]byte, 0, 16), e.Client), id[:]...))
+}
+
+// ForEachAllocation calls cb for each allocation in the verified registry's allocations, given the root of its
+// HAMT of clients to HAMTs of allocations. Clients and their allocations are visited in HAMT order.
+func ForEachAllocation(ctx context.Context, store ipldcbor.IpldStore, allocations cid.Cid, cb func(id AllocationId, a *Allocation) error) error {
+	return adt.ForEachHamtEntry(ctx, store, allocations, func(k, v []byte) error {
+		client, err := parseActorIDKey(k)
+		if err != nil {
+			return xerrors.Errorf("invalid client key %x: %w", k, err)
+		}
+		inner, err := cbg.ReadCid(bytes.NewReader(v))
+		if err != nil {
+			return xerrors.Errorf("invalid allocations root for client %d: %w", client, err)
+		}
+		return adt.ForEachHamtEntry(ctx, store, inner, func(k, v []byte) error {
+			id, n := binary.Uvarint(k)
+			if n <= 0 || n != len(k) {
+				return xerrors.Errorf("invalid allocation key %x of client %d", k, client)
+			}
+			var a Allocation
+			if _, err := cbor.Unmarshal(v, &a); err != nil {
+				return xerrors.Errorf("failed to decode allocation %d of client %d: %w", id, client, err)
+			}
+			return cb(AllocationId(id), &a)
+		})
+	})
+}
+
+// AllocationsExpiringBetween returns the allocations, across all clients, that expire in the epoch range
+// [from, to), ordered by expiration and then by ID.
+func AllocationsExpiringBetween(ctx context.Context, store ipldcbor.IpldStore, allocations cid.Cid, from, to abi.ChainEpoch) ([]AllocationEntry, error) {
+	var out []AllocationEntry
+	if err := ForEachAllocation(ctx, store, allocations, func(id AllocationId, a *Allocation) error {
+		if a.Expiration >= from && a.Expiration < to {
+			out = append(out, AllocationEntry{ID: id, Allocation: *a})
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Expiration != out[j].Expiration {
+			return out[i].Expiration < out[j].Expiration
+		}
+		return out[i].ID < out[j].ID
+	})
+	return out, nil
+}
+
+// Parses a HAMT key identifying an actor: either the bytes of its ID address or its ID as an unsigned varint.
+func parseActorIDKey(k []byte) (abi.ActorID, error) {
+	if len(k) > 0 && k[0] == byte(addr.ID) {
+		a, err := addr.NewFromBytes(k)
+		if err == nil {
+			id, err := addr.IDFromAddress(a)
+			return abi.ActorID(id), err
+		}
+	}
+	id, n := binary.Uvarint(k)
+	if n <= 0 || n != len(k) {
+		return 0, xerrors.Errorf("not an ID address or varint")
+	}
+	return abi.ActorID(id), nil
+}
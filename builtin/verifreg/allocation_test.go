@@ -0,0 +1,65 @@
+package verifreg_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin/verifreg"
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+func TestAllocationsExpiringBetween(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+	data := ipld.PutBlock(t, store, []byte{0x60})
+	alloc := func(client abi.ActorID, expiration abi.ChainEpoch) verifreg.Allocation {
+		return verifreg.Allocation{Client: client, Provider: 1000, Data: data, Size: 2048, TermMin: 100, TermMax: 200, Expiration: expiration}
+	}
+
+	a1, a2, a3 := alloc(101, 50), alloc(101, 20), alloc(102, 30)
+	client101 := ipld.PutBlock(t, store, ipld.HamtBucketNode(allocationEntry(t, 1, a1), allocationEntry(t, 2, a2)))
+	client102 := ipld.PutBlock(t, store, ipld.HamtBucketNode(allocationEntry(t, 7, a3)))
+	root := ipld.PutBlock(t, store, ipld.HamtBucketNode(clientEntry(t, 101, client101), clientEntry(t, 102, client102)))
+
+	var ids []verifreg.AllocationId
+	require.NoError(t, verifreg.ForEachAllocation(ctx, store, root, func(id verifreg.AllocationId, a *verifreg.Allocation) error {
+		ids = append(ids, id)
+		return nil
+	}))
+	assert.Equal(t, []verifreg.AllocationId{1, 2, 7}, ids)
+
+	expiring, err := verifreg.AllocationsExpiringBetween(ctx, store, root, 20, 50)
+	require.NoError(t, err)
+	assert.Equal(t, []verifreg.AllocationEntry{{ID: 2, Allocation: a2}, {ID: 7, Allocation: a3}}, expiring)
+
+	expiring, err = verifreg.AllocationsExpiringBetween(ctx, store, root, 51, 100)
+	require.NoError(t, err)
+	assert.Empty(t, expiring)
+}
+
+func TestAllocationIndexKey(t *testing.T) {
+	e := verifreg.AllocationEntry{ID: 7, Allocation: verifreg.Allocation{Client: 1000}}
+	assert.Equal(t, abi.CompactActorIDKey(1000).Key()+"\x00\x00\x00\x00\x00\x00\x00\x07", e.IndexKey())
+}
+
+func clientEntry(t *testing.T, client abi.ActorID, allocations cid.Cid) []byte {
+	a, err := addr.NewIDAddress(uint64(client))
+	require.NoError(t, err)
+	return ipld.HamtEntry(a.Bytes(), ipld.Link(allocations))
+}
+
+func allocationEntry(t *testing.T, id verifreg.AllocationId, a verifreg.Allocation) []byte {
+	key := make([]byte, binary.MaxVarintLen64)
+	key = key[:binary.PutUvarint(key, uint64(id))]
+	var value bytes.Buffer
+	require.NoError(t, a.MarshalCBOR(&value))
+	return ipld.HamtEntry(key, value.Bytes())
+}
@@ -0,0 +1,77 @@
+package verifreg
+
+import (
+	"bytes"
+
+	addr "github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+// DataCap is an integer number of bytes.
+// We can introduce policy changes and replace this in the future.
+type DataCap = abi.StoragePower
+
+// Identifies a data allocation to a provider, from a client's data cap.
+type AllocationId uint64
+
+// Identifies a claim of an allocation by a provider, made when the data is committed in a sector.
+type ClaimId uint64
+
+// Domain separation prefix for a verifier's signature authorising removal of data cap from a client.
+const SignatureDomainSeparation_RemoveDataCap = "fil_removedatacap:"
+
+// Identifies a data cap removal proposal between a verifier and a client.
+// The verified registry tracks the next expected ID for each (verifier, client) pair, which prevents replay
+// of a signed proposal.
+type RmDcProposalID struct {
+	ProposalID uint64
+}
+
+// A proposal to remove data cap from a client, to be signed by a verifier.
+type RemoveDataCapProposal struct {
+	VerifiedClient    addr.Address
+	DataCapAmount     DataCap
+	RemovalProposalID RmDcProposalID
+}
+
+// NewRemoveDataCapProposal returns a proposal to remove an amount of data cap from a client.
+// The proposal ID must be the next ID expected by the verified registry for the verifier and client.
+func NewRemoveDataCapProposal(client addr.Address, amount DataCap, proposalID uint64) *RemoveDataCapProposal {
+	return &RemoveDataCapProposal{
+		VerifiedClient:    client,
+		DataCapAmount:     amount,
+		RemovalProposalID: RmDcProposalID{ProposalID: proposalID},
+	}
+}
+
+// SigningPayload returns the bytes a verifier signs to authorise the proposal: the domain separation
+// prefix followed by the CBOR serialization of the proposal.
+func (p *RemoveDataCapProposal) SigningPayload() ([]byte, error) {
+	buf := bytes.NewBufferString(SignatureDomainSeparation_RemoveDataCap)
+	if err := p.MarshalCBOR(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// A verifier's authorisation of a data cap removal proposal.
+type RemoveDataCapRequest struct {
+	Verifier          addr.Address
+	VerifierSignature crypto.Signature
+}
+
+// Parameters to the verified registry's RemoveVerifiedClientDataCap method.
+// Removal requires the approval of two distinct verifiers.
+type RemoveDataCapParams struct {
+	VerifiedClientToRemove addr.Address
+	DataCapAmountToRemove  DataCap
+	VerifierRequest1       RemoveDataCapRequest
+	VerifierRequest2       RemoveDataCapRequest
+}
+
+type RemoveDataCapReturn struct {
+	VerifiedClient addr.Address
+	DataCapRemoved DataCap
+}
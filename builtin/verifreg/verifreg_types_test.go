@@ -0,0 +1,60 @@
+package verifreg_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	addr "github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin/verifreg"
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+func TestRemoveDataCapSigningPayload(t *testing.T) {
+	client, err := addr.NewIDAddress(101)
+	require.NoError(t, err)
+
+	proposal := verifreg.NewRemoveDataCapProposal(client, big.NewInt(1024), 7)
+	payload, err := proposal.SigningPayload()
+	require.NoError(t, err)
+
+	expected := append([]byte("fil_removedatacap:"),
+		0x83,             // array(3)
+		0x42, 0x00, 0x65, // bytes(2): ID address 101
+		0x43, 0x00, 0x04, 0x00, // bytes(3): big int 1024
+		0x81, 0x07, // array(1): proposal ID 7
+	)
+	assert.Equal(t, expected, payload)
+}
+
+func TestRemoveDataCapParamsRoundTrip(t *testing.T) {
+	client, err := addr.NewIDAddress(101)
+	require.NoError(t, err)
+	verifier1, err := addr.NewIDAddress(102)
+	require.NoError(t, err)
+	verifier2, err := addr.NewIDAddress(103)
+	require.NoError(t, err)
+
+	params := verifreg.RemoveDataCapParams{
+		VerifiedClientToRemove: client,
+		DataCapAmountToRemove:  big.NewInt(1 << 40),
+		VerifierRequest1: verifreg.RemoveDataCapRequest{
+			Verifier:          verifier1,
+			VerifierSignature: crypto.Signature{Type: crypto.SigTypeSecp256k1, Data: []byte{1, 2, 3}},
+		},
+		VerifierRequest2: verifreg.RemoveDataCapRequest{
+			Verifier:          verifier2,
+			VerifierSignature: crypto.Signature{Type: crypto.SigTypeBLS, Data: []byte{4, 5, 6}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, params.MarshalCBOR(&buf))
+	var out verifreg.RemoveDataCapParams
+	require.NoError(t, out.UnmarshalCBOR(&buf))
+	assert.Equal(t, params, out)
+}
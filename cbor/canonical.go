@@ -0,0 +1,275 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"unicode/utf8"
+)
+
+// Maximum nesting depth of arrays, maps and tags accepted by IsCanonical.
+const MaxCanonicalDepth = 64
+
+// The CBOR tag for an IPLD link (CID).
+const cidTag = 42
+
+// CBOR major types.
+const (
+	majUnsignedInt = 0
+	majNegativeInt = 1
+	majByteString  = 2
+	majTextString  = 3
+	majArray       = 4
+	majMap         = 5
+	majTag         = 6
+	majOther       = 7
+)
+
+// IsCanonical checks that data is exactly one item in canonical DAG-CBOR form, returning an error
+// describing the first violation found. In particular:
+//   - integers and lengths use the shortest possible encoding
+//   - there are no indefinite-length items
+//   - map keys are text strings, unique, and sorted by length then bytewise
+//   - text strings are valid UTF-8
+//   - the only tag is 42 (a CID), wrapping a byte string with the identity multibase prefix
+//   - floats are 64-bit and finite, and the only simple values are false, true and null
+//   - there are no trailing bytes
+func IsCanonical(data []byte) error {
+	c := canonicalChecker{data: data}
+	if err := c.checkItem(0); err != nil {
+		return err
+	}
+	if c.pos != len(data) {
+		return fmt.Errorf("%d trailing bytes after item at offset %d", len(data)-c.pos, c.pos)
+	}
+	return nil
+}
+
+type canonicalChecker struct {
+	data []byte
+	pos  int
+}
+
+func (c *canonicalChecker) errorf(offset int, format string, args ...interface{}) error {
+	return fmt.Errorf("non-canonical cbor at offset %d: %s", offset, fmt.Sprintf(format, args...))
+}
+
+// Reads an item header, checking the argument is minimally encoded and definite.
+func (c *canonicalChecker) readHeader() (byte, uint64, error) {
+	start := c.pos
+	if c.pos >= len(c.data) {
+		return 0, 0, c.errorf(start, "unexpected end of data")
+	}
+	first := c.data[c.pos]
+	c.pos++
+	maj := first >> 5
+	info := first & 0x1f
+
+	var size int
+	switch {
+	case info < 24:
+		return maj, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	case info == 31:
+		return 0, 0, c.errorf(start, "indefinite-length item")
+	default:
+		return 0, 0, c.errorf(start, "reserved additional information %d", info)
+	}
+	if len(c.data)-c.pos < size {
+		return 0, 0, c.errorf(start, "unexpected end of data")
+	}
+	buf := c.data[c.pos : c.pos+size]
+	c.pos += size
+
+	var val uint64
+	var min uint64
+	switch size {
+	case 1:
+		val, min = uint64(buf[0]), 24
+	case 2:
+		val, min = uint64(binary.BigEndian.Uint16(buf)), math.MaxUint8+1
+	case 4:
+		val, min = uint64(binary.BigEndian.Uint32(buf)), math.MaxUint16+1
+	case 8:
+		val, min = binary.BigEndian.Uint64(buf), math.MaxUint32+1
+	}
+	if val < min {
+		return 0, 0, c.errorf(start, "value %d not minimally encoded", val)
+	}
+	return maj, val, nil
+}
+
+// Reads the content of a byte or text string of length n.
+func (c *canonicalChecker) readBytes(start int, n uint64) ([]byte, error) {
+	if n > uint64(len(c.data)-c.pos) {
+		return nil, c.errorf(start, "string length %d exceeds remaining data", n)
+	}
+	b := c.data[c.pos : c.pos+int(n)]
+	c.pos += int(n)
+	return b, nil
+}
+
+func (c *canonicalChecker) checkItem(depth int) error {
+	if depth > MaxCanonicalDepth {
+		return c.errorf(c.pos, "nesting exceeds maximum depth %d", MaxCanonicalDepth)
+	}
+
+	// Floats and simple values share major type 7, and are not subject to the integer rules of readHeader.
+	if c.pos < len(c.data) && c.data[c.pos]>>5 == majOther {
+		return c.checkFloatOrSimple()
+	}
+
+	start := c.pos
+	maj, extra, err := c.readHeader()
+	if err != nil {
+		return err
+	}
+
+	switch maj {
+	case majUnsignedInt, majNegativeInt:
+		return nil
+	case majByteString:
+		_, err := c.readBytes(start, extra)
+		return err
+	case majTextString:
+		s, err := c.readBytes(start, extra)
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(s) {
+			return c.errorf(start, "text string is not valid UTF-8")
+		}
+		return nil
+	case majArray:
+		// Every item takes at least one byte.
+		if extra > uint64(len(c.data)-c.pos) {
+			return c.errorf(start, "array length %d exceeds remaining data", extra)
+		}
+		for i := uint64(0); i < extra; i++ {
+			if err := c.checkItem(depth + 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case majMap:
+		return c.checkMap(start, extra, depth)
+	case majTag:
+		if extra != cidTag {
+			return c.errorf(start, "tag %d not allowed", extra)
+		}
+		inner := c.pos
+		maj, n, err := c.readHeader()
+		if err != nil {
+			return err
+		}
+		if maj != majByteString {
+			return c.errorf(inner, "CID must be a byte string")
+		}
+		b, err := c.readBytes(inner, n)
+		if err != nil {
+			return err
+		}
+		if len(b) == 0 || b[0] != 0 {
+			return c.errorf(inner, "CID must have the identity multibase prefix")
+		}
+		return nil
+	default:
+		panic("unreachable")
+	}
+}
+
+// Checks an item of major type 7: a float, which must be 64-bit and finite, or a simple value, which must be
+// false, true or null.
+func (c *canonicalChecker) checkFloatOrSimple() error {
+	start := c.pos
+	info := c.data[c.pos] & 0x1f
+	c.pos++
+
+	switch info {
+	case 20, 21, 22:
+		return nil
+	case 25, 26:
+		return c.errorf(start, "floats must be 64-bit")
+	case 27:
+		if len(c.data)-c.pos < 8 {
+			return c.errorf(start, "unexpected end of data")
+		}
+		f := math.Float64frombits(binary.BigEndian.Uint64(c.data[c.pos:]))
+		c.pos += 8
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return c.errorf(start, "non-finite float")
+		}
+		return nil
+	case 24:
+		if c.pos >= len(c.data) {
+			return c.errorf(start, "unexpected end of data")
+		}
+		return c.errorf(start, "simple value %d not allowed", c.data[c.pos])
+	case 31:
+		return c.errorf(start, "unexpected break")
+	default:
+		if info < 24 {
+			return c.errorf(start, "simple value %d not allowed", info)
+		}
+		return c.errorf(start, "reserved additional information %d", info)
+	}
+}
+
+func (c *canonicalChecker) checkMap(start int, n uint64, depth int) error {
+	// Every entry takes at least two bytes.
+	if n > uint64(len(c.data)-c.pos)/2 {
+		return c.errorf(start, "map length %d exceeds remaining data", n)
+	}
+
+	var prev []byte
+	for i := uint64(0); i < n; i++ {
+		keyStart := c.pos
+		maj, extra, err := c.readHeader()
+		if err != nil {
+			return err
+		}
+		if maj != majTextString {
+			return c.errorf(keyStart, "map keys must be text strings")
+		}
+		key, err := c.readBytes(keyStart, extra)
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(key) {
+			return c.errorf(keyStart, "map key is not valid UTF-8")
+		}
+		if i > 0 {
+			switch cmpMapKeys(prev, key) {
+			case 0:
+				return c.errorf(keyStart, "duplicate map key %q", key)
+			case 1:
+				return c.errorf(keyStart, "map key %q out of order", key)
+			}
+		}
+		prev = key
+
+		if err := c.checkItem(depth + 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compares map keys in canonical order: shorter keys first, then bytewise.
+func cmpMapKeys(a, b []byte) int {
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return bytes.Compare(a, b)
+}
@@ -0,0 +1,82 @@
+package cbor_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+func TestIsCanonical(t *testing.T) {
+	c, err := abi.CidBuilder.Sum([]byte("data"))
+	assert.NoError(t, err)
+	var cidBuf bytes.Buffer
+	assert.NoError(t, cbg.WriteCid(&cidBuf, c))
+
+	var piece bytes.Buffer
+	info := abi.PieceInfo{Size: 1 << 20, PieceCID: c}
+	assert.NoError(t, info.MarshalCBOR(&piece))
+
+	valid := map[string][]byte{
+		"zero":          {0x00},
+		"small uint":    {0x17},
+		"uint8":         {0x18, 0x18},
+		"uint16":        {0x19, 0x01, 0x00},
+		"negative":      {0x38, 0xff},
+		"bytes":         {0x43, 1, 2, 3},
+		"text":          {0x62, 'h', 'i'},
+		"array":         {0x82, 0x01, 0xf6},
+		"map":           {0xa3, 0x61, 'b', 0x01, 0x61, 'c', 0x02, 0x62, 'a', 'a', 0x03},
+		"float64":       {0xfb, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0},
+		"float64 zero":  {0xfb, 0, 0, 0, 0, 0, 0, 0, 0},
+		"float64 tiny":  {0xfb, 0, 0, 0, 0, 0, 0, 0, 0x14},
+		"bools":         {0x82, 0xf4, 0xf5},
+		"cid":           cidBuf.Bytes(),
+		"generated":     piece.Bytes(),
+		"empty map":     {0xa0},
+		"nested arrays": {0x81, 0x81, 0x80},
+	}
+	for name, data := range valid {
+		assert.NoError(t, cbor.IsCanonical(data), name)
+	}
+
+	invalid := map[string][]byte{
+		"empty":              {},
+		"non-minimal uint8":  {0x18, 0x17},
+		"non-minimal uint16": {0x19, 0x00, 0xff},
+		"non-minimal uint32": {0x1a, 0x00, 0x00, 0xff, 0xff},
+		"non-minimal length": {0x58, 0x01, 0x00},
+		"indefinite array":   {0x9f, 0x01, 0xff},
+		"indefinite bytes":   {0x5f, 0x41, 0x00, 0xff},
+		"unsorted keys":      {0xa2, 0x61, 'b', 0x01, 0x61, 'a', 0x02},
+		"length before byte": {0xa2, 0x62, 'a', 'a', 0x01, 0x61, 'b', 0x02},
+		"duplicate keys":     {0xa2, 0x61, 'a', 0x01, 0x61, 'a', 0x02},
+		"integer key":        {0xa1, 0x01, 0x01},
+		"invalid utf8":       {0x61, 0xff},
+		"other tag":          {0xc1, 0x01},
+		"cid without prefix": {0xd8, 0x2a, 0x41, 0x01},
+		"float32":            {0xfa, 0x3f, 0x80, 0x00, 0x00},
+		"float16":            {0xf9, 0x3c, 0x00},
+		"NaN":                {0xfb, 0x7f, 0xf8, 0, 0, 0, 0, 0, 0},
+		"infinity":           {0xfb, 0x7f, 0xf0, 0, 0, 0, 0, 0, 0},
+		"truncated float64":  {0xfb, 0x3f, 0xf0},
+		"simple value 0":     {0xe0},
+		"one-byte simple":    {0xf8, 0x20},
+		"break":              {0xff},
+		"undefined":          {0xf7},
+		"trailing bytes":     {0x01, 0x02},
+		"truncated string":   {0x43, 1, 2},
+		"truncated array":    {0x82, 0x01},
+		"huge array":         {0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+	}
+	for name, data := range invalid {
+		assert.Error(t, cbor.IsCanonical(data), name)
+	}
+
+	deep := bytes.Repeat([]byte{0x81}, cbor.MaxCanonicalDepth+1)
+	assert.Error(t, cbor.IsCanonical(append(deep, 0x00)))
+}
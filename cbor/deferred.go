@@ -0,0 +1,208 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// The maximum encoded size of a Deferred read by UnmarshalCBOR, unless overridden by its MaxSize.
+const DefaultDeferredMaxSize = 1 << 20
+
+// Maximum nesting depth of a Deferred item.
+const maxDeferredDepth = MaxCanonicalDepth
+
+// Deferred holds the raw encoding of a single CBOR item, to be decoded only if and when needed.
+// Message parameters and actor state heads, for example, are often passed through without being inspected.
+//
+// The result of Decode is cached, so a Deferred decoded repeatedly into the same type decodes only once.
+// A Deferred must not be copied after first use, and Raw must not be modified after a call to Decode.
+type Deferred struct {
+	// The raw encoding of the item.
+	Raw []byte
+	// Maximum encoded size accepted by UnmarshalCBOR. If zero, DefaultDeferredMaxSize applies.
+	MaxSize int
+
+	lk     sync.Mutex
+	cached reflect.Value // Pointer to the most recently decoded value, if any.
+}
+
+var _ Er = (*Deferred)(nil)
+
+// NewDeferred returns a Deferred holding the encoding of v.
+func NewDeferred(v Marshaler) (*Deferred, error) {
+	raw, err := Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return &Deferred{Raw: raw}, nil
+}
+
+// MarshalCBOR writes the raw encoding, or a CBOR null if there is none.
+func (d *Deferred) MarshalCBOR(w io.Writer) error {
+	if d == nil || d.Raw == nil {
+		_, err := w.Write([]byte{0xf6})
+		return err
+	}
+	_, err := w.Write(d.Raw)
+	return err
+}
+
+// UnmarshalCBOR reads a single CBOR item, up to the maximum size, without decoding it.
+func (d *Deferred) UnmarshalCBOR(r io.Reader) error {
+	maxSize := d.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultDeferredMaxSize
+	}
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		// Read a byte at a time so as not to consume input beyond the item.
+		br = &byteReader{r: r}
+	}
+	s := itemScanner{r: br, remaining: maxSize}
+	if err := s.scanItem(0); err != nil {
+		return err
+	}
+
+	d.lk.Lock()
+	defer d.lk.Unlock()
+	d.Raw = s.buf.Bytes()
+	d.cached = reflect.Value{}
+	return nil
+}
+
+// Decode decodes the raw encoding into out, which must be a non-nil pointer.
+// If the Deferred was previously decoded into a value of the same type, out is set to a (shallow) copy of
+// that value, sharing any slices, maps or pointers with it.
+func (d *Deferred) Decode(out Unmarshaler) error {
+	outV := reflect.ValueOf(out)
+	if outV.Kind() != reflect.Ptr || outV.IsNil() {
+		return fmt.Errorf("decode target must be a non-nil pointer, got %T", out)
+	}
+
+	d.lk.Lock()
+	defer d.lk.Unlock()
+	if d.cached.IsValid() && d.cached.Type() == outV.Type() {
+		outV.Elem().Set(d.cached.Elem())
+		return nil
+	}
+	if err := out.UnmarshalCBOR(bytes.NewReader(d.Raw)); err != nil {
+		return err
+	}
+	cached := reflect.New(outV.Type().Elem())
+	cached.Elem().Set(outV.Elem())
+	d.cached = cached
+	return nil
+}
+
+// Scans a single CBOR item from a reader, copying its bytes to a buffer.
+type itemScanner struct {
+	r         io.ByteReader
+	buf       bytes.Buffer
+	remaining int
+}
+
+func (s *itemScanner) readByte() (byte, error) {
+	if s.remaining <= 0 {
+		return 0, fmt.Errorf("cbor item exceeds maximum size")
+	}
+	b, err := s.r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	s.remaining--
+	s.buf.WriteByte(b)
+	return b, nil
+}
+
+func (s *itemScanner) readHeader() (byte, uint64, error) {
+	first, err := s.readByte()
+	if err != nil {
+		return 0, 0, err
+	}
+	maj := first >> 5
+	info := first & 0x1f
+	var size int
+	switch {
+	case info < 24:
+		return maj, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	case info == 31:
+		return 0, 0, fmt.Errorf("indefinite-length cbor items are not supported")
+	default:
+		return 0, 0, fmt.Errorf("invalid cbor additional information %d", info)
+	}
+	var arg [8]byte
+	for i := 8 - size; i < 8; i++ {
+		if arg[i], err = s.readByte(); err != nil {
+			return 0, 0, err
+		}
+	}
+	return maj, binary.BigEndian.Uint64(arg[:]), nil
+}
+
+func (s *itemScanner) scanItem(depth int) error {
+	if depth > maxDeferredDepth {
+		return fmt.Errorf("cbor item nesting exceeds maximum depth %d", maxDeferredDepth)
+	}
+	maj, extra, err := s.readHeader()
+	if err != nil {
+		return err
+	}
+	switch maj {
+	case majUnsignedInt, majNegativeInt, majOther:
+		return nil
+	case majByteString, majTextString:
+		if extra > uint64(s.remaining) {
+			return fmt.Errorf("cbor item exceeds maximum size")
+		}
+		for i := uint64(0); i < extra; i++ {
+			if _, err := s.readByte(); err != nil {
+				return err
+			}
+		}
+		return nil
+	case majArray, majMap:
+		items := extra
+		if maj == majMap {
+			items *= 2
+		}
+		// Every item takes at least one byte.
+		if extra > uint64(s.remaining) || items > uint64(s.remaining) {
+			return fmt.Errorf("cbor item exceeds maximum size")
+		}
+		for i := uint64(0); i < items; i++ {
+			if err := s.scanItem(depth + 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case majTag:
+		return s.scanItem(depth + 1)
+	default:
+		panic("unreachable")
+	}
+}
+
+// Adapts a reader to io.ByteReader without buffering.
+type byteReader struct {
+	r   io.Reader
+	buf [1]byte
+}
+
+func (b *byteReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(b.r, b.buf[:]); err != nil {
+		return 0, err
+	}
+	return b.buf[0], nil
+}
@@ -0,0 +1,71 @@
+package cbor_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+// A value counting its decodes.
+type counted struct {
+	raw     []byte
+	decodes *int
+}
+
+func (c *counted) UnmarshalCBOR(r io.Reader) error {
+	*c.decodes++
+	var buf bytes.Buffer
+	_, err := buf.ReadFrom(r)
+	c.raw = buf.Bytes()
+	return err
+}
+
+func TestDeferred(t *testing.T) {
+	// [1, {"a": h'0102'}, 42(h'00')] followed by a trailing item.
+	item := []byte{0x83, 0x01, 0xa1, 0x61, 0x61, 0x42, 0x01, 0x02, 0xd8, 0x2a, 0x41, 0x00}
+	input := append(append([]byte{}, item...), 0x05)
+
+	r := bytes.NewReader(input)
+	var d cbor.Deferred
+	require.NoError(t, d.UnmarshalCBOR(r))
+	assert.Equal(t, item, d.Raw)
+	assert.Equal(t, 1, r.Len())
+
+	// Via a reader without ReadByte.
+	var d2 cbor.Deferred
+	require.NoError(t, d2.UnmarshalCBOR(io.MultiReader(bytes.NewReader(input))))
+	assert.Equal(t, item, d2.Raw)
+
+	var buf bytes.Buffer
+	require.NoError(t, d.MarshalCBOR(&buf))
+	assert.Equal(t, item, buf.Bytes())
+
+	decodes := 0
+	for i := 0; i < 3; i++ {
+		out := counted{decodes: &decodes}
+		require.NoError(t, d.Decode(&out))
+		assert.Equal(t, item, out.raw)
+	}
+	assert.Equal(t, 1, decodes)
+}
+
+func TestDeferredLimits(t *testing.T) {
+	d := cbor.Deferred{MaxSize: 4}
+	assert.Error(t, d.UnmarshalCBOR(bytes.NewReader([]byte{0x44, 1, 2, 3, 4})))
+	// A header claiming a huge array is rejected without reading it.
+	assert.Error(t, d.UnmarshalCBOR(bytes.NewReader([]byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff})))
+	// Indefinite lengths are rejected.
+	assert.Error(t, d.UnmarshalCBOR(bytes.NewReader([]byte{0x9f, 0xff})))
+	// Truncated input.
+	assert.Error(t, d.UnmarshalCBOR(bytes.NewReader([]byte{0x82, 0x01})))
+
+	var nilDeferred cbor.Deferred
+	var buf bytes.Buffer
+	require.NoError(t, nilDeferred.MarshalCBOR(&buf))
+	assert.Equal(t, []byte{0xf6}, buf.Bytes())
+}
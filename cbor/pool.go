@@ -0,0 +1,73 @@
+package cbor
+
+import (
+	"bytes"
+	"sync"
+)
+
+// Buffers larger than this are not returned to the pool, so that one large value does not pin memory.
+const maxPooledBufferSize = 64 << 10
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+var readerPool = sync.Pool{
+	New: func() interface{} {
+		return bytes.NewReader(nil)
+	},
+}
+
+// GetBuffer returns an empty buffer from the pool. It should be returned with PutBuffer once its contents
+// are no longer referenced.
+func GetBuffer() *bytes.Buffer {
+	buf := bufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	return buf
+}
+
+// PutBuffer returns a buffer to the pool, unless it has grown too large to keep.
+func PutBuffer(buf *bytes.Buffer) {
+	if buf.Cap() <= maxPooledBufferSize {
+		bufferPool.Put(buf)
+	}
+}
+
+// Returns a reader of b from the pool. It should be returned with putReader.
+func getReader(b []byte) *bytes.Reader {
+	r := readerPool.Get().(*bytes.Reader)
+	r.Reset(b)
+	return r
+}
+
+// Returns a reader to the pool, releasing its data.
+func putReader(r *bytes.Reader) {
+	r.Reset(nil)
+	readerPool.Put(r)
+}
+
+// Marshal returns the encoding of v, encoding into a pooled buffer.
+// The state accessors of this module encode and decode through Marshal and Unmarshal, so that the generated
+// marshallers write to pooled buffers and read from pooled readers. The 9-byte header scratch allocated within
+// each generated method is emitted by cbor-gen and is not pooled here.
+func Marshal(v Marshaler) ([]byte, error) {
+	buf := GetBuffer()
+	defer PutBuffer(buf)
+	if err := v.MarshalCBOR(buf); err != nil {
+		return nil, err
+	}
+	// Copy out of the pooled buffer.
+	return append([]byte(nil), buf.Bytes()...), nil
+}
+
+// Unmarshal decodes b into v using a pooled reader, returning the number of bytes not consumed.
+func Unmarshal(b []byte, v Unmarshaler) (int, error) {
+	r := getReader(b)
+	defer putReader(r)
+	if err := v.UnmarshalCBOR(r); err != nil {
+		return 0, err
+	}
+	return r.Len(), nil
+}
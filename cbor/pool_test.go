@@ -0,0 +1,133 @@
+package cbor_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+// A byte string of fixed length.
+type blob []byte
+
+func (b blob) MarshalCBOR(w io.Writer) error {
+	if _, err := w.Write([]byte{0x40 | byte(len(b))}); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+func (b *blob) UnmarshalCBOR(r io.Reader) error {
+	var hdr [1]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		return err
+	}
+	*b = make([]byte, hdr[0]&0x1f)
+	_, err := io.ReadFull(r, *b)
+	return err
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	enc, err := cbor.Marshal(blob("abc"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x43, 'a', 'b', 'c'}, enc)
+
+	// The result does not alias a pooled buffer.
+	enc2, err := cbor.Marshal(blob("xyz"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x43, 'a', 'b', 'c'}, enc)
+	assert.Equal(t, []byte{0x43, 'x', 'y', 'z'}, enc2)
+
+	var b blob
+	rest, err := cbor.Unmarshal(append(enc, 0x00), &b)
+	require.NoError(t, err)
+	assert.Equal(t, blob("abc"), b)
+	assert.Equal(t, 1, rest)
+
+	_, err = cbor.Unmarshal([]byte{0x43, 'a'}, &b)
+	assert.Error(t, err)
+}
+
+func TestPools(t *testing.T) {
+	buf := cbor.GetBuffer()
+	buf.WriteString("dirty")
+	cbor.PutBuffer(buf)
+	assert.Equal(t, 0, cbor.GetBuffer().Len())
+}
+
+// A value with generated marshallers, as encoded and decoded in state.
+var benchValue = &abi.PieceInfo{
+	Size:     abi.PaddedPieceSize(2048),
+	PieceCID: cid.NewCidV1(cid.FilCommitmentUnsealed, mustHash(bytes.Repeat([]byte{0xaa}, 32))),
+}
+
+func mustHash(digest []byte) mh.Multihash {
+	hash, err := mh.Encode(digest, mh.SHA2_256_TRUNC254_PADDED)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+func TestMarshalUnmarshalGenerated(t *testing.T) {
+	enc, err := cbor.Marshal(benchValue)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, benchValue.MarshalCBOR(&buf))
+	assert.Equal(t, buf.Bytes(), enc)
+
+	var v abi.PieceInfo
+	rest, err := cbor.Unmarshal(enc, &v)
+	require.NoError(t, err)
+	assert.Equal(t, 0, rest)
+	assert.Equal(t, *benchValue, v)
+}
+
+func BenchmarkMarshalPooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := cbor.Marshal(benchValue); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkMarshalUnpooled(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var buf bytes.Buffer
+		if err := benchValue.MarshalCBOR(&buf); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalPooled(b *testing.B) {
+	enc, _ := cbor.Marshal(benchValue)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var v abi.PieceInfo
+		if _, err := cbor.Unmarshal(enc, &v); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkUnmarshalUnpooled(b *testing.B) {
+	enc, _ := cbor.Marshal(benchValue)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var v abi.PieceInfo
+		if err := v.UnmarshalCBOR(bytes.NewReader(enc)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package cbor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// DecodeOptions selects checks, beyond those of a type's decoder, that UnmarshalWith applies to its input.
+// Decoders generated by cbor-gen tolerate some sloppy encodings: they ignore input after the item they decode,
+// skip unknown map fields, and let a repeated map key overwrite the first. Consumers that must agree on
+// exactly which encodings are valid can reject these.
+type DecodeOptions struct {
+	// Reject input after the decoded item.
+	RejectTrailingBytes bool
+	// Reject maps, at any depth, in which a key appears more than once. Keys are compared by their encoding.
+	RejectDuplicateKeys bool
+	// Reject input with items the decoder ignored, such as extra tuple items or unknown map fields. The
+	// decoded value must re-encode to exactly the bytes consumed, so this also rejects non-minimal encodings.
+	// The value must implement Marshaler.
+	RejectIgnoredItems bool
+}
+
+// StrictDecoding applies all the checks of DecodeOptions.
+var StrictDecoding = DecodeOptions{
+	RejectTrailingBytes: true,
+	RejectDuplicateKeys: true,
+	RejectIgnoredItems:  true,
+}
+
+// UnmarshalWith decodes b into v as Unmarshal does, additionally applying the checks selected by opts.
+// It returns the number of bytes not consumed, which is zero if trailing bytes are rejected.
+func UnmarshalWith(b []byte, v Unmarshaler, opts DecodeOptions) (int, error) {
+	if opts.RejectDuplicateKeys {
+		if err := checkNoDuplicateKeys(b); err != nil {
+			return 0, err
+		}
+	}
+	rest, err := Unmarshal(b, v)
+	if err != nil {
+		return 0, err
+	}
+	if opts.RejectTrailingBytes && rest != 0 {
+		return 0, fmt.Errorf("%d trailing bytes after %T", rest, v)
+	}
+	if opts.RejectIgnoredItems {
+		m, ok := v.(Marshaler)
+		if !ok {
+			return 0, fmt.Errorf("cannot check %T for ignored items: it does not implement Marshaler", v)
+		}
+		enc, err := Marshal(m)
+		if err != nil {
+			return 0, fmt.Errorf("failed to re-encode %T: %w", v, err)
+		}
+		if !bytes.Equal(enc, b[:len(b)-rest]) {
+			return 0, fmt.Errorf("input for %T has items its decoder ignored or does not re-encode identically", v)
+		}
+	}
+	return rest, nil
+}
+
+// Checks the first item of data for maps with duplicate keys. The item need not be canonical, but must be
+// well-formed with definite lengths.
+func checkNoDuplicateKeys(data []byte) error {
+	s := keyScanner{data: data}
+	return s.scanItem(0)
+}
+
+type keyScanner struct {
+	data []byte
+	pos  int
+}
+
+func (s *keyScanner) readHeader() (byte, uint64, error) {
+	if s.pos >= len(s.data) {
+		return 0, 0, fmt.Errorf("unexpected end of cbor data at offset %d", s.pos)
+	}
+	first := s.data[s.pos]
+	s.pos++
+	maj, info := first>>5, first&0x1f
+	var size int
+	switch {
+	case info < 24:
+		return maj, uint64(info), nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, 0, fmt.Errorf("unsupported additional information %d at offset %d", info, s.pos-1)
+	}
+	if len(s.data)-s.pos < size {
+		return 0, 0, fmt.Errorf("unexpected end of cbor data at offset %d", s.pos)
+	}
+	var buf [8]byte
+	copy(buf[8-size:], s.data[s.pos:s.pos+size])
+	s.pos += size
+	return maj, binary.BigEndian.Uint64(buf[:]), nil
+}
+
+func (s *keyScanner) scanItem(depth int) error {
+	if depth > MaxCanonicalDepth {
+		return fmt.Errorf("cbor nesting exceeds maximum depth %d", MaxCanonicalDepth)
+	}
+	maj, extra, err := s.readHeader()
+	if err != nil {
+		return err
+	}
+	switch maj {
+	case majByteString, majTextString:
+		if extra > uint64(len(s.data)-s.pos) {
+			return fmt.Errorf("string length %d exceeds remaining data", extra)
+		}
+		s.pos += int(extra)
+	case majArray:
+		if extra > uint64(len(s.data)-s.pos) {
+			return fmt.Errorf("array length %d exceeds remaining data", extra)
+		}
+		for i := uint64(0); i < extra; i++ {
+			if err := s.scanItem(depth + 1); err != nil {
+				return err
+			}
+		}
+	case majMap:
+		if extra > uint64(len(s.data)-s.pos)/2 {
+			return fmt.Errorf("map length %d exceeds remaining data", extra)
+		}
+		keys := make(map[string]struct{}, extra)
+		for i := uint64(0); i < extra; i++ {
+			start := s.pos
+			if err := s.scanItem(depth + 1); err != nil {
+				return err
+			}
+			key := string(s.data[start:s.pos])
+			if _, ok := keys[key]; ok {
+				return fmt.Errorf("duplicate map key at offset %d", start)
+			}
+			keys[key] = struct{}{}
+			if err := s.scanItem(depth + 1); err != nil {
+				return err
+			}
+		}
+	case majTag:
+		return s.scanItem(depth + 1)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package cbor_test
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+func TestUnmarshalWithTrailingBytes(t *testing.T) {
+	enc, err := cbor.Marshal(&abi.SectorID{Miner: 1000, Number: 7})
+	require.NoError(t, err)
+	trailing := append(append([]byte(nil), enc...), 0x00)
+
+	var id abi.SectorID
+	rest, err := cbor.UnmarshalWith(trailing, &id, cbor.DecodeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, rest)
+
+	_, err = cbor.UnmarshalWith(trailing, &id, cbor.DecodeOptions{RejectTrailingBytes: true})
+	assert.Error(t, err)
+
+	rest, err = cbor.UnmarshalWith(enc, &id, cbor.StrictDecoding)
+	require.NoError(t, err)
+	assert.Equal(t, 0, rest)
+	assert.Equal(t, abi.SectorID{Miner: 1000, Number: 7}, id)
+}
+
+func TestUnmarshalWithIgnoredItems(t *testing.T) {
+	// A tolerant decoder reads the first item of a tuple and skips the rest.
+	extraItems := []byte{0x83, 0x01, 0x02, 0x03}
+	var f firstItem
+	_, err := cbor.UnmarshalWith(extraItems, &f, cbor.DecodeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, firstItem(1), f)
+	_, err = cbor.UnmarshalWith(extraItems, &f, cbor.DecodeOptions{RejectIgnoredItems: true})
+	assert.Error(t, err)
+	_, err = cbor.UnmarshalWith([]byte{0x81, 0x01}, &f, cbor.DecodeOptions{RejectIgnoredItems: true})
+	assert.NoError(t, err)
+
+	// An unknown field of a map-encoded type is skipped by its decoder.
+	trace := abi.GasTrace{Name: "OnChainMessage", TotalGas: 10}
+	enc, err := cbor.Marshal(&trace)
+	require.NoError(t, err)
+	extra := withExtraMapEntry(t, enc, "Extra", 0x00)
+	var out abi.GasTrace
+	_, err = cbor.UnmarshalWith(extra, &out, cbor.DecodeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, trace, out)
+	_, err = cbor.UnmarshalWith(extra, &out, cbor.DecodeOptions{RejectIgnoredItems: true})
+	assert.Error(t, err)
+
+	_, err = cbor.UnmarshalWith(enc, &out, cbor.StrictDecoding)
+	assert.NoError(t, err)
+}
+
+func TestUnmarshalWithDuplicateKeys(t *testing.T) {
+	enc, err := cbor.Marshal(&abi.GasTrace{Name: "OnChainMessage"})
+	require.NoError(t, err)
+	dup := withExtraMapEntry(t, enc, "Name", 0x60)
+
+	// The repeated key overwrites the first.
+	var out abi.GasTrace
+	_, err = cbor.UnmarshalWith(dup, &out, cbor.DecodeOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "", out.Name)
+
+	_, err = cbor.UnmarshalWith(dup, &out, cbor.DecodeOptions{RejectDuplicateKeys: true})
+	assert.Error(t, err)
+
+	// Duplicates are found in nested maps, and malformed input is rejected.
+	nested := []byte{0x81, 0xa2, 0x61, 'a', 0x01, 0x61, 'a', 0x02}
+	_, err = cbor.UnmarshalWith(nested, &out, cbor.DecodeOptions{RejectDuplicateKeys: true})
+	assert.Error(t, err)
+	_, err = cbor.UnmarshalWith([]byte{0xa1, 0x61}, &out, cbor.DecodeOptions{RejectDuplicateKeys: true})
+	assert.Error(t, err)
+}
+
+// A tuple of which only the first item, a small integer, is kept.
+type firstItem byte
+
+func (f *firstItem) MarshalCBOR(w io.Writer) error {
+	_, err := w.Write([]byte{0x81, byte(*f)})
+	return err
+}
+
+func (f *firstItem) UnmarshalCBOR(r io.Reader) error {
+	maj, n, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || n == 0 {
+		return xerrors.Errorf("expected non-empty tuple")
+	}
+	for i := uint64(0); i < n; i++ {
+		var item cbg.Deferred
+		if err := item.UnmarshalCBOR(r); err != nil {
+			return err
+		}
+		if i == 0 {
+			*f = firstItem(item.Raw[0])
+		}
+	}
+	return nil
+}
+
+// Appends an entry with a text key and a one-byte value to an encoded map of fewer than 23 entries.
+func withExtraMapEntry(t *testing.T, enc []byte, key string, value byte) []byte {
+	require.Equal(t, byte(0xa0), enc[0]&0xe0)
+	require.Less(t, int(enc[0]&0x1f), 23)
+	out := append([]byte{enc[0] + 1}, enc[1:]...)
+	out = append(out, 0x60|byte(len(key)))
+	out = append(out, key...)
+	return append(out, value)
+}
@@ -0,0 +1,81 @@
+// Package chain defines types of the chain structure, above the state: signed messages and tipset keys.
+package chain
+
+import (
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+// SignedMessage is a message with its sender's signature.
+type SignedMessage struct {
+	Message   abi.Message
+	Signature crypto.Signature
+}
+
+// Serialize returns the CBOR encoding of the signed message.
+func (sm *SignedMessage) Serialize() ([]byte, error) {
+	return cbor.Marshal(sm)
+}
+
+// Cid returns the CID identifying the message.
+// BLS signatures of the messages in a block are aggregated into one, and the messages are included in the
+// block unsigned, so a BLS-signed message is identified by the CID of the unsigned message. Other messages
+// are identified by the CID of the signed message.
+func (sm *SignedMessage) Cid() (cid.Cid, error) {
+	if sm.Signature.Type == crypto.SigTypeBLS {
+		return sm.Message.Cid()
+	}
+	data, err := sm.Serialize()
+	if err != nil {
+		return cid.Undef, err
+	}
+	return abi.CidBuilder.Sum(data)
+}
+
+// BlockMessages holds the messages included in a block, in the layout of the block's message metadata:
+// BLS-signed messages without their signatures (which are aggregated into the block's BLS aggregate), and
+// all other messages with their signatures.
+type BlockMessages struct {
+	BlsMessages   []*abi.Message
+	SecpkMessages []*SignedMessage
+}
+
+// NewBlockMessages partitions messages, in order, into the block message layout. The signatures of the BLS
+// messages are returned, in order, for aggregation.
+func NewBlockMessages(msgs []*SignedMessage) (*BlockMessages, []crypto.Signature) {
+	bm := &BlockMessages{}
+	var blsSigs []crypto.Signature
+	for _, m := range msgs {
+		if m.Signature.Type == crypto.SigTypeBLS {
+			bm.BlsMessages = append(bm.BlsMessages, &m.Message)
+			blsSigs = append(blsSigs, m.Signature)
+		} else {
+			bm.SecpkMessages = append(bm.SecpkMessages, m)
+		}
+	}
+	return bm, blsSigs
+}
+
+// Cids returns the identifying CIDs of the block's messages: those of the BLS messages followed by those of the
+// others, which is the order in which they are executed.
+func (bm *BlockMessages) Cids() ([]cid.Cid, error) {
+	cids := make([]cid.Cid, 0, len(bm.BlsMessages)+len(bm.SecpkMessages))
+	for _, m := range bm.BlsMessages {
+		c, err := m.Cid()
+		if err != nil {
+			return nil, err
+		}
+		cids = append(cids, c)
+	}
+	for _, m := range bm.SecpkMessages {
+		c, err := m.Cid()
+		if err != nil {
+			return nil, err
+		}
+		cids = append(cids, c)
+	}
+	return cids, nil
+}
@@ -0,0 +1,79 @@
+package chain_test
+
+import (
+	"bytes"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/chain"
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+func testSignedMessage(t *testing.T, nonce uint64, sigType crypto.SigType) *chain.SignedMessage {
+	to, err := addr.NewIDAddress(100)
+	require.NoError(t, err)
+	from, err := addr.NewIDAddress(101)
+	require.NoError(t, err)
+	return &chain.SignedMessage{
+		Message: abi.Message{
+			To:         to,
+			From:       from,
+			Nonce:      nonce,
+			Value:      big.Zero(),
+			GasLimit:   1000,
+			GasFeeCap:  big.NewInt(1),
+			GasPremium: big.NewInt(1),
+		},
+		Signature: crypto.Signature{Type: sigType, Data: []byte{byte(nonce), 1, 2}},
+	}
+}
+
+func TestSignedMessageCid(t *testing.T) {
+	bls := testSignedMessage(t, 1, crypto.SigTypeBLS)
+	c, err := bls.Cid()
+	require.NoError(t, err)
+	unsigned, err := bls.Message.Cid()
+	require.NoError(t, err)
+	assert.Equal(t, unsigned, c)
+
+	secp := testSignedMessage(t, 1, crypto.SigTypeSecp256k1)
+	c, err = secp.Cid()
+	require.NoError(t, err)
+	b, err := secp.Serialize()
+	require.NoError(t, err)
+	expected, err := abi.CidBuilder.Sum(b)
+	require.NoError(t, err)
+	assert.Equal(t, expected, c)
+	assert.NotEqual(t, unsigned, c)
+
+	var decoded chain.SignedMessage
+	require.NoError(t, decoded.UnmarshalCBOR(bytes.NewReader(b)))
+	assert.Equal(t, *secp, decoded)
+}
+
+func TestNewBlockMessages(t *testing.T) {
+	m1 := testSignedMessage(t, 1, crypto.SigTypeSecp256k1)
+	m2 := testSignedMessage(t, 2, crypto.SigTypeBLS)
+	m3 := testSignedMessage(t, 3, crypto.SigTypeBLS)
+
+	bm, sigs := chain.NewBlockMessages([]*chain.SignedMessage{m1, m2, m3})
+	assert.Equal(t, []*abi.Message{&m2.Message, &m3.Message}, bm.BlsMessages)
+	assert.Equal(t, []*chain.SignedMessage{m1}, bm.SecpkMessages)
+	assert.Equal(t, []crypto.Signature{m2.Signature, m3.Signature}, sigs)
+
+	cids, err := bm.Cids()
+	require.NoError(t, err)
+	var expected []cid.Cid
+	for _, m := range []*chain.SignedMessage{m2, m3, m1} {
+		c, err := m.Cid()
+		require.NoError(t, err)
+		expected = append(expected, c)
+	}
+	assert.Equal(t, expected, cids)
+}
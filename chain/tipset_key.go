@@ -0,0 +1,149 @@
+package chain
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// Maximum encoded size of a TipSetKey read from CBOR.
+const maxTipSetKeyBytes = 1 << 16
+
+// EmptyTipSetKey is the key of no tipset, used by APIs to refer to the chain head.
+var EmptyTipSetKey = TipSetKey{}
+
+// TipSetKey is an immutable, ordered set of block CIDs identifying a tipset.
+// The CIDs are in the order of the tipset's blocks (by ticket).
+//
+// The key is represented internally as the concatenated bytes of the CIDs, which is also its canonical
+// encoding. This makes the key comparable with ==, and usable as a map key.
+type TipSetKey struct {
+	value string
+}
+
+// NewTipSetKey returns the key of a tipset with blocks with the given CIDs, in order.
+func NewTipSetKey(cids ...cid.Cid) TipSetKey {
+	var b strings.Builder
+	for _, c := range cids {
+		b.Write(c.Bytes())
+	}
+	return TipSetKey{value: b.String()}
+}
+
+// TipSetKeyFromBytes decodes a tipset key from its canonical encoding, the concatenated bytes of its CIDs.
+func TipSetKeyFromBytes(b []byte) (TipSetKey, error) {
+	if _, err := decodeKey(b); err != nil {
+		return EmptyTipSetKey, err
+	}
+	return TipSetKey{value: string(b)}, nil
+}
+
+// Cids returns the CIDs of the key, in order.
+func (k TipSetKey) Cids() []cid.Cid {
+	cids, err := decodeKey([]byte(k.value))
+	if err != nil {
+		panic("invalid tipset key: " + err.Error())
+	}
+	return cids
+}
+
+// Bytes returns the canonical encoding of the key.
+func (k TipSetKey) Bytes() []byte {
+	return []byte(k.value)
+}
+
+// IsEmpty reports whether the key contains no CIDs.
+func (k TipSetKey) IsEmpty() bool {
+	return len(k.value) == 0
+}
+
+// Equals reports whether two keys contain the same CIDs in the same order.
+func (k TipSetKey) Equals(other TipSetKey) bool {
+	return k.value == other.value
+}
+
+// Contains reports whether the key contains a block CID.
+func (k TipSetKey) Contains(c cid.Cid) bool {
+	for _, kc := range k.Cids() {
+		if kc.Equals(c) {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the key's CIDs as a list.
+func (k TipSetKey) String() string {
+	b := strings.Builder{}
+	b.WriteString("{")
+	for i, c := range k.Cids() {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString(c.String())
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// MarshalJSON encodes the key as an array of CIDs.
+func (k TipSetKey) MarshalJSON() ([]byte, error) {
+	return json.Marshal(k.Cids())
+}
+
+// UnmarshalJSON decodes the key from an array of CIDs.
+func (k *TipSetKey) UnmarshalJSON(b []byte) error {
+	var cids []cid.Cid
+	if err := json.Unmarshal(b, &cids); err != nil {
+		return err
+	}
+	*k = NewTipSetKey(cids...)
+	return nil
+}
+
+// MarshalCBOR encodes the key as a byte string of its canonical encoding.
+func (k *TipSetKey) MarshalCBOR(w io.Writer) error {
+	if err := cbg.CborWriteHeader(w, cbg.MajByteString, uint64(len(k.value))); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, k.value)
+	return err
+}
+
+// UnmarshalCBOR decodes the key from a byte string of its canonical encoding.
+func (k *TipSetKey) UnmarshalCBOR(r io.Reader) error {
+	maj, extra, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajByteString {
+		return fmt.Errorf("expected byte string for tipset key, got major type %d", maj)
+	}
+	if extra > maxTipSetKeyBytes {
+		return fmt.Errorf("tipset key too large: %d bytes", extra)
+	}
+	b := make([]byte, extra)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return err
+	}
+	*k, err = TipSetKeyFromBytes(b)
+	return err
+}
+
+func decodeKey(b []byte) ([]cid.Cid, error) {
+	cids := []cid.Cid{}
+	for len(b) > 0 {
+		n, c, err := cid.CidFromBytes(b)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid CID at offset %d of tipset key: %w", len(cids), err)
+		}
+		cids = append(cids, c)
+		b = b[n:]
+	}
+	return cids, nil
+}
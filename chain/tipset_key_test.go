@@ -0,0 +1,65 @@
+package chain_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/chain"
+)
+
+func testCid(t *testing.T, s string) cid.Cid {
+	c, err := abi.CidBuilder.Sum([]byte(s))
+	require.NoError(t, err)
+	return c
+}
+
+func TestTipSetKey(t *testing.T) {
+	c1, c2, c3 := testCid(t, "a"), testCid(t, "b"), testCid(t, "c")
+
+	k := chain.NewTipSetKey(c1, c2)
+	assert.Equal(t, []cid.Cid{c1, c2}, k.Cids())
+	assert.Equal(t, append(c1.Bytes(), c2.Bytes()...), k.Bytes())
+	assert.True(t, k.Contains(c2))
+	assert.False(t, k.Contains(c3))
+	assert.False(t, k.IsEmpty())
+	assert.True(t, chain.EmptyTipSetKey.IsEmpty())
+	assert.Empty(t, chain.EmptyTipSetKey.Cids())
+
+	assert.True(t, k.Equals(chain.NewTipSetKey(c1, c2)))
+	assert.False(t, k.Equals(chain.NewTipSetKey(c2, c1)))
+	assert.False(t, k.Equals(chain.NewTipSetKey(c1)))
+
+	decoded, err := chain.TipSetKeyFromBytes(k.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, k, decoded)
+	_, err = chain.TipSetKeyFromBytes(append(k.Bytes(), 0x01))
+	assert.Error(t, err)
+
+	assert.Equal(t, "{"+c1.String()+","+c2.String()+"}", k.String())
+}
+
+func TestTipSetKeyEncoding(t *testing.T) {
+	k := chain.NewTipSetKey(testCid(t, "a"), testCid(t, "b"))
+
+	j, err := json.Marshal(k)
+	require.NoError(t, err)
+	var fromJSON chain.TipSetKey
+	require.NoError(t, json.Unmarshal(j, &fromJSON))
+	assert.Equal(t, k, fromJSON)
+
+	j, err = json.Marshal(chain.EmptyTipSetKey)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", string(j))
+
+	var buf bytes.Buffer
+	require.NoError(t, k.MarshalCBOR(&buf))
+	var fromCBOR chain.TipSetKey
+	require.NoError(t, fromCBOR.UnmarshalCBOR(&buf))
+	assert.Equal(t, k, fromCBOR)
+}
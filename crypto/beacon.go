@@ -0,0 +1,37 @@
+package crypto
+
+import "golang.org/x/xerrors"
+
+// BeaconEntry is an entry of a randomness beacon (drand), included in block headers. Each entry's data is
+// the beacon's signature for its round.
+type BeaconEntry struct {
+	Round uint64
+	Data  []byte
+}
+
+// BeaconChainValidator validates that one beacon entry may follow another. Implementations typically verify
+// the entry's signature against the beacon's public key; for chained beacons the signed message includes the
+// previous entry's signature.
+type BeaconChainValidator interface {
+	ValidateChain(prev, next BeaconEntry) error
+}
+
+// ValidateBeaconEntries checks that entries follow prev, with strictly increasing rounds, and are each
+// accepted by the validator (if not nil) given the entry before.
+func ValidateBeaconEntries(v BeaconChainValidator, prev BeaconEntry, entries []BeaconEntry) error {
+	for i, next := range entries {
+		if next.Round <= prev.Round {
+			return xerrors.Errorf("beacon entry %d has round %d, not after round %d", i, next.Round, prev.Round)
+		}
+		if len(next.Data) == 0 {
+			return xerrors.Errorf("beacon entry %d for round %d has no data", i, next.Round)
+		}
+		if v != nil {
+			if err := v.ValidateChain(prev, next); err != nil {
+				return xerrors.Errorf("invalid beacon entry %d for round %d: %w", i, next.Round, err)
+			}
+		}
+		prev = next
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package crypto_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+// Accepts entries whose data is the previous entry's data followed by one byte.
+type chainValidator struct{}
+
+func (chainValidator) ValidateChain(prev, next crypto.BeaconEntry) error {
+	if !bytes.HasPrefix(next.Data, prev.Data) || len(next.Data) != len(prev.Data)+1 {
+		return xerrors.New("bad signature")
+	}
+	return nil
+}
+
+func TestBeaconEntryCBOR(t *testing.T) {
+	e := crypto.BeaconEntry{Round: 1000, Data: []byte{1, 2, 3}}
+	var buf bytes.Buffer
+	require.NoError(t, e.MarshalCBOR(&buf))
+	assert.Equal(t, []byte{0x82, 0x19, 0x03, 0xe8, 0x43, 1, 2, 3}, buf.Bytes())
+
+	var out crypto.BeaconEntry
+	require.NoError(t, out.UnmarshalCBOR(&buf))
+	assert.Equal(t, e, out)
+}
+
+func TestValidateBeaconEntries(t *testing.T) {
+	prev := crypto.BeaconEntry{Round: 10, Data: []byte{1}}
+	entries := []crypto.BeaconEntry{{Round: 11, Data: []byte{1, 2}}, {Round: 13, Data: []byte{1, 2, 3}}}
+	assert.NoError(t, crypto.ValidateBeaconEntries(chainValidator{}, prev, entries))
+	assert.NoError(t, crypto.ValidateBeaconEntries(nil, prev, nil))
+
+	err := crypto.ValidateBeaconEntries(chainValidator{}, prev, []crypto.BeaconEntry{{Round: 11, Data: []byte{2, 2}}})
+	assert.EqualError(t, err, "invalid beacon entry 0 for round 11: bad signature")
+	err = crypto.ValidateBeaconEntries(nil, prev, []crypto.BeaconEntry{{Round: 10, Data: []byte{1}}})
+	assert.EqualError(t, err, "beacon entry 0 has round 10, not after round 10")
+	err = crypto.ValidateBeaconEntries(nil, prev, []crypto.BeaconEntry{{Round: 12}})
+	assert.EqualError(t, err, "beacon entry 0 for round 12 has no data")
+}
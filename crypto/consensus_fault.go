@@ -0,0 +1,108 @@
+package crypto
+
+import (
+	"bytes"
+	"fmt"
+
+	addr "github.com/filecoin-project/go-address"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// ConsensusFaultType identifies a kind of consensus fault committed by a block producer.
+type ConsensusFaultType int64
+
+const (
+	// Two blocks mined by the same miner at the same epoch.
+	ConsensusFaultDoubleForkMining ConsensusFaultType = 1
+	// A block mined by a miner which omits, from its parents, another block the same miner mined at the
+	// parent epoch on the same parents (so grinding on its own blocks).
+	ConsensusFaultParentGrinding ConsensusFaultType = 2
+	// Two blocks mined by the same miner with the same parents but different epochs.
+	ConsensusFaultTimeOffsetMining ConsensusFaultType = 3
+)
+
+func (t ConsensusFaultType) String() string {
+	switch t {
+	case ConsensusFaultDoubleForkMining:
+		return "double-fork mining"
+	case ConsensusFaultParentGrinding:
+		return "parent grinding"
+	case ConsensusFaultTimeOffsetMining:
+		return "time-offset mining"
+	default:
+		return fmt.Sprintf("ConsensusFaultType(%d)", int64(t))
+	}
+}
+
+// ConsensusFault is the result of verifying consensus fault evidence.
+type ConsensusFault struct {
+	// Address of the miner at fault (always an ID address).
+	Target addr.Address
+	// Epoch of the fault, which is the higher epoch of the two blocks causing it.
+	Epoch abi.ChainEpoch
+	// Type of fault.
+	Type ConsensusFaultType
+}
+
+// ConsensusFaultEvidence holds the serialized block headers evidencing a consensus fault, in the layout
+// expected by the miner actor's ReportConsensusFault method and the VM's consensus fault verification.
+// Double-fork mining and time-offset mining are evidenced by two distinct headers from the same miner,
+// at the same epoch or on the same parents respectively. Parent grinding is evidenced by a header, a header
+// from the same miner at a later epoch which omits the first from its parents, and an extra header which
+// shares the first header's parents and is among the parents of the second.
+type ConsensusFaultEvidence struct {
+	Type             ConsensusFaultType
+	BlockHeader1     []byte
+	BlockHeader2     []byte
+	BlockHeaderExtra []byte
+}
+
+// NewDoubleForkMiningEvidence returns evidence of two blocks mined by the same miner at the same epoch.
+func NewDoubleForkMiningEvidence(header1, header2 []byte) (*ConsensusFaultEvidence, error) {
+	return newConsensusFaultEvidence(ConsensusFaultDoubleForkMining, header1, header2, nil)
+}
+
+// NewTimeOffsetMiningEvidence returns evidence of two blocks mined by the same miner on the same parents
+// at different epochs.
+func NewTimeOffsetMiningEvidence(header1, header2 []byte) (*ConsensusFaultEvidence, error) {
+	return newConsensusFaultEvidence(ConsensusFaultTimeOffsetMining, header1, header2, nil)
+}
+
+// NewParentGrindingEvidence returns evidence of a miner omitting its own block from a subsequent block's parents.
+// The extra header is a block on the same parents as header1, which is among the parents of header2.
+func NewParentGrindingEvidence(header1, header2, extra []byte) (*ConsensusFaultEvidence, error) {
+	return newConsensusFaultEvidence(ConsensusFaultParentGrinding, header1, header2, extra)
+}
+
+func newConsensusFaultEvidence(t ConsensusFaultType, header1, header2, extra []byte) (*ConsensusFaultEvidence, error) {
+	e := &ConsensusFaultEvidence{Type: t, BlockHeader1: header1, BlockHeader2: header2, BlockHeaderExtra: extra}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Validate checks that the evidence has the layout required by its fault type.
+// It does not decode or verify the headers themselves.
+func (e *ConsensusFaultEvidence) Validate() error {
+	if len(e.BlockHeader1) == 0 || len(e.BlockHeader2) == 0 {
+		return fmt.Errorf("%s evidence requires two block headers", e.Type)
+	}
+	if bytes.Equal(e.BlockHeader1, e.BlockHeader2) {
+		return fmt.Errorf("%s evidence requires distinct block headers", e.Type)
+	}
+	switch e.Type {
+	case ConsensusFaultDoubleForkMining, ConsensusFaultTimeOffsetMining:
+		if len(e.BlockHeaderExtra) != 0 {
+			return fmt.Errorf("%s evidence takes no extra block header", e.Type)
+		}
+	case ConsensusFaultParentGrinding:
+		if len(e.BlockHeaderExtra) == 0 {
+			return fmt.Errorf("%s evidence requires an extra block header", e.Type)
+		}
+	default:
+		return fmt.Errorf("invalid consensus fault type %d", e.Type)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+func TestConsensusFaultEvidence(t *testing.T) {
+	h1, h2, extra := []byte{0x01}, []byte{0x02}, []byte{0x03}
+
+	e, err := crypto.NewDoubleForkMiningEvidence(h1, h2)
+	require.NoError(t, err)
+	assert.Equal(t, crypto.ConsensusFaultDoubleForkMining, e.Type)
+	assert.Nil(t, e.BlockHeaderExtra)
+
+	e, err = crypto.NewParentGrindingEvidence(h1, h2, extra)
+	require.NoError(t, err)
+	assert.Equal(t, extra, e.BlockHeaderExtra)
+
+	_, err = crypto.NewTimeOffsetMiningEvidence(h1, h1)
+	assert.Error(t, err)
+	_, err = crypto.NewTimeOffsetMiningEvidence(h1, nil)
+	assert.Error(t, err)
+	_, err = crypto.NewParentGrindingEvidence(h1, h2, nil)
+	assert.Error(t, err)
+	assert.Error(t, (&crypto.ConsensusFaultEvidence{Type: crypto.ConsensusFaultDoubleForkMining, BlockHeader1: h1, BlockHeader2: h2, BlockHeaderExtra: extra}).Validate())
+	assert.Error(t, (&crypto.ConsensusFaultEvidence{Type: 4, BlockHeader1: h1, BlockHeader2: h2}).Validate())
+
+	assert.Equal(t, "parent grinding", crypto.ConsensusFaultParentGrinding.String())
+}
@@ -1,5 +1,12 @@
 package crypto
 
+import (
+	"strconv"
+	"sync"
+
+	"golang.org/x/xerrors"
+)
+
 // Specifies a domain for randomness generation.
 type DomainSeparationTag int64
 
@@ -14,3 +21,97 @@ const (
 	DomainSeparationTag_MarketDealCronSeed
 	DomainSeparationTag_PoStChainCommit
 )
+
+// Tags from this value are available to user-defined actors needing their own randomness domains.
+// Lower tags are reserved for builtin domains, including those yet to be defined.
+const FirstUserDomainSeparationTag DomainSeparationTag = 1 << 16
+
+// User-defined domains are registered with a unique tag and name, so that randomness drawn for one domain can
+// never be mistaken for (or replayed as) randomness for another.
+var (
+	userTagsLk      sync.RWMutex
+	userTagNames    = map[DomainSeparationTag]string{}
+	userTagsByName  = map[string]DomainSeparationTag{}
+	builtinTagNames = func() map[string]DomainSeparationTag {
+		m := make(map[string]DomainSeparationTag, len(domainSeparationTagNames))
+		for tag, name := range domainSeparationTagNames {
+			m[name] = tag
+		}
+		return m
+	}()
+)
+
+// RegisterDomainSeparationTag registers a user-defined randomness domain. The tag must be at least
+// FirstUserDomainSeparationTag, and neither the tag nor the name may already be in use.
+func RegisterDomainSeparationTag(tag DomainSeparationTag, name string) error {
+	if tag < FirstUserDomainSeparationTag {
+		return xerrors.Errorf("domain separation tag %d is reserved for builtin domains", tag)
+	}
+	if name == "" {
+		return xerrors.Errorf("domain separation tag %d must have a name", tag)
+	}
+	userTagsLk.Lock()
+	defer userTagsLk.Unlock()
+	if existing, ok := userTagNames[tag]; ok {
+		return xerrors.Errorf("domain separation tag %d is already registered as %q", tag, existing)
+	}
+	if existing, ok := builtinTagNames[name]; ok {
+		return xerrors.Errorf("domain name %q is already used by builtin tag %d", name, existing)
+	}
+	if existing, ok := userTagsByName[name]; ok {
+		return xerrors.Errorf("domain name %q is already registered for tag %d", name, existing)
+	}
+	userTagNames[tag] = name
+	userTagsByName[name] = tag
+	return nil
+}
+
+// ClearUserDomainSeparationTags forgets all registered user-defined domains. This is intended for testing.
+func ClearUserDomainSeparationTags() {
+	userTagsLk.Lock()
+	defer userTagsLk.Unlock()
+	userTagNames = map[DomainSeparationTag]string{}
+	userTagsByName = map[string]DomainSeparationTag{}
+}
+
+// DomainSeparationTagByName returns the builtin or registered tag with a name.
+func DomainSeparationTagByName(name string) (DomainSeparationTag, bool) {
+	if tag, ok := builtinTagNames[name]; ok {
+		return tag, true
+	}
+	userTagsLk.RLock()
+	defer userTagsLk.RUnlock()
+	tag, ok := userTagsByName[name]
+	return tag, ok
+}
+
+// IsBuiltin returns whether the tag is one of the builtin domains.
+func (t DomainSeparationTag) IsBuiltin() bool {
+	_, ok := domainSeparationTagNames[t]
+	return ok
+}
+
+// IsKnown returns whether the tag is a builtin domain or a registered user-defined domain.
+func (t DomainSeparationTag) IsKnown() bool {
+	_, ok := t.Name()
+	return ok
+}
+
+// Name returns the name of a builtin or registered tag.
+func (t DomainSeparationTag) Name() (string, bool) {
+	if name, ok := domainSeparationTagNames[t]; ok {
+		return name, true
+	}
+	userTagsLk.RLock()
+	defer userTagsLk.RUnlock()
+	name, ok := userTagNames[t]
+	return name, ok
+}
+
+// String returns the name of a builtin or registered tag, or the type and number otherwise.
+func (t DomainSeparationTag) String() string {
+	if name, ok := t.Name(); ok {
+		return name
+	}
+	return "DomainSeparationTag(" + strconv.FormatInt(int64(t), 10) + ")"
+}
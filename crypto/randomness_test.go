@@ -0,0 +1,43 @@
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/crypto"
+)
+
+func TestDomainSeparationTagRegistry(t *testing.T) {
+	defer crypto.ClearUserDomainSeparationTags()
+
+	assert.Equal(t, "SealRandomness", crypto.DomainSeparationTag_SealRandomness.String())
+	assert.True(t, crypto.DomainSeparationTag_SealRandomness.IsBuiltin())
+	tag, ok := crypto.DomainSeparationTagByName("PoStChainCommit")
+	require.True(t, ok)
+	assert.Equal(t, crypto.DomainSeparationTag_PoStChainCommit, tag)
+
+	user := crypto.FirstUserDomainSeparationTag + 7
+	assert.False(t, user.IsKnown())
+	assert.Equal(t, "DomainSeparationTag(65543)", user.String())
+	require.NoError(t, crypto.RegisterDomainSeparationTag(user, "LotteryDraw"))
+	assert.True(t, user.IsKnown())
+	assert.False(t, user.IsBuiltin())
+	assert.Equal(t, "LotteryDraw", user.String())
+	tag, ok = crypto.DomainSeparationTagByName("LotteryDraw")
+	require.True(t, ok)
+	assert.Equal(t, user, tag)
+
+	// Collisions with builtin or registered tags and names are rejected.
+	assert.Error(t, crypto.RegisterDomainSeparationTag(crypto.DomainSeparationTag_SealRandomness, "Other"))
+	assert.Error(t, crypto.RegisterDomainSeparationTag(crypto.FirstUserDomainSeparationTag-1, "Other"))
+	assert.Error(t, crypto.RegisterDomainSeparationTag(user, "Other"))
+	assert.Error(t, crypto.RegisterDomainSeparationTag(user+1, "LotteryDraw"))
+	assert.Error(t, crypto.RegisterDomainSeparationTag(user+1, "SealRandomness"))
+	assert.Error(t, crypto.RegisterDomainSeparationTag(user+1, ""))
+
+	crypto.ClearUserDomainSeparationTags()
+	assert.False(t, user.IsKnown())
+	require.NoError(t, crypto.RegisterDomainSeparationTag(user+1, "LotteryDraw"))
+}
@@ -65,6 +65,12 @@ func (d *Info) NextOpen() abi.ChainEpoch {
 	return d.Close
 }
 
+// The quantization of expirations and other scheduled events to this deadline: the last epoch of each
+// instance of the deadline.
+func (d *Info) QuantSpec() abi.QuantSpec {
+	return abi.NewQuantSpec(d.WPoStProvingPeriod, d.Last())
+}
+
 // Whether the deadline's fault cutoff has passed.
 func (d *Info) FaultCutoffPassed() bool {
 	return d.CurrentEpoch >= d.FaultCutoff
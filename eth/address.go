@@ -0,0 +1,159 @@
+// Package eth bridges Ethereum-style identifiers and their Filecoin counterparts, for the FEVM.
+package eth
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+
+	addr "github.com/filecoin-project/go-address"
+	"golang.org/x/crypto/sha3"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+)
+
+// Length of an Ethereum address, in bytes.
+const EthAddressLength = 20
+
+// Prefix of a masked ID address: an Ethereum address standing for an actor ID address, with the ID in the
+// last 8 bytes (big-endian).
+var maskedIDPrefix = [12]byte{0xff}
+
+// EthAddress is an Ethereum address.
+// An actor with a delegated (f410) address in the Ethereum address manager's namespace has an Ethereum address
+// equal to the delegated sub-address. Any other actor is addressable from the EVM by its masked ID address.
+type EthAddress [EthAddressLength]byte
+
+// EthAddressFromBytes returns the Ethereum address with some bytes, which must be exactly EthAddressLength long.
+func EthAddressFromBytes(b []byte) (EthAddress, error) {
+	var ea EthAddress
+	if len(b) != EthAddressLength {
+		return ea, xerrors.Errorf("eth address must be %d bytes, got %d", EthAddressLength, len(b))
+	}
+	copy(ea[:], b)
+	return ea, nil
+}
+
+// ParseEthAddress parses a 0x-prefixed hex Ethereum address. A mixed-case address must have a valid
+// EIP-55 checksum.
+func ParseEthAddress(s string) (EthAddress, error) {
+	var ea EthAddress
+	if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+		return ea, xerrors.Errorf("eth address %q must begin with 0x", s)
+	}
+	h := s[2:]
+	if len(h) != 2*EthAddressLength {
+		return ea, xerrors.Errorf("eth address %q must have %d hex digits", s, 2*EthAddressLength)
+	}
+	var parsed EthAddress
+	if _, err := hex.Decode(parsed[:], []byte(h)); err != nil {
+		return ea, xerrors.Errorf("invalid eth address %q: %w", s, err)
+	}
+	if h != strings.ToLower(h) && h != strings.ToUpper(h) && parsed.String() != "0x"+h {
+		return ea, xerrors.Errorf("invalid checksum for eth address %q", s)
+	}
+	return parsed, nil
+}
+
+// NewMaskedIDAddress returns the masked ID address standing for an actor ID.
+func NewMaskedIDAddress(id abi.ActorID) EthAddress {
+	var ea EthAddress
+	copy(ea[:], maskedIDPrefix[:])
+	binary.BigEndian.PutUint64(ea[len(maskedIDPrefix):], uint64(id))
+	return ea
+}
+
+// EthAddressFromFilecoinAddress returns the Ethereum address of an ID address (as a masked ID address) or of
+// a delegated address in the Ethereum address manager's namespace.
+func EthAddressFromFilecoinAddress(a addr.Address) (EthAddress, error) {
+	switch a.Protocol() {
+	case addr.ID:
+		id, err := addr.IDFromAddress(a)
+		if err != nil {
+			return EthAddress{}, err
+		}
+		return NewMaskedIDAddress(abi.ActorID(id)), nil
+	case addr.Delegated:
+		payload := a.Payload()
+		namespace, n := binary.Uvarint(payload)
+		if n <= 0 {
+			return EthAddress{}, xerrors.Errorf("invalid delegated address namespace in %s", a)
+		}
+		if namespace != uint64(builtin.EthereumAddressManagerActorID) {
+			return EthAddress{}, xerrors.Errorf("delegated address %s is not in the Ethereum address manager's namespace", a)
+		}
+		ea, err := EthAddressFromBytes(payload[n:])
+		if err != nil {
+			return EthAddress{}, xerrors.Errorf("invalid delegated address %s: %w", a, err)
+		}
+		if _, isID := ea.ActorID(); isID {
+			return EthAddress{}, xerrors.Errorf("delegated address %s holds a masked ID address", a)
+		}
+		return ea, nil
+	default:
+		return EthAddress{}, xerrors.Errorf("address %s has no eth address", a)
+	}
+}
+
+// ActorID returns the actor ID of a masked ID address, and whether the address is a masked ID address.
+func (ea EthAddress) ActorID() (abi.ActorID, bool) {
+	if !bytes.Equal(ea[:len(maskedIDPrefix)], maskedIDPrefix[:]) {
+		return 0, false
+	}
+	return abi.ActorID(binary.BigEndian.Uint64(ea[len(maskedIDPrefix):])), true
+}
+
+// ToFilecoinAddress returns the ID address of a masked ID address, and otherwise the f410 delegated address.
+func (ea EthAddress) ToFilecoinAddress() (addr.Address, error) {
+	if id, ok := ea.ActorID(); ok {
+		return addr.NewIDAddress(uint64(id))
+	}
+	return addr.NewDelegatedAddress(uint64(builtin.EthereumAddressManagerActorID), ea[:])
+}
+
+// Bytes returns the address bytes.
+func (ea EthAddress) Bytes() []byte {
+	return ea[:]
+}
+
+// String returns the 0x-prefixed hex address with an EIP-55 checksum: each hex letter is upper case if the
+// corresponding nibble of the Keccak-256 hash of the lower case hex address is at least 8.
+func (ea EthAddress) String() string {
+	lower := hex.EncodeToString(ea[:])
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write([]byte(lower))
+	sum := h.Sum(nil)
+
+	out := []byte(lower)
+	for i, c := range out {
+		nibble := sum[i/2] >> 4
+		if i%2 == 1 {
+			nibble = sum[i/2] & 0x0f
+		}
+		if c >= 'a' && nibble >= 8 {
+			out[i] = c - 'a' + 'A'
+		}
+	}
+	return "0x" + string(out)
+}
+
+func (ea EthAddress) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ea.String())
+}
+
+func (ea *EthAddress) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseEthAddress(s)
+	if err != nil {
+		return err
+	}
+	*ea = parsed
+	return nil
+}
@@ -0,0 +1,83 @@
+package eth_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/eth"
+)
+
+func TestEthAddressString(t *testing.T) {
+	// Test vectors from EIP-55.
+	for _, s := range []string{
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"0xD1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		ea, err := eth.ParseEthAddress(s)
+		require.NoError(t, err)
+		assert.Equal(t, s, ea.String())
+
+		lower, err := eth.ParseEthAddress("0x" + s[2:len(s)-1] + "x")
+		assert.Error(t, err)
+		assert.Equal(t, eth.EthAddress{}, lower)
+	}
+
+	_, err := eth.ParseEthAddress("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed")
+	assert.NoError(t, err)
+	_, err = eth.ParseEthAddress("0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	assert.Error(t, err)
+	_, err = eth.ParseEthAddress("5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	assert.Error(t, err)
+
+	ea, err := eth.ParseEthAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	require.NoError(t, err)
+	j, err := json.Marshal(ea)
+	require.NoError(t, err)
+	assert.Equal(t, `"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"`, string(j))
+	var decoded eth.EthAddress
+	require.NoError(t, json.Unmarshal(j, &decoded))
+	assert.Equal(t, ea, decoded)
+}
+
+func TestEthAddressConversion(t *testing.T) {
+	ea, err := eth.ParseEthAddress("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	require.NoError(t, err)
+	_, isID := ea.ActorID()
+	assert.False(t, isID)
+
+	f4, err := ea.ToFilecoinAddress()
+	require.NoError(t, err)
+	assert.Equal(t, addr.Delegated, f4.Protocol())
+	back, err := eth.EthAddressFromFilecoinAddress(f4)
+	require.NoError(t, err)
+	assert.Equal(t, ea, back)
+
+	masked := eth.NewMaskedIDAddress(1234)
+	expectedMasked, err := eth.ParseEthAddress("0xff000000000000000000000000000000000004d2")
+	require.NoError(t, err)
+	assert.Equal(t, expectedMasked, masked)
+	id, isID := masked.ActorID()
+	assert.True(t, isID)
+	assert.Equal(t, abi.ActorID(1234), id)
+
+	f0, err := masked.ToFilecoinAddress()
+	require.NoError(t, err)
+	expected, err := addr.NewIDAddress(1234)
+	require.NoError(t, err)
+	assert.Equal(t, expected, f0)
+	back, err = eth.EthAddressFromFilecoinAddress(f0)
+	require.NoError(t, err)
+	assert.Equal(t, masked, back)
+
+	otherNamespace, err := addr.NewDelegatedAddress(32, ea[:])
+	require.NoError(t, err)
+	_, err = eth.EthAddressFromFilecoinAddress(otherNamespace)
+	assert.Error(t, err)
+}
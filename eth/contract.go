@@ -0,0 +1,53 @@
+package eth
+
+import (
+	"encoding/binary"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// The Ethereum address manager assigns each contract it creates the Ethereum address the EVM would, and the
+// contract's delegated address has that Ethereum address as its sub-address.
+
+// NewContractAddress returns the address of a contract created with CREATE by sender with some nonce:
+// the last 20 bytes of the Keccak-256 hash of the RLP encoding of [sender, nonce].
+func NewContractAddress(sender EthAddress, nonce uint64) EthAddress {
+	// The list payload is at most 30 bytes, so the list and its items need only short RLP headers.
+	payload := make([]byte, 0, 1+EthAddressLength+9)
+	payload = append(payload, 0x80+EthAddressLength)
+	payload = append(payload, sender[:]...)
+	switch {
+	case nonce == 0:
+		payload = append(payload, 0x80)
+	case nonce < 0x80:
+		payload = append(payload, byte(nonce))
+	default:
+		var n [8]byte
+		binary.BigEndian.PutUint64(n[:], nonce)
+		i := 0
+		for n[i] == 0 {
+			i++
+		}
+		payload = append(payload, 0x80+byte(len(n)-i))
+		payload = append(payload, n[i:]...)
+	}
+	return keccakAddress([]byte{0xc0 + byte(len(payload))}, payload)
+}
+
+// NewContract2Address returns the address of a contract created with CREATE2 by sender: the last 20 bytes of
+// the Keccak-256 hash of 0xff, sender, salt and the Keccak-256 hash of the init code.
+func NewContract2Address(sender EthAddress, salt [32]byte, initCode []byte) EthAddress {
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write(initCode)
+	return keccakAddress([]byte{0xff}, sender[:], salt[:], h.Sum(nil))
+}
+
+func keccakAddress(parts ...[]byte) EthAddress {
+	h := sha3.NewLegacyKeccak256()
+	for _, p := range parts {
+		_, _ = h.Write(p)
+	}
+	var ea EthAddress
+	copy(ea[:], h.Sum(nil)[32-EthAddressLength:])
+	return ea
+}
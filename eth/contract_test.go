@@ -0,0 +1,44 @@
+package eth_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/eth"
+)
+
+func TestNewContractAddress(t *testing.T) {
+	sender, err := eth.ParseEthAddress("0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0")
+	require.NoError(t, err)
+	for nonce, expected := range []string{
+		"0xcd234a471b72ba2f1ccf0a70fcaba648a5eecd8d",
+		"0x343c43a37d37dff08ae8c4a11544c718abb4fcf8",
+		"0xf778b86fa74e846c4f0a1fbd1335fe81c00a0c91",
+		"0xfffd933a0bc612844eaf0c6fe3e5b8e9b6c1d19c",
+	} {
+		ea, err := eth.ParseEthAddress(expected)
+		require.NoError(t, err)
+		assert.Equal(t, ea, eth.NewContractAddress(sender, uint64(nonce)))
+	}
+	// Nonces of one byte or more are length-prefixed.
+	assert.NotEqual(t, eth.NewContractAddress(sender, 0x7f), eth.NewContractAddress(sender, 0x80))
+	assert.NotEqual(t, eth.NewContractAddress(sender, 0x100), eth.NewContractAddress(sender, 0x10000))
+}
+
+func TestNewContract2Address(t *testing.T) {
+	// Test vectors from EIP-1014.
+	for _, tc := range []struct {
+		sender, expected string
+		initCode         []byte
+	}{
+		{"0x0000000000000000000000000000000000000000", "0x4D1A2e2bB4F88F0250f26Ffff098B0b30B26BF38", []byte{0x00}},
+		{"0xdeadbeef00000000000000000000000000000000", "0xB928f69Bb1D91Cd65274e3c79d8986362984fDA3", []byte{0x00}},
+		{"0x0000000000000000000000000000000000000000", "0xE33C0C7F7df4809055C3ebA6c09CFe4BaF1BD9e0", nil},
+	} {
+		sender, err := eth.ParseEthAddress(tc.sender)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, eth.NewContract2Address(sender, [32]byte{}, tc.initCode).String())
+	}
+}
@@ -0,0 +1,111 @@
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/xerrors"
+)
+
+// ActorError is an error terminating an actor invocation, carrying the exit code to be recorded in the
+// message receipt.
+//
+// A fatal error indicates a failure of the VM itself (e.g. a missing state object) rather than of the
+// message, and must not be recorded in a receipt; it carries no meaningful exit code.
+type ActorError struct {
+	fatal bool
+	code  ExitCode
+	cause error
+}
+
+// Newf returns a non-fatal error with the given exit code. The message is formatted as by xerrors.Errorf,
+// so may wrap an underlying error with %w.
+func Newf(code ExitCode, format string, args ...interface{}) *ActorError {
+	return &ActorError{
+		code:  code,
+		cause: xerrors.Errorf(format, args...),
+	}
+}
+
+// Fatalf returns a fatal error.
+func Fatalf(format string, args ...interface{}) *ActorError {
+	return &ActorError{
+		fatal: true,
+		code:  Ok,
+		cause: xerrors.Errorf(format, args...),
+	}
+}
+
+// FromError converts an error into an ActorError. An ActorError anywhere in the chain of err is returned
+// as-is; otherwise the exit code is extracted from err as by Unwrap, defaulting to defaultExitCode.
+// A nil error converts to nil.
+func FromError(err error, defaultExitCode ExitCode) *ActorError {
+	if err == nil {
+		return nil
+	}
+	var aerr *ActorError
+	if errors.As(err, &aerr) {
+		return aerr
+	}
+	return &ActorError{
+		code:  Unwrap(err, defaultExitCode),
+		cause: err,
+	}
+}
+
+// FromExitCode returns an error for a receipt exit code, or nil if the code indicates success.
+func FromExitCode(code ExitCode) *ActorError {
+	if code.IsSuccess() {
+		return nil
+	}
+	return &ActorError{
+		code:  code,
+		cause: errors.New(code.String()),
+	}
+}
+
+// RetCode returns the exit code to be recorded in the receipt.
+func (e *ActorError) RetCode() ExitCode {
+	return e.code
+}
+
+// IsFatal returns whether the error indicates a failure of the VM rather than of the message.
+func (e *ActorError) IsFatal() bool {
+	return e.fatal
+}
+
+// IsRetriable returns whether the same message might succeed if applied again to a different state.
+// This is the case for fatal errors, and for send failures (see ExitCode.IsSendFailure), for which the
+// sender's call sequence number is not consumed.
+func (e *ActorError) IsRetriable() bool {
+	return e.fatal || e.code.IsSendFailure()
+}
+
+func (e *ActorError) Error() string {
+	if e.fatal {
+		return fmt.Sprintf("fatal error: %s", e.cause)
+	}
+	return fmt.Sprintf("%s (RetCode=%s)", e.cause, e.code)
+}
+
+// Unwrap returns the underlying error.
+func (e *ActorError) Unwrap() error {
+	return e.cause
+}
+
+// implements the interface required by errors.As, exposing the exit code of a non-fatal error
+func (e *ActorError) As(target interface{}) bool {
+	if _, ok := target.(*ExitCode); ok && e.fatal {
+		return false
+	}
+	return errors.As(e.code, target)
+}
+
+// implements the interface required by errors.Is
+func (e *ActorError) Is(target error) bool {
+	if code, ok := target.(ExitCode); ok {
+		// As for wrapped exit codes, this error's code shadows any in the chain of its cause.
+		return !e.fatal && e.code == code
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package exitcode_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/exitcode"
+)
+
+func TestActorError(t *testing.T) {
+	baseErr := errors.New("base error")
+	aerr := exitcode.Newf(exitcode.ErrNotFound, "no such thing: %w", baseErr)
+	wrappedErr := xerrors.Errorf("wrapper: %w", aerr)
+
+	assert.Equal(t, exitcode.ErrNotFound, aerr.RetCode())
+	assert.False(t, aerr.IsFatal())
+	assert.False(t, aerr.IsRetriable())
+	assert.Equal(t, "no such thing: base error (RetCode=17)", aerr.Error())
+
+	assert.True(t, errors.Is(wrappedErr, baseErr))
+	assert.True(t, errors.Is(wrappedErr, exitcode.ErrNotFound))
+	assert.False(t, errors.Is(wrappedErr, exitcode.ErrForbidden))
+	assert.Equal(t, exitcode.ErrNotFound, exitcode.Unwrap(wrappedErr, exitcode.Ok))
+	assert.Same(t, aerr, exitcode.FromError(wrappedErr, exitcode.ErrIllegalState))
+
+	// Conversion of other errors.
+	assert.Nil(t, exitcode.FromError(nil, exitcode.ErrIllegalState))
+	assert.Equal(t, exitcode.ErrIllegalState, exitcode.FromError(baseErr, exitcode.ErrIllegalState).RetCode())
+	coded := exitcode.ErrForbidden.Wrapf("coded: %w", baseErr)
+	assert.Equal(t, exitcode.ErrForbidden, exitcode.FromError(coded, exitcode.ErrIllegalState).RetCode())
+
+	// Conversion from receipts.
+	assert.Nil(t, exitcode.FromExitCode(exitcode.Ok))
+	sendFailure := exitcode.FromExitCode(exitcode.SysErrSenderStateInvalid)
+	assert.Equal(t, exitcode.SysErrSenderStateInvalid, sendFailure.RetCode())
+	assert.True(t, sendFailure.IsRetriable())
+
+	// Fatal errors carry no exit code.
+	fatal := exitcode.Fatalf("state missing: %w", baseErr)
+	assert.True(t, fatal.IsFatal())
+	assert.True(t, fatal.IsRetriable())
+	assert.True(t, errors.Is(fatal, baseErr))
+	assert.Equal(t, exitcode.ErrIllegalState, exitcode.Unwrap(fatal, exitcode.ErrIllegalState))
+}
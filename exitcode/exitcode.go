@@ -28,9 +28,10 @@ func (x ExitCode) IsSendFailure() bool {
 }
 
 // A non-canonical string representation for human inspection.
+// Only system codes are named, since actors may redefine the common codes.
 func (x ExitCode) String() string {
-	name, ok := names[x]
-	if ok {
+	name, ok := exitCodeNames[x]
+	if ok && x < FirstActorErrorCode {
 		return fmt.Sprintf("%s(%d)", name, x)
 	}
 	return strconv.FormatInt(int64(x), 10)
@@ -0,0 +1,57 @@
+package exitcode
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"golang.org/x/xerrors"
+)
+
+// Exit codes by canonical name.
+var codesByName = func() map[string]ExitCode {
+	m := make(map[string]ExitCode, len(exitCodeNames))
+	for code, name := range exitCodeNames {
+		m[name] = code
+	}
+	return m
+}()
+
+// Name returns the canonical name of a system or common exit code, such as "SysErrOutOfGas" or
+// "ErrIllegalArgument". Actor-specific codes have no name.
+func (x ExitCode) Name() (string, bool) {
+	name, ok := exitCodeNames[x]
+	return name, ok
+}
+
+// FromName returns the exit code with a canonical name.
+func FromName(name string) (ExitCode, bool) {
+	code, ok := codesByName[name]
+	return code, ok
+}
+
+// MarshalJSON writes the exit code as its canonical name if it has one, otherwise as a number.
+func (x ExitCode) MarshalJSON() ([]byte, error) {
+	if name, ok := x.Name(); ok {
+		return json.Marshal(name)
+	}
+	return []byte(strconv.FormatInt(int64(x), 10)), nil
+}
+
+// UnmarshalJSON reads an exit code from a canonical name or a number.
+func (x *ExitCode) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		code, ok := FromName(name)
+		if !ok {
+			return xerrors.Errorf("unknown exit code name %q", name)
+		}
+		*x = code
+		return nil
+	}
+	var n int64
+	if err := json.Unmarshal(b, &n); err != nil {
+		return xerrors.Errorf("exit code must be a name or an integer: %w", err)
+	}
+	*x = ExitCode(n)
+	return nil
+}
@@ -0,0 +1,41 @@
+package exitcode_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/exitcode"
+)
+
+func TestExitCodeJSON(t *testing.T) {
+	for code, expected := range map[exitcode.ExitCode]string{
+		exitcode.Ok:                 `"Ok"`,
+		exitcode.SysErrOutOfGas:     `"SysErrOutOfGas"`,
+		exitcode.ErrIllegalArgument: `"ErrIllegalArgument"`,
+		exitcode.ExitCode(33):       `33`,
+		exitcode.ExitCode(-1):       `-1`,
+	} {
+		b, err := json.Marshal(code)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(b))
+
+		var decoded exitcode.ExitCode
+		require.NoError(t, json.Unmarshal(b, &decoded))
+		assert.Equal(t, code, decoded)
+	}
+
+	var decoded exitcode.ExitCode
+	require.NoError(t, json.Unmarshal([]byte(`18`), &decoded))
+	assert.Equal(t, exitcode.ErrForbidden, decoded)
+	assert.Error(t, json.Unmarshal([]byte(`"ErrUnknown"`), &decoded))
+	assert.Error(t, json.Unmarshal([]byte(`1.5`), &decoded))
+
+	name, ok := exitcode.SysErrForbidden.Name()
+	assert.True(t, ok)
+	assert.Equal(t, "SysErrForbidden", name)
+	_, ok = exitcode.ExitCode(40).Name()
+	assert.False(t, ok)
+}
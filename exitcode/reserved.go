@@ -59,22 +59,3 @@ const (
 // The initial range of exit codes is reserved for system errors.
 // Actors may define codes starting with this one.
 const FirstActorErrorCode = ExitCode(16)
-
-var names = map[ExitCode]string{
-	Ok:                       "Ok",
-	SysErrSenderInvalid:      "SysErrSenderInvalid",
-	SysErrSenderStateInvalid: "SysErrSenderStateInvalid",
-	SysErrInvalidMethod:      "SysErrInvalidMethod",
-	SysErrReserved1:          "SysErrReserved1",
-	SysErrInvalidReceiver:    "SysErrInvalidReceiver",
-	SysErrInsufficientFunds:  "SysErrInsufficientFunds",
-	SysErrOutOfGas:           "SysErrOutOfGas",
-	SysErrForbidden:          "SysErrForbidden",
-	SysErrorIllegalActor:     "SysErrorIllegalActor",
-	SysErrorIllegalArgument:  "SysErrorIllegalArgument",
-	SysErrReserved2:          "SysErrReserved2",
-	SysErrReserved3:          "SysErrReserved3",
-	SysErrReserved4:          "SysErrReserved4",
-	SysErrReserved5:          "SysErrReserved5",
-	SysErrReserved6:          "SysErrReserved6",
-}
@@ -0,0 +1,133 @@
+// Command bundles generates the table of released actors bundle manifest CIDs from manifest/releases.json.
+// Run it from the module root. To record a release, pass the bundle CARs published with it, e.g.
+//
+//	go run ./gen/bundles -version 12 -network mainnet builtin-actors-mainnet.car
+//
+// which reads each bundle, adds its manifest CID to releases.json (replacing any previous entry for the same
+// version and network), and regenerates the table.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+
+	"github.com/filecoin-project/go-state-types/manifest"
+)
+
+const (
+	releasesFile = "manifest/releases.json"
+	tableFile    = "manifest/releases_gen.go"
+)
+
+// A released bundle.
+type release struct {
+	Version  int    `json:"version"`
+	Network  string `json:"network"`
+	Manifest string `json:"manifest"`
+}
+
+func main() {
+	version := flag.Int("version", -1, "actors version of the bundles to add")
+	network := flag.String("network", "", "network preset of the bundle to add")
+	flag.Parse()
+
+	if err := run(*version, *network, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(version int, network string, cars []string) error {
+	releases, err := readReleases()
+	if err != nil {
+		return err
+	}
+	if len(cars) > 1 {
+		return fmt.Errorf("a network has one bundle per version, got %d", len(cars))
+	}
+	for _, car := range cars {
+		if version < 0 || network == "" {
+			return fmt.Errorf("-version and -network are required to add a bundle")
+		}
+		f, err := os.Open(car)
+		if err != nil {
+			return err
+		}
+		b, err := manifest.ReadBundle(f)
+		_ = f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", car, err)
+		}
+		releases = addRelease(releases, release{Version: version, Network: network, Manifest: b.ManifestCid.String()})
+	}
+	if len(cars) > 0 {
+		if err := writeReleases(releases); err != nil {
+			return err
+		}
+	}
+	return writeTable(releases)
+}
+
+func readReleases() ([]release, error) {
+	b, err := os.ReadFile(releasesFile)
+	if err != nil {
+		return nil, err
+	}
+	var releases []release
+	if err := json.Unmarshal(b, &releases); err != nil {
+		return nil, fmt.Errorf("%s: %w", releasesFile, err)
+	}
+	return releases, nil
+}
+
+func addRelease(releases []release, r release) []release {
+	for i := range releases {
+		if releases[i].Version == r.Version && releases[i].Network == r.Network {
+			releases[i] = r
+			return releases
+		}
+	}
+	releases = append(releases, r)
+	sort.Slice(releases, func(i, j int) bool {
+		if releases[i].Version != releases[j].Version {
+			return releases[i].Version < releases[j].Version
+		}
+		return releases[i].Network < releases[j].Network
+	})
+	return releases
+}
+
+func writeReleases(releases []release) error {
+	b, err := json.MarshalIndent(releases, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(releasesFile, append(b, '\n'), 0o644)
+}
+
+func writeTable(releases []release) error {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by gen/bundles. DO NOT EDIT.\n\npackage manifest\n\n")
+	buf.WriteString("import (\n\t\"github.com/filecoin-project/go-state-types/abi\"\n\t\"github.com/filecoin-project/go-state-types/actors\"\n)\n\n")
+	buf.WriteString("// Manifest CIDs of the released actors bundles, by actors version and network preset.\n")
+	buf.WriteString("var releasedManifests = map[actors.Version]map[abi.NetworkPreset]string{\n")
+	for i := 0; i < len(releases); {
+		v := releases[i].Version
+		fmt.Fprintf(&buf, "\t%d: {\n", v)
+		for ; i < len(releases) && releases[i].Version == v; i++ {
+			fmt.Fprintf(&buf, "\t\t%q: %q,\n", releases[i].Network, releases[i].Manifest)
+		}
+		buf.WriteString("\t},\n")
+	}
+	buf.WriteString("}\n")
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return os.WriteFile(tableFile, src, 0o644)
+}
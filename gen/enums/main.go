@@ -0,0 +1,210 @@
+// Command enums generates name tables and String methods for the enumerated types of this module from their
+// constant declarations, which are the only source of truth for the names. Run it from the module root.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// An enumerated type, named by its constants of that type.
+type enum struct {
+	// Name of the type.
+	Type string
+	// Prefix trimmed from constant names to form the names of values.
+	TrimPrefix string
+	// Constants of the type that are not names of values, such as bounds and aliases.
+	Skip []string
+	// Whether to generate a String method, rather than only the table.
+	Stringer bool
+}
+
+// A package of enumerated types, for which one file is generated.
+type pkg struct {
+	Dir   string
+	Enums []enum
+}
+
+var pkgs = []pkg{
+	{Dir: "abi", Enums: []enum{
+		{Type: "RegisteredSealProof", TrimPrefix: "RegisteredSealProof_", Stringer: true},
+		{Type: "RegisteredPoStProof", TrimPrefix: "RegisteredPoStProof_", Stringer: true},
+		{Type: "RegisteredAggregationProof", TrimPrefix: "RegisteredAggregationProof_", Stringer: true},
+		{Type: "RegisteredUpdateProof", TrimPrefix: "RegisteredUpdateProof_", Stringer: true},
+	}},
+	{Dir: "crypto", Enums: []enum{
+		{Type: "SigType", TrimPrefix: "SigType", Stringer: true},
+		// DomainSeparationTag has a hand-written String method, which includes registered tags.
+		{Type: "DomainSeparationTag", TrimPrefix: "DomainSeparationTag_", Skip: []string{"FirstUserDomainSeparationTag"}},
+	}},
+	{Dir: "exitcode", Enums: []enum{
+		// ExitCode has a hand-written String method, which includes the number.
+		{Type: "ExitCode", Skip: []string{"FirstActorErrorCode", "FirstActorSpecificExitCode"}},
+	}},
+	{Dir: "network", Enums: []enum{
+		{Type: "Version", Skip: []string{"VersionCount", "VersionLatest", "VersionNext", "VersionMax"}, Stringer: true},
+		{Type: "GasSchedule", TrimPrefix: "GasSchedule", Stringer: true},
+	}},
+}
+
+func main() {
+	for _, p := range pkgs {
+		if err := generate(p); err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", p.Dir, err)
+			os.Exit(1)
+		}
+	}
+}
+
+func generate(p pkg) error {
+	fset := token.NewFileSet()
+	files, err := parseDir(fset, p.Dir)
+	if err != nil {
+		return err
+	}
+	// Only constant values are needed, so imports outside the standard library are stubbed out and the
+	// resulting type errors ignored.
+	conf := types.Config{Importer: stubImporter{importer.ForCompiler(fset, "source", nil)}, Error: func(error) {}}
+	tpkg, _ := conf.Check(p.Dir, fset, files, nil)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen/enums. DO NOT EDIT.\n\npackage %s\n\n", tpkg.Name())
+	if anyStringer(p.Enums) {
+		buf.WriteString("import \"strconv\"\n\n")
+	}
+	for _, e := range p.Enums {
+		consts, err := enumConsts(fset, tpkg, e)
+		if err != nil {
+			return err
+		}
+		writeEnum(&buf, e, consts)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return os.WriteFile(filepath.Join(p.Dir, "enum_names_gen.go"), src, 0o644)
+}
+
+func parseDir(fset *token.FileSet, dir string) ([]*ast.File, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+	var files []*ast.File
+	for _, name := range names {
+		if strings.HasSuffix(name, "_test.go") || strings.HasSuffix(name, "enum_names_gen.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+type namedConst struct {
+	Name  string
+	Value int64
+}
+
+// Returns the constants naming each value of an enumerated type, in order of value. Where several constants
+// have the same value, the first declared names it.
+func enumConsts(fset *token.FileSet, tpkg *types.Package, e enum) ([]namedConst, error) {
+	skip := map[string]bool{}
+	for _, s := range e.Skip {
+		skip[s] = true
+	}
+	var objs []*types.Const
+	for _, name := range tpkg.Scope().Names() {
+		c, ok := tpkg.Scope().Lookup(name).(*types.Const)
+		if !ok || skip[name] {
+			continue
+		}
+		named, ok := c.Type().(*types.Named)
+		if !ok || named.Obj().Name() != e.Type || named.Obj().Pkg() != tpkg {
+			continue
+		}
+		objs = append(objs, c)
+	}
+	if len(objs) == 0 {
+		return nil, fmt.Errorf("no constants of type %s", e.Type)
+	}
+	sort.Slice(objs, func(i, j int) bool {
+		pi, pj := fset.Position(objs[i].Pos()), fset.Position(objs[j].Pos())
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		return pi.Offset < pj.Offset
+	})
+
+	seen := map[int64]bool{}
+	var consts []namedConst
+	for _, c := range objs {
+		v, exact := constant.Int64Val(c.Val())
+		if !exact {
+			return nil, fmt.Errorf("constant %s is not an integer", c.Name())
+		}
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		consts = append(consts, namedConst{Name: c.Name(), Value: v})
+	}
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Value < consts[j].Value })
+	return consts, nil
+}
+
+func writeEnum(buf *bytes.Buffer, e enum, consts []namedConst) {
+	table := strings.ToLower(e.Type[:1]) + e.Type[1:] + "Names"
+	fmt.Fprintf(buf, "// Names of the values of %s.\n", e.Type)
+	fmt.Fprintf(buf, "var %s = map[%s]string{\n", table, e.Type)
+	for _, c := range consts {
+		fmt.Fprintf(buf, "\t%s: %q,\n", c.Name, strings.TrimPrefix(c.Name, e.TrimPrefix))
+	}
+	buf.WriteString("}\n\n")
+	if !e.Stringer {
+		return
+	}
+	recv := strings.ToLower(e.Type[:1])
+	fmt.Fprintf(buf, "// String returns the name of the value, or the type and number if it has none.\n")
+	fmt.Fprintf(buf, "func (%s %s) String() string {\n", recv, e.Type)
+	fmt.Fprintf(buf, "\tif name, ok := %s[%s]; ok {\n\t\treturn name\n\t}\n", table, recv)
+	fmt.Fprintf(buf, "\treturn \"%s(\" + strconv.FormatInt(int64(%s), 10) + \")\"\n}\n\n", e.Type, recv)
+}
+
+func anyStringer(enums []enum) bool {
+	for _, e := range enums {
+		if e.Stringer {
+			return true
+		}
+	}
+	return false
+}
+
+// Imports the standard library from source, and stubs out other packages as empty.
+type stubImporter struct {
+	std types.Importer
+}
+
+func (i stubImporter) Import(path string) (*types.Package, error) {
+	if !strings.Contains(strings.Split(path, "/")[0], ".") {
+		return i.std.Import(path)
+	}
+	p := types.NewPackage(path, filepath.Base(path))
+	p.MarkComplete()
+	return p, nil
+}
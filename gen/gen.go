@@ -4,6 +4,21 @@ import (
 	gen "github.com/whyrusleeping/cbor-gen"
 
 	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/datacap"
+	"github.com/filecoin-project/go-state-types/builtin/eam"
+	"github.com/filecoin-project/go-state-types/builtin/market"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/builtin/multisig"
+	"github.com/filecoin-project/go-state-types/builtin/power"
+	"github.com/filecoin-project/go-state-types/builtin/reward"
+	"github.com/filecoin-project/go-state-types/builtin/smoothing"
+	"github.com/filecoin-project/go-state-types/builtin/verifreg"
+	"github.com/filecoin-project/go-state-types/chain"
+	"github.com/filecoin-project/go-state-types/crypto"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/filecoin-project/go-state-types/proof"
+	"github.com/filecoin-project/go-state-types/statetree"
 )
 
 func main() {
@@ -11,6 +26,177 @@ func main() {
 	if err := gen.WriteTupleEncodersToFile("./abi/cbor_gen.go", "abi",
 		abi.PieceInfo{},
 		abi.SectorID{},
+		abi.Message{},
+		abi.Event{},
+		abi.EventEntry{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Common types encoded as maps keyed by field name, for consumers outside of Go (such as tracing and
+	// indexing services) that cannot rely on field order. Only types that are never committed to chain state
+	// may be moved here: events and manifests are hashed into state, so stay tuple-encoded.
+	if err := gen.WriteMapEncodersToFile("./abi/cbor_map_gen.go", "abi",
+		abi.ExecutionTrace{},
+		abi.MessageTrace{},
+		abi.ReturnTrace{},
+		abi.GasTrace{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Chain types
+	if err := gen.WriteTupleEncodersToFile("./chain/cbor_gen.go", "chain",
+		chain.SignedMessage{},
+	); err != nil {
+		panic(err)
+	}
+
+	// State tree types
+	if err := gen.WriteTupleEncodersToFile("./statetree/cbor_gen.go", "statetree",
+		statetree.StateRoot{},
+		statetree.ActorV4{},
+		statetree.ActorV5{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Proof types
+	if err := gen.WriteTupleEncodersToFile("./proof/cbor_gen.go", "proof",
+		proof.SealVerifyInfo{},
+		proof.AggregateSealVerifyInfo{},
+		proof.AggregateSealVerifyProofAndInfos{},
+		proof.PoStProof{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Crypto types
+	if err := gen.WriteTupleEncodersToFile("./crypto/cbor_gen.go", "crypto",
+		crypto.BeaconEntry{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Smoothing filter types
+	if err := gen.WriteTupleEncodersToFile("./builtin/smoothing/cbor_gen.go", "smoothing",
+		smoothing.FilterEstimate{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Builtin actor common types
+	if err := gen.WriteTupleEncodersToFile("./builtin/cbor_gen.go", "builtin",
+		builtin.BatchReturn{},
+		builtin.FailCode{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Storage miner actor types
+	if err := gen.WriteTupleEncodersToFile("./builtin/miner/cbor_gen.go", "miner",
+		miner.MinerInfo{},
+		miner.MinerInfo3{},
+		miner.MinerInfo2{},
+		miner.MinerInfo0{},
+		miner.WorkerKeyChange{},
+		miner.BeneficiaryTerm{},
+		miner.PendingBeneficiaryChange{},
+		miner.ReportConsensusFaultParams{},
+		miner.FaultDeclaration{},
+		miner.DeclareFaultsParams{},
+		miner.RecoveryDeclaration{},
+		miner.DeclareFaultsRecoveredParams{},
+		miner.SectorClaim{},
+		miner.ExpirationExtension2{},
+		miner.ExtendSectorExpiration2Params{},
+		miner.VerifiedAllocationKey{},
+		miner.DataActivationNotification{},
+		miner.PieceActivationManifest{},
+		miner.SectorUpdateManifest{},
+		miner.ProveReplicaUpdates3Params{},
+		miner.SectorActivationManifest{},
+		miner.ProveCommitSectors3Params{},
+		miner.DisputeWindowedPoStParams{},
+		miner.WindowedPoSt{},
+		miner.SectorOnChainInfo{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Storage power actor types
+	if err := gen.WriteTupleEncodersToFile("./builtin/power/cbor_gen.go", "power",
+		power.CronEvent{},
+		power.EnrollCronEventParams{},
+		power.ConfirmSectorProofsParams{},
+		power.ProofValidationBatchEntry{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Ethereum address manager actor types
+	if err := gen.WriteTupleEncodersToFile("./builtin/eam/cbor_gen.go", "eam",
+		eam.CreateParams{},
+		eam.Create2Params{},
+		eam.CreateReturn{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Storage market actor types
+	if err := gen.WriteTupleEncodersToFile("./builtin/market/cbor_gen.go", "market",
+		market.DealState{},
+		market.OnMinerSectorsTerminateParams{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Multisig actor types
+	if err := gen.WriteTupleEncodersToFile("./builtin/multisig/cbor_gen.go", "multisig",
+		multisig.State{},
+		multisig.Transaction{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Reward actor types
+	if err := gen.WriteTupleEncodersToFile("./builtin/reward/cbor_gen.go", "reward",
+		reward.StateV0{},
+		reward.State{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Verified registry actor types
+	if err := gen.WriteTupleEncodersToFile("./builtin/verifreg/cbor_gen.go", "verifreg",
+		verifreg.RmDcProposalID{},
+		verifreg.RemoveDataCapProposal{},
+		verifreg.RemoveDataCapRequest{},
+		verifreg.RemoveDataCapParams{},
+		verifreg.RemoveDataCapReturn{},
+		verifreg.Allocation{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Datacap actor types
+	if err := gen.WriteTupleEncodersToFile("./builtin/datacap/cbor_gen.go", "datacap",
+		datacap.TransferParams{},
+		datacap.TransferReturn{},
+		datacap.TransferFromParams{},
+		datacap.TransferFromReturn{},
+		datacap.UniversalReceiverParams{},
+		datacap.FRC46TokenReceived{},
+		datacap.State{},
+		datacap.TokenState{},
+	); err != nil {
+		panic(err)
+	}
+
+	// Actor bundle manifest types
+	if err := gen.WriteTupleEncodersToFile("./manifest/cbor_gen.go", "manifest",
+		manifest.Manifest{},
+		manifest.ManifestEntry{},
 	); err != nil {
 		panic(err)
 	}
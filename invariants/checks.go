@@ -0,0 +1,163 @@
+package invariants
+
+import (
+	"context"
+
+	addr "github.com/filecoin-project/go-address"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/adt"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/datacap"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/cbor"
+	"github.com/filecoin-project/go-state-types/statetree"
+)
+
+// BalanceSum checks that every actor's balance is non-negative, and that the balances of all actors sum to the
+// total supply of FIL (which includes the unminted FIL held by the reward actor, and burnt FIL).
+// A BalanceSum accumulates across calls, so a new one must be used for each run.
+type BalanceSum struct {
+	// The expected sum of balances. If nil, big.TotalFilecoinSupply applies.
+	Total abi.TokenAmount
+
+	sum abi.TokenAmount
+}
+
+var _ Check = (*BalanceSum)(nil)
+
+func (c *BalanceSum) Name() string {
+	return "BalanceSum"
+}
+
+func (c *BalanceSum) Visit(_ context.Context, _ ipldcbor.IpldStore, a addr.Address, act *statetree.Actor, r *Reporter) error {
+	if c.sum.Nil() {
+		c.sum = big.Zero()
+	}
+	if act.Balance.Nil() || act.Balance.LessThan(big.Zero()) {
+		r.Addf(a, "invalid balance %v", act.Balance)
+		return nil
+	}
+	c.sum = big.Add(c.sum, act.Balance)
+	return nil
+}
+
+func (c *BalanceSum) Done(r *Reporter) error {
+	total := c.Total
+	if total.Nil() {
+		total = big.TotalFilecoinSupply()
+	}
+	sum := c.sum
+	if sum.Nil() {
+		sum = big.Zero()
+	}
+	if !sum.Equals(total) {
+		r.Addf(addr.Undef, "balances sum to %s, expected %s", sum, total)
+	}
+	return nil
+}
+
+// DataCapConservation checks that the datacap balances held sum to the datacap token supply, and that no
+// balance is negative.
+type DataCapConservation struct{}
+
+var _ Check = DataCapConservation{}
+
+func (DataCapConservation) Name() string {
+	return "DataCapConservation"
+}
+
+func (DataCapConservation) Visit(ctx context.Context, store ipldcbor.IpldStore, a addr.Address, act *statetree.Actor, r *Reporter) error {
+	if a != builtin.DatacapActorAddr {
+		return nil
+	}
+	var st datacap.State
+	if err := store.Get(ctx, act.Head, &st); err != nil {
+		r.Addf(a, "failed to load state: %s", err)
+		return nil
+	}
+	sum := big.Zero()
+	if err := adt.ForEachHamtEntry(ctx, store, st.Token.Balances, func(k, v []byte) error {
+		var balance abi.TokenAmount
+		if _, err := cbor.Unmarshal(v, &balance); err != nil {
+			r.Addf(a, "failed to decode balance with key %x: %s", k, err)
+			return nil
+		}
+		if balance.LessThan(big.Zero()) {
+			r.Addf(a, "negative balance %s with key %x", balance, k)
+		}
+		sum = big.Add(sum, balance)
+		return nil
+	}); err != nil {
+		r.Addf(a, "failed to load balances: %s", err)
+		return nil
+	}
+	if !sum.Equals(st.Token.Supply) {
+		r.Addf(a, "balances sum to %s, but supply is %s", sum, st.Token.Supply)
+	}
+	return nil
+}
+
+func (DataCapConservation) Done(*Reporter) error {
+	return nil
+}
+
+// DeadlinesConsistency checks that each miner's deadlines and partitions can be loaded, and that no sector is
+// assigned to more than one partition.
+type DeadlinesConsistency struct{}
+
+var _ Check = DeadlinesConsistency{}
+
+func (DeadlinesConsistency) Name() string {
+	return "DeadlinesConsistency"
+}
+
+func (DeadlinesConsistency) Visit(ctx context.Context, store ipldcbor.IpldStore, a addr.Address, act *statetree.Actor, r *Reporter) error {
+	if !builtin.IsStorageMinerActor(act.Code) {
+		return nil
+	}
+	deadlines, err := miner.LoadStateDeadlines(ctx, store, act.Head)
+	if err != nil {
+		r.Addf(a, "failed to load deadlines: %s", err)
+		return nil
+	}
+	seen := map[abi.SectorNumber]miner.SectorLocation{}
+	for dlIdx, dl := range deadlines.Due {
+		partitions, err := miner.LoadDeadlinePartitions(ctx, store, dl)
+		if err != nil {
+			r.Addf(a, "failed to load deadline %d: %s", dlIdx, err)
+			continue
+		}
+		if err := adt.ForEachAmtEntry(ctx, store, partitions, func(partIdx uint64, v []byte) error {
+			sectors, err := miner.DecodePartitionSectors(v)
+			if err != nil {
+				r.Addf(a, "failed to decode deadline %d partition %d: %s", dlIdx, partIdx, err)
+				return nil
+			}
+			loc := miner.SectorLocation{Deadline: uint64(dlIdx), Partition: partIdx}
+			return sectors.ForEach(func(n abi.SectorNumber) error {
+				if prev, ok := seen[n]; ok {
+					r.Addf(a, "sector %d in deadline %d partition %d and deadline %d partition %d",
+						n, prev.Deadline, prev.Partition, loc.Deadline, loc.Partition)
+					return nil
+				}
+				seen[n] = loc
+				return nil
+			})
+		}); err != nil {
+			r.Addf(a, "failed to load deadline %d partitions: %s", dlIdx, err)
+		}
+	}
+	return nil
+}
+
+func (DeadlinesConsistency) Done(*Reporter) error {
+	return nil
+}
+
+// DefaultChecks returns the builtin checks, with the balance sum expecting the mainnet total supply.
+func DefaultChecks() []Check {
+	return []Check{&BalanceSum{}, DataCapConservation{}, DeadlinesConsistency{}}
+}
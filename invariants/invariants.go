@@ -0,0 +1,101 @@
+// Package invariants checks the consistency of actor state across a state tree, such as after a migration
+// or when auditing the chain. A set of checks is run over every actor in the tree, and the violations found
+// are collected in a report rather than stopping the run.
+package invariants
+
+import (
+	"context"
+	"fmt"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/statetree"
+)
+
+// Check is an invariant over the state tree. Visit is called for each actor in the tree, then Done once
+// all actors have been visited. A check reports violations to the reporter; it returns an error only if it
+// cannot proceed, which aborts the run.
+type Check interface {
+	Name() string
+	Visit(ctx context.Context, store ipldcbor.IpldStore, a addr.Address, act *statetree.Actor, r *Reporter) error
+	Done(r *Reporter) error
+}
+
+// Violation describes a broken invariant.
+type Violation struct {
+	// The name of the check which found the violation.
+	Check string
+	// The actor whose state is inconsistent, or addr.Undef if the violation concerns the tree as a whole.
+	Actor   addr.Address
+	Message string
+}
+
+func (v Violation) String() string {
+	if v.Actor == addr.Undef {
+		return fmt.Sprintf("%s: %s", v.Check, v.Message)
+	}
+	return fmt.Sprintf("%s: actor %s: %s", v.Check, v.Actor, v.Message)
+}
+
+// Report is the result of checking a state tree.
+type Report struct {
+	// The state root checked.
+	Root cid.Cid
+	// Number of actors visited.
+	Actors int
+	// Violations found, in the order found.
+	Violations []Violation
+}
+
+// OK returns whether no violations were found.
+func (r *Report) OK() bool {
+	return len(r.Violations) == 0
+}
+
+// Reporter collects the violations found by a check.
+type Reporter struct {
+	check  string
+	report *Report
+}
+
+// Addf records a violation concerning an actor, or the tree as a whole if the address is addr.Undef.
+func (r *Reporter) Addf(a addr.Address, format string, args ...interface{}) {
+	r.report.Violations = append(r.report.Violations, Violation{
+		Check:   r.check,
+		Actor:   a,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// CheckStateTree runs checks over the state tree with some root.
+func CheckStateTree(ctx context.Context, store ipldcbor.IpldStore, root cid.Cid, checks ...Check) (*Report, error) {
+	tree, err := statetree.LoadStateTree(ctx, store, root)
+	if err != nil {
+		return nil, err
+	}
+	report := &Report{Root: root}
+	reporters := make([]*Reporter, len(checks))
+	for i, c := range checks {
+		reporters[i] = &Reporter{check: c.Name(), report: report}
+	}
+	if err := tree.ForEach(ctx, func(a addr.Address, act *statetree.Actor) error {
+		report.Actors++
+		for i, c := range checks {
+			if err := c.Visit(ctx, store, a, act, reporters[i]); err != nil {
+				return xerrors.Errorf("check %s failed at actor %s: %w", c.Name(), a, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	for i, c := range checks {
+		if err := c.Done(reporters[i]); err != nil {
+			return nil, xerrors.Errorf("check %s failed: %w", c.Name(), err)
+		}
+	}
+	return report, nil
+}
@@ -0,0 +1,121 @@
+package invariants_test
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/builtin"
+	"github.com/filecoin-project/go-state-types/builtin/datacap"
+	"github.com/filecoin-project/go-state-types/builtin/miner"
+	"github.com/filecoin-project/go-state-types/invariants"
+	"github.com/filecoin-project/go-state-types/manifest"
+	"github.com/filecoin-project/go-state-types/statetree"
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+func TestCheckStateTree(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+	empty := ipld.PutBlock(t, store, []byte{0x80})
+
+	// A datacap actor whose balances exceed the supply.
+	balances := ipld.PutBlock(t, store, ipld.HamtBucketNode(ipld.HamtEntry([]byte{100}, tokenAmount(t, 5)), ipld.HamtEntry([]byte{101}, tokenAmount(t, 7))))
+	dcState := datacap.State{Governor: builtin.VerifiedRegistryActorAddr, Token: datacap.TokenState{
+		Supply: big.NewInt(10), Balances: balances, Allowances: balances, HamtBitWidth: 5,
+	}}
+	var dcBuf bytes.Buffer
+	require.NoError(t, dcState.MarshalCBOR(&dcBuf))
+
+	// A miner with sector 3 in two partitions.
+	partitions := ipld.PutBlock(t, store, ipld.AmtRoot(6, 0, 0, ipld.AmtNode([]byte{0b11}, nil,
+		partition(t, abi.SectorRangeSetFromNumbers(1, 2, 3)),
+		partition(t, abi.SectorRangeSetFromNumbers(3, 4)),
+	)))
+	emptyPartitions := ipld.PutBlock(t, store, ipld.AmtRoot(6, 0, 0, ipld.AmtNode([]byte{0}, nil)))
+	var deadlines miner.Deadlines
+	for i := range deadlines.Due {
+		deadlines.Due[i] = ipld.PutBlock(t, store, ipld.Tuple(ipld.Link(emptyPartitions)))
+	}
+	deadlines.Due[2] = ipld.PutBlock(t, store, ipld.Tuple(ipld.Link(partitions)))
+	var dlBuf bytes.Buffer
+	require.NoError(t, deadlines.MarshalCBOR(&dlBuf))
+	minerFields := make([][]byte, 15)
+	for i := range minerFields {
+		minerFields[i] = []byte{0x00}
+	}
+	minerFields[12] = ipld.Link(ipld.PutBlock(t, store, dlBuf.Bytes()))
+	minerCode, ok := builtin.GetActorCodeID(actors.Version7, manifest.MinerKey)
+	require.True(t, ok)
+	accountCode, ok := builtin.GetActorCodeID(actors.Version7, manifest.AccountKey)
+	require.True(t, ok)
+
+	root := stateTree(t, store, map[addr.Address]statetree.Actor{
+		idAddr(t, 100):           {Code: accountCode, Head: empty, Balance: big.NewInt(60)},
+		builtin.DatacapActorAddr: {Code: accountCode, Head: ipld.PutBlock(t, store, dcBuf.Bytes()), Balance: big.NewInt(0)},
+		idAddr(t, 1000):          {Code: minerCode, Head: ipld.PutBlock(t, store, ipld.Tuple(minerFields...)), Balance: big.NewInt(40)},
+	})
+
+	report, err := invariants.CheckStateTree(ctx, store, root,
+		&invariants.BalanceSum{Total: big.NewInt(100)}, invariants.DataCapConservation{}, invariants.DeadlinesConsistency{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Actors)
+	require.Len(t, report.Violations, 2)
+	assert.Equal(t, invariants.Violation{Check: "DataCapConservation", Actor: builtin.DatacapActorAddr, Message: "balances sum to 12, but supply is 10"}, report.Violations[0])
+	assert.Equal(t, invariants.Violation{Check: "DeadlinesConsistency", Actor: idAddr(t, 1000), Message: "sector 3 in deadline 2 partition 0 and deadline 2 partition 1"}, report.Violations[1])
+
+	report, err = invariants.CheckStateTree(ctx, store, root, &invariants.BalanceSum{Total: big.NewInt(99)})
+	require.NoError(t, err)
+	assert.False(t, report.OK())
+	assert.Equal(t, []invariants.Violation{{Check: "BalanceSum", Message: "balances sum to 100, expected 99"}}, report.Violations)
+}
+
+func stateTree(t *testing.T, store *ipld.MemStore, actors map[addr.Address]statetree.Actor) cid.Cid {
+	// Entries in a deterministic order, so that violations are reported in a deterministic order.
+	addrs := make([]addr.Address, 0, len(actors))
+	for a := range actors {
+		addrs = append(addrs, a)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+	var entries [][]byte
+	for _, a := range addrs {
+		act := actors[a]
+		b, err := statetree.EncodeActor(statetree.StateTreeVersion4, &act)
+		require.NoError(t, err)
+		entries = append(entries, ipld.HamtEntry(a.Bytes(), b))
+	}
+	sr := statetree.StateRoot{Version: statetree.StateTreeVersion4, Actors: ipld.PutBlock(t, store, ipld.HamtBucketNode(entries...)), Info: ipld.PutBlock(t, store, []byte{0x80})}
+	var buf bytes.Buffer
+	require.NoError(t, sr.MarshalCBOR(&buf))
+	return ipld.PutBlock(t, store, buf.Bytes())
+}
+
+func partition(t *testing.T, sectors abi.SectorRangeSet) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, sectors.MarshalCBOR(&buf))
+	return ipld.Tuple(buf.Bytes())
+}
+
+func tokenAmount(t *testing.T, n int64) []byte {
+	var buf bytes.Buffer
+	amount := big.NewInt(n)
+	require.NoError(t, amount.MarshalCBOR(&buf))
+	return buf.Bytes()
+}
+
+func idAddr(t *testing.T, id uint64) addr.Address {
+	a, err := addr.NewIDAddress(id)
+	require.NoError(t, err)
+	return a
+}
@@ -0,0 +1,167 @@
+package manifest
+
+import (
+	"bytes"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/actors"
+)
+
+// A Bundle is an actors bundle read from a CAR: the manifest and the blocks it references.
+type Bundle struct {
+	ManifestCid cid.Cid
+	Manifest    *Manifest
+	// Raw blocks in the bundle, including the actor code.
+	Blocks map[cid.Cid][]byte
+}
+
+// ReadBundle reads and validates an actors bundle CAR. The CAR must have a single root which is the
+// manifest, the manifest must reference an entry for each builtin actor, and the code for every entry
+// must be present. Every block is verified against its CID.
+func ReadBundle(r io.Reader) (*Bundle, error) {
+	roots, blocks, err := readCar(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(roots) != 1 {
+		return nil, xerrors.Errorf("expected exactly one root in bundle, found %d", len(roots))
+	}
+	root := roots[0]
+
+	raw, err := blocks.GetBlock(root)
+	if err != nil {
+		return nil, xerrors.Errorf("bundle manifest missing: %w", err)
+	}
+	var m Manifest
+	if err := m.UnmarshalCBOR(bytes.NewReader(raw)); err != nil {
+		return nil, xerrors.Errorf("failed to decode manifest %s: %w", root, err)
+	}
+	if err := m.Load(blocks); err != nil {
+		return nil, err
+	}
+
+	for _, name := range GetBuiltinActorsKeys() {
+		if _, ok := m.Get(name); !ok {
+			return nil, xerrors.Errorf("bundle manifest %s has no entry for %s", root, name)
+		}
+	}
+	for name, code := range m.entries {
+		if _, ok := blocks[code]; !ok {
+			return nil, xerrors.Errorf("bundle is missing code %s for %s", code, name)
+		}
+	}
+
+	return &Bundle{
+		ManifestCid: root,
+		Manifest:    &m,
+		Blocks:      blocks,
+	}, nil
+}
+
+// ReadBundleExpecting reads and validates an actors bundle CAR, additionally checking that its manifest
+// has the expected CID.
+func ReadBundleExpecting(r io.Reader, expected cid.Cid) (*Bundle, error) {
+	b, err := ReadBundle(r)
+	if err != nil {
+		return nil, err
+	}
+	if !b.ManifestCid.Equals(expected) {
+		return nil, xerrors.Errorf("bundle manifest %s does not match expected %s", b.ManifestCid, expected)
+	}
+	return b, nil
+}
+
+// Loaded manifests, by actors version.
+var (
+	manifestsLk sync.RWMutex
+	manifests   = map[actors.Version]*loadedManifest{}
+)
+
+type loadedManifest struct {
+	cid      cid.Cid
+	manifest *Manifest
+}
+
+// LoadBundle reads and validates an actors bundle CAR and registers its manifest for an actors version,
+// replacing any manifest previously registered for that version. Returns the manifest CID.
+func LoadBundle(av actors.Version, r io.Reader) (cid.Cid, error) {
+	b, err := ReadBundle(r)
+	if err != nil {
+		return cid.Undef, err
+	}
+	Register(av, b.ManifestCid, b.Manifest)
+	return b.ManifestCid, nil
+}
+
+// Register records a loaded manifest for an actors version, replacing any manifest previously registered
+// for that version.
+func Register(av actors.Version, manifestCid cid.Cid, m *Manifest) {
+	manifestsLk.Lock()
+	defer manifestsLk.Unlock()
+	manifests[av] = &loadedManifest{cid: manifestCid, manifest: m}
+}
+
+// GetManifest returns the CID of the manifest registered for an actors version.
+func GetManifest(av actors.Version) (cid.Cid, bool) {
+	manifestsLk.RLock()
+	defer manifestsLk.RUnlock()
+	lm, ok := manifests[av]
+	if !ok {
+		return cid.Undef, false
+	}
+	return lm.cid, true
+}
+
+// GetActorCodeID returns the code CID of a named actor in the manifest registered for an actors version.
+func GetActorCodeID(av actors.Version, name string) (cid.Cid, bool) {
+	manifestsLk.RLock()
+	defer manifestsLk.RUnlock()
+	lm, ok := manifests[av]
+	if !ok {
+		return cid.Undef, false
+	}
+	return lm.manifest.Get(name)
+}
+
+// GetActorCodeIDs returns the name to code CID mapping of the manifest registered for an actors version.
+func GetActorCodeIDs(av actors.Version) (map[string]cid.Cid, bool) {
+	manifestsLk.RLock()
+	defer manifestsLk.RUnlock()
+	lm, ok := manifests[av]
+	if !ok {
+		return nil, false
+	}
+	return lm.manifest.Entries(), true
+}
+
+// ClearManifests forgets all registered manifests. This is intended for testing.
+func ClearManifests() {
+	manifestsLk.Lock()
+	defer manifestsLk.Unlock()
+	manifests = map[actors.Version]*loadedManifest{}
+}
+
+// GetActorNameByCode searches the registered manifests for a code CID, returning the actor name and the
+// actors version of the manifest in which it was found.
+// Code that is unchanged between versions appears in several manifests, in which case the lowest
+// actors version is returned.
+func GetActorNameByCode(c cid.Cid) (string, actors.Version, bool) {
+	manifestsLk.RLock()
+	defer manifestsLk.RUnlock()
+	versions := make([]actors.Version, 0, len(manifests))
+	for av := range manifests {
+		versions = append(versions, av)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+	for _, av := range versions {
+		if name, ok := manifests[av].manifest.GetActorName(c); ok {
+			return name, av, true
+		}
+	}
+	return "", -1, false
+}
@@ -0,0 +1,200 @@
+package manifest_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+	"github.com/filecoin-project/go-state-types/manifest"
+)
+
+type testBlock struct {
+	cid  cid.Cid
+	data []byte
+}
+
+func rawBlock(t *testing.T, data []byte) testBlock {
+	c, err := cid.Prefix{Version: 1, Codec: cid.Raw, MhType: mh.BLAKE2B_MIN + 31, MhLength: -1}.Sum(data)
+	require.NoError(t, err)
+	return testBlock{c, data}
+}
+
+func cborBlock(t *testing.T, obj cbg.CBORMarshaler) testBlock {
+	var buf bytes.Buffer
+	require.NoError(t, obj.MarshalCBOR(&buf))
+	c, err := cid.Prefix{Version: 1, Codec: cid.DagCBOR, MhType: mh.BLAKE2B_MIN + 31, MhLength: -1}.Sum(buf.Bytes())
+	require.NoError(t, err)
+	return testBlock{c, buf.Bytes()}
+}
+
+func writeSection(buf *bytes.Buffer, data ...[]byte) {
+	size := 0
+	for _, d := range data {
+		size += len(d)
+	}
+	var lenBuf [binary.MaxVarintLen64]byte
+	buf.Write(lenBuf[:binary.PutUvarint(lenBuf[:], uint64(size))])
+	for _, d := range data {
+		buf.Write(d)
+	}
+}
+
+func writeCar(t *testing.T, root cid.Cid, blocks ...testBlock) []byte {
+	var header bytes.Buffer
+	require.NoError(t, cbg.CborWriteHeader(&header, cbg.MajMap, 2))
+	require.NoError(t, cbg.CborWriteHeader(&header, cbg.MajTextString, 5))
+	header.WriteString("roots")
+	require.NoError(t, cbg.CborWriteHeader(&header, cbg.MajArray, 1))
+	require.NoError(t, cbg.WriteCid(&header, root))
+	require.NoError(t, cbg.CborWriteHeader(&header, cbg.MajTextString, 7))
+	header.WriteString("version")
+	require.NoError(t, cbg.CborWriteHeader(&header, cbg.MajUnsignedInt, 1))
+
+	var car bytes.Buffer
+	writeSection(&car, header.Bytes())
+	for _, b := range blocks {
+		writeSection(&car, b.cid.Bytes(), b.data)
+	}
+	return car.Bytes()
+}
+
+func makeBundle(t *testing.T, names []string) (cid.Cid, map[string]cid.Cid, []testBlock) {
+	var blocks []testBlock
+	var data manifest.ManifestData
+	codes := map[string]cid.Cid{}
+	for _, name := range names {
+		code := rawBlock(t, []byte("wasm code for "+name))
+		blocks = append(blocks, code)
+		data.Entries = append(data.Entries, manifest.ManifestEntry{Name: name, Code: code.cid})
+		codes[name] = code.cid
+	}
+	dataBlock := cborBlock(t, &data)
+	root := cborBlock(t, &manifest.Manifest{Version: 1, Data: dataBlock.cid})
+	blocks = append(blocks, dataBlock, root)
+	return root.cid, codes, blocks
+}
+
+func TestLoadBundle(t *testing.T) {
+	defer manifest.ClearManifests()
+
+	root, codes, blocks := makeBundle(t, manifest.GetBuiltinActorsKeys())
+	car := writeCar(t, root, blocks...)
+
+	b, err := manifest.ReadBundleExpecting(bytes.NewReader(car), root)
+	require.NoError(t, err)
+	assert.Equal(t, root, b.ManifestCid)
+	assert.Equal(t, codes, b.Manifest.Entries())
+	name, ok := b.Manifest.GetActorName(codes[manifest.MinerKey])
+	assert.True(t, ok)
+	assert.Equal(t, manifest.MinerKey, name)
+
+	_, ok = manifest.GetActorCodeID(actors.Version0, manifest.MinerKey)
+	assert.False(t, ok)
+
+	loaded, err := manifest.LoadBundle(actors.Version0, bytes.NewReader(car))
+	require.NoError(t, err)
+	assert.Equal(t, root, loaded)
+
+	c, ok := manifest.GetManifest(actors.Version0)
+	assert.True(t, ok)
+	assert.Equal(t, root, c)
+	c, ok = manifest.GetActorCodeID(actors.Version0, manifest.MinerKey)
+	assert.True(t, ok)
+	assert.Equal(t, codes[manifest.MinerKey], c)
+	all, ok := manifest.GetActorCodeIDs(actors.Version0)
+	assert.True(t, ok)
+	assert.Equal(t, codes, all)
+}
+
+func TestGetActorNameByCodeLowestVersion(t *testing.T) {
+	defer manifest.ClearManifests()
+
+	root, codes, blocks := makeBundle(t, manifest.GetBuiltinActorsKeys())
+	car := writeCar(t, root, blocks...)
+	for _, av := range []actors.Version{actors.Version10, actors.Version8, actors.Version9} {
+		_, err := manifest.LoadBundle(av, bytes.NewReader(car))
+		require.NoError(t, err)
+	}
+
+	for i := 0; i < 10; i++ {
+		name, av, ok := manifest.GetActorNameByCode(codes[manifest.MinerKey])
+		require.True(t, ok)
+		assert.Equal(t, manifest.MinerKey, name)
+		assert.Equal(t, actors.Version8, av)
+	}
+}
+
+func TestReadBundleRejectsInvalid(t *testing.T) {
+	t.Run("unexpected manifest", func(t *testing.T) {
+		root, _, blocks := makeBundle(t, manifest.GetBuiltinActorsKeys())
+		_, err := manifest.ReadBundleExpecting(bytes.NewReader(writeCar(t, root, blocks...)), blocks[0].cid)
+		assert.Error(t, err)
+	})
+
+	t.Run("missing actor", func(t *testing.T) {
+		root, _, blocks := makeBundle(t, manifest.GetBuiltinActorsKeys()[1:])
+		_, err := manifest.ReadBundle(bytes.NewReader(writeCar(t, root, blocks...)))
+		assert.Error(t, err)
+	})
+
+	t.Run("missing code", func(t *testing.T) {
+		root, _, blocks := makeBundle(t, manifest.GetBuiltinActorsKeys())
+		_, err := manifest.ReadBundle(bytes.NewReader(writeCar(t, root, blocks[1:]...)))
+		assert.Error(t, err)
+	})
+
+	t.Run("corrupt block", func(t *testing.T) {
+		root, _, blocks := makeBundle(t, manifest.GetBuiltinActorsKeys())
+		blocks[0].data = []byte("tampered")
+		_, err := manifest.ReadBundle(bytes.NewReader(writeCar(t, root, blocks...)))
+		assert.Error(t, err)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		root, _, blocks := makeBundle(t, manifest.GetBuiltinActorsKeys())
+		car := writeCar(t, root, blocks...)
+		_, err := manifest.ReadBundle(bytes.NewReader(car[:len(car)-1]))
+		assert.Error(t, err)
+	})
+}
+
+func TestExpectedManifestCID(t *testing.T) {
+	// The manifests of the published mainnet bundle of version 14 and calibration network bundle of version 8.
+	c, ok := manifest.ExpectedManifestCID(actors.Version14, abi.PresetMainnet)
+	require.True(t, ok)
+	assert.Equal(t, "bafy2bzacecbueuzsropvqawsri27owo7isa5gp2qtluhrfsto2qg7wpgxnkba", c.String())
+	c, ok = manifest.ExpectedManifestCID(actors.Version8, abi.PresetCalibnet)
+	require.True(t, ok)
+	assert.Equal(t, "bafy2bzacedrdn6z3z7xz7lx4wll3tlgktirhllzqxb766dxpaqp3ukxsjfsba", c.String())
+
+	// Every builtin actors version has a release for mainnet and the calibration and butterfly networks.
+	for av := actors.Version8; av <= actors.Version14; av++ {
+		for _, preset := range []abi.NetworkPreset{abi.PresetMainnet, abi.PresetCalibnet, "butterflynet"} {
+			_, ok := manifest.ExpectedManifestCID(av, preset)
+			assert.True(t, ok, "no release of actors version %d for %s", av, preset)
+		}
+	}
+	_, ok = manifest.ExpectedManifestCID(actors.Version14, abi.PresetDevnet)
+	assert.False(t, ok)
+}
+
+func TestLoadReleasedBundle(t *testing.T) {
+	defer manifest.ClearManifests()
+
+	// Only recorded releases are loaded.
+	_, ok := manifest.ExpectedManifestCID(actors.Version(999), abi.PresetMainnet)
+	assert.False(t, ok)
+	root, _, blocks := makeBundle(t, manifest.GetBuiltinActorsKeys())
+	_, err := manifest.LoadReleasedBundle(actors.Version(999), abi.PresetMainnet, bytes.NewReader(writeCar(t, root, blocks...)))
+	assert.Error(t, err)
+	_, ok = manifest.GetManifest(actors.Version(999))
+	assert.False(t, ok)
+}
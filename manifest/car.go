@@ -0,0 +1,154 @@
+package manifest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// Maximum size of a single section in a bundle CAR. Actor code blocks are the largest, at a few MiB.
+const maxCarSectionSize = 32 << 20
+
+// Maximum size of a CAR header. A bundle has only one root.
+const maxCarHeaderSize = 1 << 10
+
+// A carBlocks is the set of blocks read from a CAR, each verified against its CID.
+type carBlocks map[cid.Cid][]byte
+
+func (b carBlocks) GetBlock(c cid.Cid) ([]byte, error) {
+	data, ok := b[c]
+	if !ok {
+		return nil, xerrors.Errorf("block %s not found", c)
+	}
+	return data, nil
+}
+
+// Reads a version 1 CAR, returning the roots and the blocks.
+// Every block's data is checked against the hash in its CID.
+func readCar(r io.Reader) ([]cid.Cid, carBlocks, error) {
+	br := bufio.NewReader(r)
+
+	header, err := readCarSection(br, maxCarHeaderSize)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("failed to read car header: %w", err)
+	}
+	if header == nil {
+		return nil, nil, xerrors.Errorf("empty car")
+	}
+	roots, err := decodeCarHeader(header)
+	if err != nil {
+		return nil, nil, xerrors.Errorf("invalid car header: %w", err)
+	}
+
+	blocks := make(carBlocks)
+	for {
+		section, err := readCarSection(br, maxCarSectionSize)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("failed to read car section: %w", err)
+		}
+		if section == nil {
+			break
+		}
+
+		n, c, err := cid.CidFromBytes(section)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("invalid cid in car section: %w", err)
+		}
+		data := section[n:]
+
+		expected, err := c.Prefix().Sum(data)
+		if err != nil {
+			return nil, nil, xerrors.Errorf("failed to hash block %s: %w", c, err)
+		}
+		if !expected.Equals(c) {
+			return nil, nil, xerrors.Errorf("block data does not match cid %s", c)
+		}
+		blocks[c] = data
+	}
+	return roots, blocks, nil
+}
+
+// Reads a varint-length-prefixed section, returning nil at EOF.
+func readCarSection(br *bufio.Reader, maxSize uint64) ([]byte, error) {
+	size, err := binary.ReadUvarint(br)
+	if err == io.EOF {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, xerrors.Errorf("empty section")
+	}
+	if size > maxSize {
+		return nil, xerrors.Errorf("section too large (%d bytes)", size)
+	}
+
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(br, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// Decodes the DAG-CBOR CAR header map {"roots": [CID...], "version": 1}.
+func decodeCarHeader(header []byte) ([]cid.Cid, error) {
+	br := cbg.GetPeeker(bytes.NewReader(header))
+	maj, extra, err := cbg.CborReadHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if maj != cbg.MajMap {
+		return nil, fmt.Errorf("car header should be a map")
+	}
+
+	var roots []cid.Cid
+	version := uint64(0)
+	for i := uint64(0); i < extra; i++ {
+		key, err := cbg.ReadString(br)
+		if err != nil {
+			return nil, err
+		}
+		switch key {
+		case "roots":
+			maj, n, err := cbg.CborReadHeader(br)
+			if err != nil {
+				return nil, err
+			}
+			if maj != cbg.MajArray {
+				return nil, fmt.Errorf("car roots should be an array")
+			}
+			if n > 1 {
+				return nil, fmt.Errorf("too many car roots (%d)", n)
+			}
+			for j := uint64(0); j < n; j++ {
+				c, err := cbg.ReadCid(br)
+				if err != nil {
+					return nil, err
+				}
+				roots = append(roots, c)
+			}
+		case "version":
+			maj, v, err := cbg.CborReadHeader(br)
+			if err != nil {
+				return nil, err
+			}
+			if maj != cbg.MajUnsignedInt {
+				return nil, fmt.Errorf("car version should be an integer")
+			}
+			version = v
+		default:
+			return nil, fmt.Errorf("unexpected car header field %q", key)
+		}
+	}
+
+	if version != 1 {
+		return nil, fmt.Errorf("unsupported car version %d", version)
+	}
+	return roots, nil
+}
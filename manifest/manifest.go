@@ -0,0 +1,163 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// The version of the manifest format understood by this package.
+const ManifestVersion = 1
+
+// Names of the builtin actors, as they appear in a bundle manifest.
+const (
+	AccountKey  = "account"
+	CronKey     = "cron"
+	InitKey     = "init"
+	MarketKey   = "storagemarket"
+	MinerKey    = "storageminer"
+	MultisigKey = "multisig"
+	PaychKey    = "paymentchannel"
+	PowerKey    = "storagepower"
+	RewardKey   = "reward"
+	SystemKey   = "system"
+	VerifregKey = "verifiedregistry"
+	DatacapKey  = "datacap"
+)
+
+// GetBuiltinActorsKeys returns the names of the actors expected in a bundle.
+// Datacap was introduced with a later actors version so is not required.
+func GetBuiltinActorsKeys() []string {
+	return []string{
+		AccountKey,
+		CronKey,
+		InitKey,
+		MarketKey,
+		MinerKey,
+		MultisigKey,
+		PaychKey,
+		PowerKey,
+		RewardKey,
+		SystemKey,
+		VerifregKey,
+	}
+}
+
+// A Manifest is the root of an actors bundle, identifying the code CID of each actor.
+type Manifest struct {
+	Version uint64 // this is really a u32, but cbor-gen can't deal with it
+	Data    cid.Cid
+
+	entries    map[string]cid.Cid
+	actorNames map[cid.Cid]string
+}
+
+type ManifestEntry struct {
+	Name string
+	Code cid.Cid
+}
+
+// ManifestData is the list of entries referenced by a manifest.
+// It is serialized as a bare array of entries.
+type ManifestData struct {
+	Entries []ManifestEntry
+}
+
+// A BlockGetter retrieves the raw data of a block by CID.
+type BlockGetter interface {
+	GetBlock(c cid.Cid) ([]byte, error)
+}
+
+// Load fetches and decodes the manifest data, after which code CIDs may be looked up.
+func (m *Manifest) Load(bg BlockGetter) error {
+	if m.Version != ManifestVersion {
+		return xerrors.Errorf("unknown manifest version %d", m.Version)
+	}
+
+	raw, err := bg.GetBlock(m.Data)
+	if err != nil {
+		return xerrors.Errorf("failed to load manifest data %s: %w", m.Data, err)
+	}
+	var data ManifestData
+	if err := data.UnmarshalCBOR(bytes.NewReader(raw)); err != nil {
+		return xerrors.Errorf("failed to decode manifest data %s: %w", m.Data, err)
+	}
+
+	entries := make(map[string]cid.Cid, len(data.Entries))
+	actorNames := make(map[cid.Cid]string, len(data.Entries))
+	for _, e := range data.Entries {
+		if _, ok := entries[e.Name]; ok {
+			return xerrors.Errorf("duplicate manifest entry for %s", e.Name)
+		}
+		if other, ok := actorNames[e.Code]; ok {
+			return xerrors.Errorf("actors %s and %s share code CID %s", other, e.Name, e.Code)
+		}
+		entries[e.Name] = e.Code
+		actorNames[e.Code] = e.Name
+	}
+
+	m.entries = entries
+	m.actorNames = actorNames
+	return nil
+}
+
+// Get returns the code CID for an actor name.
+func (m *Manifest) Get(name string) (cid.Cid, bool) {
+	c, ok := m.entries[name]
+	return c, ok
+}
+
+// GetActorName returns the name of the actor with a code CID.
+func (m *Manifest) GetActorName(c cid.Cid) (string, bool) {
+	name, ok := m.actorNames[c]
+	return name, ok
+}
+
+// Entries returns a copy of the loaded name to code CID mapping.
+func (m *Manifest) Entries() map[string]cid.Cid {
+	entries := make(map[string]cid.Cid, len(m.entries))
+	for name, c := range m.entries {
+		entries[name] = c
+	}
+	return entries
+}
+
+func (d *ManifestData) MarshalCBOR(w io.Writer) error {
+	if err := cbg.CborWriteHeader(w, cbg.MajArray, uint64(len(d.Entries))); err != nil {
+		return err
+	}
+	for _, e := range d.Entries {
+		if err := e.MarshalCBOR(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *ManifestData) UnmarshalCBOR(r io.Reader) error {
+	*d = ManifestData{}
+
+	br := cbg.GetPeeker(r)
+	maj, extra, err := cbg.CborReadHeader(br)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray {
+		return fmt.Errorf("cbor input should be of type array")
+	}
+	if extra > cbg.MaxLength {
+		return fmt.Errorf("too many manifest entries (%d)", extra)
+	}
+
+	d.Entries = make([]ManifestEntry, extra)
+	for i := range d.Entries {
+		if err := d.Entries[i].UnmarshalCBOR(br); err != nil {
+			return err
+		}
+	}
+	return nil
+}
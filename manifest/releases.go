@@ -0,0 +1,45 @@
+package manifest
+
+import (
+	"io"
+
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/actors"
+)
+
+// The manifest CIDs of the released actors bundles are recorded in releases.json, and compiled into a table by
+// gen/bundles, which computes each CID from the bundle CAR published with the release. Networks without a preset
+// of their own, such as butterflynet, are recorded under the network name of their bundle.
+
+// ExpectedManifestCID returns the manifest CID of the released actors bundle for an actors version and network
+// preset, if the release is recorded.
+func ExpectedManifestCID(av actors.Version, preset abi.NetworkPreset) (cid.Cid, bool) {
+	s, ok := releasedManifests[av][preset]
+	if !ok {
+		return cid.Undef, false
+	}
+	c, err := cid.Decode(s)
+	if err != nil {
+		// The table is generated from valid CIDs.
+		panic(xerrors.Errorf("invalid released manifest CID %q: %w", s, err))
+	}
+	return c, true
+}
+
+// LoadReleasedBundle reads an actors bundle CAR, checks that it is the released bundle for an actors version
+// and network preset, and registers its manifest for the actors version. Returns the manifest CID.
+func LoadReleasedBundle(av actors.Version, preset abi.NetworkPreset, r io.Reader) (cid.Cid, error) {
+	expected, ok := ExpectedManifestCID(av, preset)
+	if !ok {
+		return cid.Undef, xerrors.Errorf("no released bundle of actors version %d for %s", av, preset)
+	}
+	b, err := ReadBundleExpecting(r, expected)
+	if err != nil {
+		return cid.Undef, err
+	}
+	Register(av, b.ManifestCid, b.Manifest)
+	return b.ManifestCid, nil
+}
@@ -0,0 +1,32 @@
+package math_test
+
+import (
+	gomath "math"
+	gobig "math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/math"
+)
+
+func TestLn(t *testing.T) {
+	for _, x := range []float64{1, 1.5, 2, 3, 10, 0.25, 12345.678, 1e20} {
+		q, _ := new(gobig.Float).Mul(gobig.NewFloat(x), new(gobig.Float).SetInt(new(gobig.Int).Lsh(gobig.NewInt(1), math.Precision128))).Int(nil)
+		ln := math.Ln(big.NewFromGo(q))
+		actual, _ := new(gobig.Float).Quo(new(gobig.Float).SetInt(ln.Int), new(gobig.Float).SetInt(new(gobig.Int).Lsh(gobig.NewInt(1), math.Precision128))).Float64()
+		assert.InDelta(t, gomath.Log(x), actual, 1e-12, "ln(%v)", x)
+	}
+}
+
+func TestPolyval(t *testing.T) {
+	// 2x^2 + 3x + 1 at x = 2, with integral coefficients and point in Q.128.
+	one := new(gobig.Int).Lsh(gobig.NewInt(1), math.Precision128)
+	coefs := []*gobig.Int{new(gobig.Int).Mul(gobig.NewInt(2), one), new(gobig.Int).Mul(gobig.NewInt(3), one), one}
+	res := math.Polyval(coefs, new(gobig.Int).Mul(gobig.NewInt(2), one))
+	assert.Equal(t, new(gobig.Int).Mul(gobig.NewInt(15), one), res)
+
+	assert.Equal(t, []*gobig.Int{gobig.NewInt(-12), gobig.NewInt(34)}, math.Parse([]string{"-12", "34"}))
+	assert.Panics(t, func() { math.Parse([]string{"1.5"}) })
+}
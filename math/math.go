@@ -0,0 +1,38 @@
+// Package math provides the fixed-point arithmetic used by actor economics, on integers with a fractional
+// part of 128 bits (Q.128). The results are exact functions of the inputs, so that all implementations agree.
+package math
+
+import (
+	"math/big"
+
+	"golang.org/x/xerrors"
+)
+
+// The number of fractional bits in a Q.128 fixed-point number.
+const Precision128 = 128
+
+// Parse parses decimal integer strings, such as fixed-point constants, panicking on failure.
+func Parse(coefs []string) []*big.Int {
+	out := make([]*big.Int, len(coefs))
+	for i, coef := range coefs {
+		c, ok := new(big.Int).SetString(coef, 10)
+		if !ok {
+			panic(xerrors.Errorf("could not parse %q as a decimal integer", coef))
+		}
+		out[i] = c
+	}
+	return out
+}
+
+// Polyval evaluates the polynomial with Q.128 coefficients p, ordered from the highest order
+// coefficient to the lowest, at the Q.128 point x, using Horner's method. The result is Q.128.
+func Polyval(p []*big.Int, x *big.Int) *big.Int {
+	res := new(big.Int).Set(p[0]) // Q.128
+	tmp := new(big.Int)           // big.Int.Mul doesn't like when input is reused as output
+	for _, c := range p[1:] {
+		tmp = tmp.Mul(res, x)            // Q.128 * Q.128 => Q.256
+		res = res.Rsh(tmp, Precision128) // Q.256 => Q.128
+		res = res.Add(res, c)
+	}
+	return res
+}
@@ -0,0 +1,60 @@
+package migration
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBudget limits the total estimated memory, in bytes, of in-flight migration jobs.
+// Acquiring more than the remaining budget blocks until enough is released, so that a job scheduler
+// acquiring before it dispatches each job pauses while the in-flight jobs are too large.
+// A request larger than the whole budget is admitted only when nothing else is in flight, so it cannot
+// block forever.
+type MemoryBudget struct {
+	limit int64
+
+	lk      sync.Mutex
+	inUse   int64
+	changed chan struct{} // Closed and replaced whenever memory is released.
+}
+
+// NewMemoryBudget returns a budget of limit bytes. A non-positive limit is unlimited.
+func NewMemoryBudget(limit int64) *MemoryBudget {
+	return &MemoryBudget{limit: limit, changed: make(chan struct{})}
+}
+
+// Acquire reserves n bytes of the budget, waiting until they are available or the context is done.
+func (b *MemoryBudget) Acquire(ctx context.Context, n int64) error {
+	for {
+		b.lk.Lock()
+		if b.limit <= 0 || b.inUse == 0 || b.inUse+n <= b.limit {
+			b.inUse += n
+			b.lk.Unlock()
+			return nil
+		}
+		changed := b.changed
+		b.lk.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Release returns n bytes, previously acquired, to the budget.
+func (b *MemoryBudget) Release(n int64) {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	b.inUse -= n
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+// InUse returns the number of bytes currently acquired.
+func (b *MemoryBudget) InUse() int64 {
+	b.lk.Lock()
+	defer b.lk.Unlock()
+	return b.inUse
+}
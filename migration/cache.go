@@ -0,0 +1,70 @@
+package migration
+
+import (
+	"sync"
+
+	"github.com/ipfs/go-cid"
+)
+
+// MigrationCache records the results of migrations, keyed by strings such as those from ActorHeadKey.
+// Implementations must be safe for concurrent use.
+type MigrationCache interface {
+	Write(key string, newCid cid.Cid) error
+	Read(key string) (bool, cid.Cid, error)
+	// Load returns the cached value for a key, or computes, caches and returns it with loadFunc.
+	Load(key string, loadFunc func() (cid.Cid, error)) (cid.Cid, error)
+}
+
+// MemMigrationCache is a MigrationCache held in memory.
+type MemMigrationCache struct {
+	MigrationMap sync.Map
+}
+
+var _ MigrationCache = (*MemMigrationCache)(nil)
+
+func NewMemMigrationCache() *MemMigrationCache {
+	return new(MemMigrationCache)
+}
+
+func (m *MemMigrationCache) Write(key string, c cid.Cid) error {
+	m.MigrationMap.Store(key, c)
+	return nil
+}
+
+func (m *MemMigrationCache) Read(key string) (bool, cid.Cid, error) {
+	val, found := m.MigrationMap.Load(key)
+	if !found {
+		return false, cid.Undef, nil
+	}
+	c, ok := val.(cid.Cid)
+	if !ok {
+		return false, cid.Undef, nil
+	}
+	return true, c, nil
+}
+
+func (m *MemMigrationCache) Load(key string, loadFunc func() (cid.Cid, error)) (cid.Cid, error) {
+	found, c, err := m.Read(key)
+	if err != nil {
+		return cid.Undef, err
+	}
+	if found {
+		return c, nil
+	}
+	c, err = loadFunc()
+	if err != nil {
+		return cid.Undef, err
+	}
+	m.MigrationMap.Store(key, c)
+	return c, nil
+}
+
+// Clone returns a copy of the cache's contents.
+func (m *MemMigrationCache) Clone() *MemMigrationCache {
+	newCache := NewMemMigrationCache()
+	m.MigrationMap.Range(func(k, v interface{}) bool {
+		newCache.MigrationMap.Store(k, v)
+		return true
+	})
+	return newCache
+}
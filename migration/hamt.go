@@ -0,0 +1,187 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/adt"
+)
+
+// Number of pointers in a node or entries in a bucket above which a block is rejected, as in adt.
+const maxHamtNodeWidth = 256
+
+// rewriteHamt rewrites the values of a HAMT, returning the new root. Since no key changes, the shape of the
+// HAMT is preserved: each node is copied with its values replaced by transform, and its links by the rewritten
+// children, in the node's original encoding. A node in which nothing changed is not written again.
+func rewriteHamt(ctx context.Context, store ipldcbor.IpldStore, root cid.Cid, transform func(k, v []byte) ([]byte, error)) (cid.Cid, error) {
+	if err := ctx.Err(); err != nil {
+		return cid.Undef, err
+	}
+	var raw cbg.Deferred
+	if err := store.Get(ctx, root, &raw); err != nil {
+		return cid.Undef, xerrors.Errorf("failed to load HAMT node %s: %w", root, err)
+	}
+	rw := hamtRewriter{ctx: ctx, store: store, transform: transform}
+	out, err := rw.node(bytes.NewReader(raw.Raw))
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to rewrite HAMT node %s: %w", root, err)
+	}
+	if !rw.changed {
+		return root, nil
+	}
+	return store.Put(ctx, &cbg.Deferred{Raw: out})
+}
+
+type hamtRewriter struct {
+	ctx       context.Context
+	store     ipldcbor.IpldStore
+	transform func(k, v []byte) ([]byte, error)
+
+	buf     bytes.Buffer
+	changed bool
+}
+
+func (rw *hamtRewriter) node(br *bytes.Reader) ([]byte, error) {
+	if err := rw.copyHeader(br, cbg.MajArray, adt.HamtNodeFields); err != nil {
+		return nil, xerrors.Errorf("expected %d-tuple: %w", adt.HamtNodeFields, err)
+	}
+	var bitfield cbg.Deferred
+	if err := bitfield.UnmarshalCBOR(br); err != nil {
+		return nil, xerrors.Errorf("failed to read bitfield: %w", err)
+	}
+	rw.buf.Write(bitfield.Raw)
+
+	n, err := rw.readHeader(br, cbg.MajArray, maxHamtNodeWidth)
+	if err != nil {
+		return nil, xerrors.Errorf("expected pointers array: %w", err)
+	}
+	for i := uint64(0); i < n; i++ {
+		if err := rw.pointer(br); err != nil {
+			return nil, err
+		}
+	}
+	if br.Len() != 0 {
+		return nil, xerrors.Errorf("%d trailing bytes", br.Len())
+	}
+	return rw.buf.Bytes(), nil
+}
+
+func (rw *hamtRewriter) pointer(br *bytes.Reader) error {
+	first, err := br.ReadByte()
+	if err != nil {
+		return err
+	}
+	if err := br.UnreadByte(); err != nil {
+		return err
+	}
+
+	switch first >> 5 {
+	case cbg.MajTag:
+		return rw.link(br)
+	case cbg.MajArray:
+		return rw.bucket(br)
+	case cbg.MajMap:
+		if err := rw.copyHeader(br, cbg.MajMap, 1); err != nil {
+			return xerrors.Errorf("expected single-entry pointer map: %w", err)
+		}
+		key, err := cbg.ReadString(br)
+		if err != nil {
+			return err
+		}
+		if err := cbg.WriteMajorTypeHeader(&rw.buf, cbg.MajTextString, uint64(len(key))); err != nil {
+			return err
+		}
+		rw.buf.WriteString(key)
+		switch key {
+		case "0":
+			return rw.link(br)
+		case "1":
+			return rw.bucket(br)
+		default:
+			return xerrors.Errorf("invalid pointer map key %q", key)
+		}
+	default:
+		return xerrors.Errorf("invalid pointer type %d", first>>5)
+	}
+}
+
+func (rw *hamtRewriter) link(br *bytes.Reader) error {
+	c, err := cbg.ReadCid(br)
+	if err != nil {
+		return err
+	}
+	newC, err := rewriteHamt(rw.ctx, rw.store, c, rw.transform)
+	if err != nil {
+		return err
+	}
+	if newC != c {
+		rw.changed = true
+	}
+	return cbg.WriteCid(&rw.buf, newC)
+}
+
+func (rw *hamtRewriter) bucket(br *bytes.Reader) error {
+	n, err := rw.readHeader(br, cbg.MajArray, maxHamtNodeWidth)
+	if err != nil {
+		return xerrors.Errorf("expected bucket array: %w", err)
+	}
+	for i := uint64(0); i < n; i++ {
+		if err := rw.copyHeader(br, cbg.MajArray, 2); err != nil {
+			return xerrors.Errorf("expected key-value 2-tuple: %w", err)
+		}
+		key, err := cbg.ReadByteArray(br, cbg.ByteArrayMaxLen)
+		if err != nil {
+			return xerrors.Errorf("failed to read key: %w", err)
+		}
+		if err := cbg.CborWriteHeader(&rw.buf, cbg.MajByteString, uint64(len(key))); err != nil {
+			return err
+		}
+		rw.buf.Write(key)
+		var value cbg.Deferred
+		if err := value.UnmarshalCBOR(br); err != nil {
+			return xerrors.Errorf("failed to read value: %w", err)
+		}
+		newValue, err := rw.transform(key, value.Raw)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(newValue, value.Raw) {
+			rw.changed = true
+		}
+		rw.buf.Write(newValue)
+	}
+	return nil
+}
+
+// Reads a header of some major type with a count of at most max, and writes it to the output.
+func (rw *hamtRewriter) readHeader(r io.Reader, maj byte, max uint64) (uint64, error) {
+	m, n, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return 0, err
+	}
+	if m != maj {
+		return 0, xerrors.Errorf("got major type %d", m)
+	}
+	if n > max {
+		return 0, xerrors.Errorf("too many items (%d)", n)
+	}
+	return n, cbg.WriteMajorTypeHeader(&rw.buf, maj, n)
+}
+
+// Reads a header of some major type with a count of exactly n, and writes it to the output.
+func (rw *hamtRewriter) copyHeader(r io.Reader, maj byte, n uint64) error {
+	got, err := rw.readHeader(r, maj, n)
+	if err != nil {
+		return err
+	}
+	if got != n {
+		return xerrors.Errorf("got %d items", got)
+	}
+	return nil
+}
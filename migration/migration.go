@@ -0,0 +1,42 @@
+package migration
+
+import (
+	"context"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+)
+
+// Input to an actor migration: the actor to migrate and a cache of prior results.
+type ActorMigrationInput struct {
+	Address addr.Address   // actor's address
+	Head    cid.Cid        // actor's state head in the input state tree
+	Cache   MigrationCache // cache of existing cid -> cid migrations for this actor
+}
+
+// Result of an actor migration: the actor's new code and state head.
+type ActorMigrationResult struct {
+	NewCodeCID cid.Cid
+	NewHead    cid.Cid
+}
+
+// An ActorMigration migrates the state of all actors with some code CID to a new code CID.
+type ActorMigration interface {
+	// Loads an actor's state from the store and writes new state to the store.
+	// Returns the actor's new code CID and state head.
+	MigrateState(ctx context.Context, store ipldcbor.IpldStore, input ActorMigrationInput) (*ActorMigrationResult, error)
+
+	// The code CID of actors after migration.
+	MigratedCodeCID() cid.Cid
+
+	// Whether this migration must be run only after all non-deferred migrations have completed,
+	// e.g. because it depends on their results. A deferred migration is not run by the migration of
+	// individual actors, but by some subsequent step of the network upgrade.
+	Deferred() bool
+}
+
+// ActorHeadKey is the key under which the migrated head of an actor's state is cached.
+func ActorHeadKey(a addr.Address, head cid.Cid) string {
+	return a.String() + "-head-" + head.String()
+}
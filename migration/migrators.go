@@ -0,0 +1,87 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+)
+
+// CodeMigrator migrates an actor by changing only its code CID, preserving its state head.
+// This is appropriate for actors whose state schema did not change.
+type CodeMigrator struct {
+	OutCodeCID cid.Cid
+}
+
+var _ ActorMigration = CodeMigrator{}
+
+func (m CodeMigrator) MigrateState(_ context.Context, _ ipldcbor.IpldStore, in ActorMigrationInput) (*ActorMigrationResult, error) {
+	return &ActorMigrationResult{
+		NewCodeCID: m.OutCodeCID,
+		NewHead:    in.Head,
+	}, nil
+}
+
+func (m CodeMigrator) MigratedCodeCID() cid.Cid {
+	return m.OutCodeCID
+}
+
+func (m CodeMigrator) Deferred() bool {
+	return false
+}
+
+// CachedMigrator wraps a migration so that the new state head for an (address, head) pair is computed
+// at most once and stored in the cache. This allows the result of a migration run in advance of the
+// upgrade epoch (a "pre-migration") to be reused for actors whose state has not since changed.
+type CachedMigrator struct {
+	cache MigrationCache
+	ActorMigration
+}
+
+var _ ActorMigration = CachedMigrator{}
+
+// CachedMigration wraps a migration with a result cache.
+func CachedMigration(cache MigrationCache, m ActorMigration) ActorMigration {
+	return CachedMigrator{
+		cache:          cache,
+		ActorMigration: m,
+	}
+}
+
+func (m CachedMigrator) MigrateState(ctx context.Context, store ipldcbor.IpldStore, in ActorMigrationInput) (*ActorMigrationResult, error) {
+	newHead, err := m.cache.Load(ActorHeadKey(in.Address, in.Head), func() (cid.Cid, error) {
+		result, err := m.ActorMigration.MigrateState(ctx, store, in)
+		if err != nil {
+			return cid.Undef, err
+		}
+		return result.NewHead, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ActorMigrationResult{
+		NewCodeCID: m.MigratedCodeCID(),
+		NewHead:    newHead,
+	}, nil
+}
+
+// DeferredMigrator marks actors whose migration is performed after all other actors have been migrated,
+// outside the per-actor migration. MigrateState must not be called.
+type DeferredMigrator struct {
+	OutCodeCID cid.Cid
+}
+
+var _ ActorMigration = DeferredMigrator{}
+
+func (m DeferredMigrator) MigrateState(_ context.Context, _ ipldcbor.IpldStore, in ActorMigrationInput) (*ActorMigrationResult, error) {
+	return nil, xerrors.Errorf("deferred migration of actor %s must not be run directly", in.Address)
+}
+
+func (m DeferredMigrator) MigratedCodeCID() cid.Cid {
+	return m.OutCodeCID
+}
+
+func (m DeferredMigrator) Deferred() bool {
+	return true
+}
@@ -0,0 +1,74 @@
+package migration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/migration"
+)
+
+type countingMigrator struct {
+	migration.CodeMigrator
+	calls int
+}
+
+func (m *countingMigrator) MigrateState(_ context.Context, _ ipldcbor.IpldStore, in migration.ActorMigrationInput) (*migration.ActorMigrationResult, error) {
+	m.calls++
+	return &migration.ActorMigrationResult{NewCodeCID: m.OutCodeCID, NewHead: m.OutCodeCID}, nil
+}
+
+func TestCachedMigration(t *testing.T) {
+	ctx := context.Background()
+	code := mustCid(t, "new-code")
+	head := mustCid(t, "head")
+	addr, err := address.NewIDAddress(100)
+	require.NoError(t, err)
+
+	inner := &countingMigrator{CodeMigrator: migration.CodeMigrator{OutCodeCID: code}}
+	cache := migration.NewMemMigrationCache()
+	m := migration.CachedMigration(cache, inner)
+
+	in := migration.ActorMigrationInput{Address: addr, Head: head, Cache: cache}
+	for i := 0; i < 2; i++ {
+		result, err := m.MigrateState(ctx, nil, in)
+		require.NoError(t, err)
+		assert.Equal(t, code, result.NewCodeCID)
+		assert.Equal(t, code, result.NewHead)
+	}
+	assert.Equal(t, 1, inner.calls)
+
+	found, c, err := cache.Clone().Read(migration.ActorHeadKey(addr, head))
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, code, c)
+}
+
+func TestCodeAndDeferredMigrators(t *testing.T) {
+	ctx := context.Background()
+	code := mustCid(t, "new-code")
+	head := mustCid(t, "head")
+
+	result, err := migration.CodeMigrator{OutCodeCID: code}.MigrateState(ctx, nil, migration.ActorMigrationInput{Head: head})
+	require.NoError(t, err)
+	assert.Equal(t, code, result.NewCodeCID)
+	assert.Equal(t, head, result.NewHead)
+
+	deferred := migration.DeferredMigrator{OutCodeCID: code}
+	assert.True(t, deferred.Deferred())
+	assert.Equal(t, code, deferred.MigratedCodeCID())
+	_, err = deferred.MigrateState(ctx, nil, migration.ActorMigrationInput{Head: head})
+	assert.Error(t, err)
+}
+
+func mustCid(t *testing.T, s string) cid.Cid {
+	c, err := abi.CidBuilder.Sum([]byte(s))
+	require.NoError(t, err)
+	return c
+}
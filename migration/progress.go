@@ -0,0 +1,97 @@
+package migration
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/go-state-types/statetree"
+)
+
+// ProgressReporter receives the progress of MigrateActors, such as for a node to expose the progress of an
+// upgrade through its API.
+type ProgressReporter interface {
+	// ReportProgress is called with the number of actors migrated so far, of the total to migrate, and the
+	// time elapsed since the migration started. Calls are serialized and should return promptly.
+	ReportProgress(actorsDone, actorsTotal int, elapsed time.Duration)
+}
+
+// ProgressFunc adapts a function to a ProgressReporter.
+type ProgressFunc func(actorsDone, actorsTotal int, elapsed time.Duration)
+
+func (f ProgressFunc) ReportProgress(actorsDone, actorsTotal int, elapsed time.Duration) {
+	f(actorsDone, actorsTotal, elapsed)
+}
+
+// Tracks the actors migrated, reporting to the configured reporter at most once per interval.
+// A nil tracker, for a migration with no reporter, ignores progress.
+type progressTracker struct {
+	reporter ProgressReporter
+	interval time.Duration
+	start    time.Time
+	total    int
+
+	lk           sync.Mutex
+	done         int
+	reported     time.Time
+	reportedDone int
+}
+
+// Returns a tracker of the actors of the tree with a non-deferred migration, after reporting that none are
+// done yet, or nil if cfg has no reporter.
+// Counting the actors costs a traversal of the tree, which is made only when progress is reported.
+func newProgressTracker(ctx context.Context, cfg Config, tree *statetree.StateTree, migrations map[cid.Cid]ActorMigration) (*progressTracker, error) {
+	if cfg.Progress == nil {
+		return nil, nil
+	}
+	start := time.Now()
+	total := 0
+	if len(migrations) > 0 {
+		err := tree.ForEach(ctx, func(_ addr.Address, act *statetree.Actor) error {
+			if m, ok := migrations[act.Code]; ok && !m.Deferred() {
+				total++
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	p := &progressTracker{reporter: cfg.Progress, interval: cfg.ProgressInterval, start: start, total: total, reported: start}
+	p.reporter.ReportProgress(0, total, time.Since(start))
+	return p, nil
+}
+
+// Records n more actors as migrated, reporting if the interval has passed since the last report.
+func (p *progressTracker) add(n int) {
+	if p == nil {
+		return
+	}
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	p.done += n
+	now := time.Now()
+	if now.Sub(p.reported) >= p.interval {
+		p.report(now)
+	}
+}
+
+// Reports the final progress, unless it was already the last reported.
+func (p *progressTracker) finish() {
+	if p == nil {
+		return
+	}
+	p.lk.Lock()
+	defer p.lk.Unlock()
+	if p.done != p.reportedDone {
+		p.report(time.Now())
+	}
+}
+
+func (p *progressTracker) report(now time.Time) {
+	p.reported, p.reportedDone = now, p.done
+	p.reporter.ReportProgress(p.done, p.total, now.Sub(p.start))
+}
@@ -0,0 +1,72 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/statetree"
+)
+
+// ValidateStateTreeUpgrade checks that an upgrade may move a state tree from one version to another.
+// An upgrade keeps the version or advances it by one; it never moves it backwards.
+func ValidateStateTreeUpgrade(from, to statetree.StateTreeVersion) error {
+	if to > statetree.StateTreeVersionLatest {
+		return xerrors.Errorf("unsupported state tree version %d", to)
+	}
+	if to < from {
+		return xerrors.Errorf("cannot downgrade state tree from version %d to %d", from, to)
+	}
+	if to > from+1 {
+		return xerrors.Errorf("cannot upgrade state tree from version %d to %d in one step", from, to)
+	}
+	return nil
+}
+
+// StoreStateRoot writes a state root envelope, returning the root CID of the state tree.
+// A StateTreeVersion0 tree has no envelope, so its root is the actors tree, and it must have no info.
+func StoreStateRoot(ctx context.Context, store ipldcbor.IpldStore, sr *statetree.StateRoot) (cid.Cid, error) {
+	if sr.Version > statetree.StateTreeVersionLatest {
+		return cid.Undef, xerrors.Errorf("unsupported state tree version %d", sr.Version)
+	}
+	if !sr.Actors.Defined() {
+		return cid.Undef, xerrors.Errorf("state root has no actors tree")
+	}
+	if sr.Version == statetree.StateTreeVersion0 {
+		if sr.Info.Defined() {
+			return cid.Undef, xerrors.Errorf("state tree version 0 cannot hold info %s", sr.Info)
+		}
+		return sr.Actors, nil
+	}
+	if !sr.Info.Defined() {
+		return cid.Undef, xerrors.Errorf("state root version %d has no info", sr.Version)
+	}
+	c, err := store.Put(ctx, sr)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to store state root: %w", err)
+	}
+	return c, nil
+}
+
+// UpgradeStateRoot writes the state root of a migrated state tree, given the root of the tree before
+// migration, the migrated actors tree, and the new version. The info of the prior root is carried over;
+// an upgrade from StateTreeVersion0 (which has none) writes an empty StateInfo0.
+func UpgradeStateRoot(ctx context.Context, store ipldcbor.IpldStore, oldRoot cid.Cid, newActors cid.Cid, to statetree.StateTreeVersion) (cid.Cid, error) {
+	old, err := statetree.LoadStateRoot(ctx, store, oldRoot)
+	if err != nil {
+		return cid.Undef, err
+	}
+	if err := ValidateStateTreeUpgrade(old.Version, to); err != nil {
+		return cid.Undef, err
+	}
+
+	info := old.Info
+	if to != statetree.StateTreeVersion0 && !info.Defined() {
+		if info, err = store.Put(ctx, &statetree.StateInfo0{}); err != nil {
+			return cid.Undef, xerrors.Errorf("failed to store state info: %w", err)
+		}
+	}
+	return StoreStateRoot(ctx, store, &statetree.StateRoot{Version: to, Actors: newActors, Info: info})
+}
@@ -0,0 +1,60 @@
+package migration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/migration"
+	"github.com/filecoin-project/go-state-types/statetree"
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+func TestValidateStateTreeUpgrade(t *testing.T) {
+	assert.NoError(t, migration.ValidateStateTreeUpgrade(statetree.StateTreeVersion4, statetree.StateTreeVersion4))
+	assert.NoError(t, migration.ValidateStateTreeUpgrade(statetree.StateTreeVersion4, statetree.StateTreeVersion5))
+	assert.Error(t, migration.ValidateStateTreeUpgrade(statetree.StateTreeVersion4, statetree.StateTreeVersion3))
+	assert.Error(t, migration.ValidateStateTreeUpgrade(statetree.StateTreeVersion3, statetree.StateTreeVersion5))
+	assert.Error(t, migration.ValidateStateTreeUpgrade(statetree.StateTreeVersion5, statetree.StateTreeVersionLatest+1))
+}
+
+func TestUpgradeStateRoot(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+	// An empty HAMT node.
+	actors0, err := store.PutRaw([]byte{0x82, 0x40, 0x80})
+	require.NoError(t, err)
+	actors1, err := store.PutRaw([]byte{0x82, 0x41, 0x00, 0x80})
+	require.NoError(t, err)
+
+	// Version 0 to 1 wraps the tree in an envelope with empty info.
+	root1, err := migration.UpgradeStateRoot(ctx, store, actors0, actors1, statetree.StateTreeVersion1)
+	require.NoError(t, err)
+	sr, err := statetree.LoadStateRoot(ctx, store, root1)
+	require.NoError(t, err)
+	assert.Equal(t, statetree.StateTreeVersion1, sr.Version)
+	assert.Equal(t, actors1, sr.Actors)
+	var info statetree.StateInfo0
+	require.NoError(t, store.Get(ctx, sr.Info, &info))
+
+	// Later upgrades keep the info.
+	root2, err := migration.UpgradeStateRoot(ctx, store, root1, actors1, statetree.StateTreeVersion2)
+	require.NoError(t, err)
+	sr2, err := statetree.LoadStateRoot(ctx, store, root2)
+	require.NoError(t, err)
+	assert.Equal(t, statetree.StateTreeVersion2, sr2.Version)
+	assert.Equal(t, sr.Info, sr2.Info)
+
+	_, err = migration.UpgradeStateRoot(ctx, store, root2, actors1, statetree.StateTreeVersion1)
+	assert.Error(t, err)
+
+	// A version 0 root is the actors tree itself.
+	sr0, err := statetree.LoadStateRoot(ctx, store, actors0)
+	require.NoError(t, err)
+	assert.Equal(t, statetree.StateTreeVersion0, sr0.Version)
+	c, err := migration.StoreStateRoot(ctx, store, sr0)
+	require.NoError(t, err)
+	assert.Equal(t, actors0, c)
+}
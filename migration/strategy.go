@@ -0,0 +1,164 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/statetree"
+)
+
+// Strategy is the way in which MigrateActors traverses the state tree.
+type Strategy int
+
+const (
+	// TopDown migrates each actor as a job, concurrently as configured, and then writes the migrated actors
+	// into the tree. It supports any migration.
+	TopDown Strategy = iota
+	// BottomUp rewrites the blocks of the actors HAMT from the leaves up, changing actors' code CIDs as it
+	// goes, with no per-actor jobs. It supports only migrations in which every migrator is a CodeMigrator,
+	// for which it is much faster.
+	BottomUp
+)
+
+func (s Strategy) String() string {
+	switch s {
+	case TopDown:
+		return "top-down"
+	case BottomUp:
+		return "bottom-up"
+	default:
+		return fmt.Sprintf("Strategy(%d)", int(s))
+	}
+}
+
+// MigrateActors migrates the actors of a state tree with the migrations keyed by their current code CID, using
+// the strategy of cfg, and returns the root of the migrated actors HAMT, in the tree's encoding.
+// Actors with no migration, or a deferred one, are carried over unchanged.
+// Progress, in actors with a non-deferred migration, is reported to the configured reporter, if any.
+// The migration stops promptly, with the context's error, when ctx is done.
+func MigrateActors(ctx context.Context, store ipldcbor.IpldStore, cfg Config, cache MigrationCache, tree *statetree.StateTree, migrations map[cid.Cid]ActorMigration) (cid.Cid, error) {
+	if cfg.Strategy != TopDown && cfg.Strategy != BottomUp {
+		return cid.Undef, xerrors.Errorf("unknown migration strategy %s", cfg.Strategy)
+	}
+	progress, err := newProgressTracker(ctx, cfg, tree, migrations)
+	if err != nil {
+		return cid.Undef, xerrors.Errorf("failed to count actor migrations: %w", err)
+	}
+
+	var root cid.Cid
+	if cfg.Strategy == TopDown {
+		root, err = migrateTopDown(ctx, store, cfg, cache, tree, migrations, progress)
+	} else {
+		root, err = migrateBottomUp(ctx, store, tree, migrations, progress)
+	}
+	if err != nil {
+		return cid.Undef, err
+	}
+	progress.finish()
+	return root, nil
+}
+
+func migrateTopDown(ctx context.Context, store ipldcbor.IpldStore, cfg Config, cache MigrationCache, tree *statetree.StateTree, migrations map[cid.Cid]ActorMigration, progress *progressTracker) (cid.Cid, error) {
+	queueCtx, cancelQueue := context.WithCancel(ctx)
+	defer cancelQueue()
+
+	jobs := make(chan *MigrationJob)
+	var queueErr error
+	var queued sync.WaitGroup
+	queued.Add(1)
+	go func() {
+		defer queued.Done()
+		defer close(jobs)
+		queueErr = tree.ForEach(queueCtx, func(a addr.Address, act *statetree.Actor) error {
+			m, ok := migrations[act.Code]
+			if !ok || m.Deferred() {
+				return nil
+			}
+			job := &MigrationJob{
+				ActorMigrationInput: ActorMigrationInput{Address: a, Head: act.Head, Cache: cache},
+				Migrator:            m,
+			}
+			select {
+			case jobs <- job:
+				return nil
+			case <-queueCtx.Done():
+				return queueCtx.Err()
+			}
+		})
+	}()
+
+	results := map[addr.Address]ActorMigrationResult{}
+	err := RunMigrationJobs(ctx, store, cfg, jobs, func(r *MigrationJobResult) error {
+		results[r.Address] = r.ActorMigrationResult
+		progress.add(1)
+		return nil
+	})
+	cancelQueue()
+	queued.Wait()
+	if err != nil {
+		return cid.Undef, err
+	}
+	if queueErr != nil {
+		return cid.Undef, xerrors.Errorf("failed to queue actor migrations: %w", queueErr)
+	}
+
+	return rewriteActors(ctx, store, tree, func(a addr.Address, act *statetree.Actor) (bool, error) {
+		r, ok := results[a]
+		if !ok {
+			return false, nil
+		}
+		act.Code = r.NewCodeCID
+		act.Head = r.NewHead
+		return true, nil
+	})
+}
+
+func migrateBottomUp(ctx context.Context, store ipldcbor.IpldStore, tree *statetree.StateTree, migrations map[cid.Cid]ActorMigration, progress *progressTracker) (cid.Cid, error) {
+	codes := make(map[cid.Cid]cid.Cid, len(migrations))
+	for from, m := range migrations {
+		if m.Deferred() {
+			continue
+		}
+		cm, ok := m.(CodeMigrator)
+		if !ok {
+			return cid.Undef, xerrors.Errorf("bottom-up migration supports only code migrations, but actors with code %s have %T", from, m)
+		}
+		codes[from] = cm.OutCodeCID
+	}
+
+	return rewriteActors(ctx, store, tree, func(_ addr.Address, act *statetree.Actor) (bool, error) {
+		to, ok := codes[act.Code]
+		if !ok {
+			return false, nil
+		}
+		act.Code = to
+		progress.add(1)
+		return true, nil
+	})
+}
+
+// rewriteActors rewrites the actors HAMT of a tree, re-encoding each actor that update changes.
+func rewriteActors(ctx context.Context, store ipldcbor.IpldStore, tree *statetree.StateTree, update func(a addr.Address, act *statetree.Actor) (bool, error)) (cid.Cid, error) {
+	version := tree.Version()
+	return rewriteHamt(ctx, store, tree.ActorsRoot(), func(k, v []byte) ([]byte, error) {
+		a, err := addr.NewFromBytes(k)
+		if err != nil {
+			return nil, xerrors.Errorf("invalid address key %x: %w", k, err)
+		}
+		act, err := statetree.DecodeActor(version, v)
+		if err != nil {
+			return nil, xerrors.Errorf("failed to decode actor %s: %w", a, err)
+		}
+		changed, err := update(a, act)
+		if err != nil || !changed {
+			return v, err
+		}
+		return statetree.EncodeActor(version, act)
+	})
+}
@@ -0,0 +1,213 @@
+package migration_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/migration"
+	"github.com/filecoin-project/go-state-types/statetree"
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+func TestMigrateActorsStrategies(t *testing.T) {
+	ctx := context.Background()
+	oldCode := mustCid(t, "old-code")
+	newCode := mustCid(t, "new-code")
+	otherCode := mustCid(t, "other-code")
+
+	for _, version := range []statetree.StateTreeVersion{statetree.StateTreeVersion0, statetree.StateTreeVersion4, statetree.StateTreeVersion5} {
+		store := ipld.NewMemStore()
+		a1, act1 := testActor(t, 100, oldCode)
+		a2, act2 := testActor(t, 101, otherCode)
+		a3, act3 := testActor(t, 102, oldCode)
+		a4, act4 := testActor(t, 103, otherCode)
+		old := version == statetree.StateTreeVersion0
+
+		// Nothing in the second child is migrated, so it is carried over as is.
+		child := ipld.PutBlock(t, store, ipld.HamtNode(old, ipld.HamtBucket(old, kv(t, version, a3, act3))))
+		unchanged := ipld.PutBlock(t, store, ipld.HamtNode(old, ipld.HamtBucket(old, kv(t, version, a4, act4))))
+		root := ipld.PutBlock(t, store, ipld.HamtNode(old, ipld.HamtBucket(old, kv(t, version, a1, act1), kv(t, version, a2, act2)), ipld.HamtLink(old, child), ipld.HamtLink(old, unchanged)))
+		tree := loadTree(t, store, version, root)
+
+		migrations := map[cid.Cid]migration.ActorMigration{oldCode: migration.CodeMigrator{OutCodeCID: newCode}}
+		var roots []cid.Cid
+		for _, strategy := range []migration.Strategy{migration.TopDown, migration.BottomUp} {
+			cfg := migration.Config{MaxWorkers: 2, Strategy: strategy}
+			newRoot, err := migration.MigrateActors(ctx, store, cfg, migration.NewMemMigrationCache(), tree, migrations)
+			require.NoError(t, err, strategy)
+			roots = append(roots, newRoot)
+
+			actors := map[addr.Address]statetree.Actor{}
+			require.NoError(t, loadTree(t, store, version, newRoot).ForEach(ctx, func(a addr.Address, act *statetree.Actor) error {
+				actors[a] = *act
+				return nil
+			}))
+			act1.Code, act3.Code = newCode, newCode
+			assert.Equal(t, map[addr.Address]statetree.Actor{a1: act1, a2: act2, a3: act3, a4: act4}, actors)
+			act1.Code, act3.Code = oldCode, oldCode
+
+			var raw cbg.Deferred
+			require.NoError(t, store.Get(ctx, newRoot, &raw))
+			assert.True(t, bytes.Contains(raw.Raw, unchanged.Bytes()))
+			assert.False(t, bytes.Contains(raw.Raw, child.Bytes()))
+		}
+		assert.Equal(t, roots[0], roots[1], "strategies differ for version %d", version)
+
+		// With nothing to migrate, the tree is unchanged.
+		for _, strategy := range []migration.Strategy{migration.TopDown, migration.BottomUp} {
+			newRoot, err := migration.MigrateActors(ctx, store, migration.Config{Strategy: strategy}, nil, tree, nil)
+			require.NoError(t, err)
+			assert.Equal(t, root, newRoot)
+		}
+	}
+}
+
+func TestMigrateActorsStateChange(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+	oldCode := mustCid(t, "old-code")
+	newCode := mustCid(t, "new-code")
+	a1, act1 := testActor(t, 100, oldCode)
+	root := ipld.PutBlock(t, store, ipld.HamtNode(false, ipld.HamtBucket(false, kv(t, statetree.StateTreeVersion5, a1, act1))))
+	tree := loadTree(t, store, statetree.StateTreeVersion5, root)
+
+	m := &countingMigrator{CodeMigrator: migration.CodeMigrator{OutCodeCID: newCode}}
+	migrations := map[cid.Cid]migration.ActorMigration{oldCode: m}
+
+	// Only the top-down strategy can change actors' state.
+	_, err := migration.MigrateActors(ctx, store, migration.Config{Strategy: migration.BottomUp}, nil, tree, migrations)
+	assert.Error(t, err)
+
+	newRoot, err := migration.MigrateActors(ctx, store, migration.Config{Strategy: migration.TopDown}, migration.NewMemMigrationCache(), tree, migrations)
+	require.NoError(t, err)
+	assert.Equal(t, 1, m.calls)
+	require.NoError(t, loadTree(t, store, statetree.StateTreeVersion5, newRoot).ForEach(ctx, func(a addr.Address, act *statetree.Actor) error {
+		assert.Equal(t, newCode, act.Code)
+		assert.Equal(t, newCode, act.Head)
+		return nil
+	}))
+
+	// Deferred migrations are skipped by both strategies.
+	deferred := map[cid.Cid]migration.ActorMigration{oldCode: migration.DeferredMigrator{OutCodeCID: newCode}}
+	for _, strategy := range []migration.Strategy{migration.TopDown, migration.BottomUp} {
+		newRoot, err := migration.MigrateActors(ctx, store, migration.Config{Strategy: strategy}, nil, tree, deferred)
+		require.NoError(t, err)
+		assert.Equal(t, root, newRoot)
+	}
+
+	_, err = migration.MigrateActors(ctx, store, migration.Config{Strategy: 7}, nil, tree, migrations)
+	assert.Error(t, err)
+}
+
+func TestMigrateActorsProgress(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+	oldCode := mustCid(t, "old-code")
+	otherCode := mustCid(t, "other-code")
+	var kvs [][]byte
+	for i := uint64(0); i < 5; i++ {
+		code := oldCode
+		if i == 4 {
+			code = otherCode
+		}
+		a, act := testActor(t, 100+i, code)
+		kvs = append(kvs, kv(t, statetree.StateTreeVersion5, a, act))
+	}
+	tree := loadTree(t, store, statetree.StateTreeVersion5, ipld.PutBlock(t, store, ipld.HamtNode(false, ipld.HamtBucket(false, kvs...))))
+	migrations := map[cid.Cid]migration.ActorMigration{oldCode: migration.CodeMigrator{OutCodeCID: mustCid(t, "new-code")}}
+
+	for _, strategy := range []migration.Strategy{migration.TopDown, migration.BottomUp} {
+		var done, totals []int
+		cfg := migration.Config{MaxWorkers: 2, Strategy: strategy, Progress: migration.ProgressFunc(func(actorsDone, actorsTotal int, elapsed time.Duration) {
+			assert.True(t, elapsed >= 0)
+			done = append(done, actorsDone)
+			totals = append(totals, actorsTotal)
+		})}
+		_, err := migration.MigrateActors(ctx, store, cfg, migration.NewMemMigrationCache(), tree, migrations)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, done, strategy)
+		assert.Equal(t, []int{4, 4, 4, 4, 4}, totals, strategy)
+
+		// Past the first report, only the final one is made within a long interval.
+		done = nil
+		cfg.ProgressInterval = time.Hour
+		_, err = migration.MigrateActors(ctx, store, cfg, migration.NewMemMigrationCache(), tree, migrations)
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 4}, done, strategy)
+	}
+}
+
+// Cancels a context while migrating the first actor.
+type cancellingMigrator struct {
+	migration.CodeMigrator
+	cancel context.CancelFunc
+	calls  int
+}
+
+func (m *cancellingMigrator) MigrateState(ctx context.Context, store ipldcbor.IpldStore, in migration.ActorMigrationInput) (*migration.ActorMigrationResult, error) {
+	m.calls++
+	m.cancel()
+	return m.CodeMigrator.MigrateState(ctx, store, in)
+}
+
+func TestMigrateActorsCancellation(t *testing.T) {
+	store := ipld.NewMemStore()
+	oldCode := mustCid(t, "old-code")
+	var kvs [][]byte
+	for i := uint64(0); i < 3; i++ {
+		a, act := testActor(t, 100+i, oldCode)
+		kvs = append(kvs, kv(t, statetree.StateTreeVersion5, a, act))
+	}
+	tree := loadTree(t, store, statetree.StateTreeVersion5, ipld.PutBlock(t, store, ipld.HamtNode(false, ipld.HamtBucket(false, kvs...))))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &cancellingMigrator{CodeMigrator: migration.CodeMigrator{OutCodeCID: mustCid(t, "new-code")}, cancel: cancel}
+	migrations := map[cid.Cid]migration.ActorMigration{oldCode: m}
+	_, err := migration.MigrateActors(ctx, store, migration.Config{MaxWorkers: 1}, nil, tree, migrations)
+	assert.True(t, xerrors.Is(err, context.Canceled), "%v", err)
+	assert.Equal(t, 1, m.calls)
+
+	// A done context stops both strategies before any work.
+	for _, strategy := range []migration.Strategy{migration.TopDown, migration.BottomUp} {
+		_, err := migration.MigrateActors(ctx, store, migration.Config{Strategy: strategy}, nil, tree, map[cid.Cid]migration.ActorMigration{oldCode: m.CodeMigrator})
+		assert.True(t, xerrors.Is(err, context.Canceled), "%s: %v", strategy, err)
+	}
+	assert.Equal(t, 1, m.calls)
+}
+
+func testActor(t *testing.T, id uint64, code cid.Cid) (addr.Address, statetree.Actor) {
+	a, err := addr.NewIDAddress(id)
+	require.NoError(t, err)
+	return a, statetree.Actor{Code: code, Head: mustCid(t, "head"), Nonce: id, Balance: big.NewInt(int64(id))}
+}
+
+// Loads a state tree of some version with an actors HAMT root, wrapping it in a state root as needed.
+func loadTree(t *testing.T, store *ipld.MemStore, version statetree.StateTreeVersion, actors cid.Cid) *statetree.StateTree {
+	root := actors
+	if version != statetree.StateTreeVersion0 {
+		info := ipld.PutBlock(t, store, []byte{0x80})
+		var buf bytes.Buffer
+		require.NoError(t, (&statetree.StateRoot{Version: version, Actors: actors, Info: info}).MarshalCBOR(&buf))
+		root = ipld.PutBlock(t, store, buf.Bytes())
+	}
+	tree, err := statetree.LoadStateTree(context.Background(), store, root)
+	require.NoError(t, err)
+	return tree
+}
+
+func kv(t *testing.T, version statetree.StateTreeVersion, a addr.Address, act statetree.Actor) []byte {
+	b, err := statetree.EncodeActor(version, &act)
+	require.NoError(t, err)
+	return ipld.HamtEntry(a.Bytes(), b)
+}
@@ -0,0 +1,137 @@
+package migration
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"golang.org/x/xerrors"
+)
+
+// Config configures the concurrent migration of actors.
+type Config struct {
+	// Number of migration worker goroutines. At least one worker is always run.
+	MaxWorkers int
+	// Budget, in bytes, for the estimated memory of jobs in flight. Scheduling of new jobs pauses while
+	// the budget is exhausted. Zero means unlimited.
+	MemoryBudget int64
+	// How MigrateActors traverses the state tree.
+	Strategy Strategy
+	// Optional reporter of the progress of MigrateActors.
+	Progress ProgressReporter
+	// Minimum interval between progress reports, other than the first and last. Zero reports every actor.
+	ProgressInterval time.Duration
+}
+
+// A MigrationJob migrates a single actor.
+type MigrationJob struct {
+	ActorMigrationInput
+	Migrator ActorMigration
+	// Estimated peak memory needed by the migration, in bytes, such as from the size of the actor's state.
+	// Zero if negligible.
+	EstimatedSize int64
+}
+
+// The result of a MigrationJob.
+type MigrationJobResult struct {
+	ActorMigrationInput
+	ActorMigrationResult
+}
+
+// RunMigrationJobs migrates actors from the jobs channel concurrently, until the channel is closed, calling cb
+// with each result. Calls to cb are serialized, but in no particular order.
+// Jobs are dispatched to workers only while their estimated memory fits within the budget. The first error from
+// a migration or from cb stops the migration, and is returned, after any jobs in flight finish. Cancellation of
+// ctx likewise stops the migration: jobs already dispatched to workers are dropped, and their migrations
+// should return promptly.
+func RunMigrationJobs(ctx context.Context, store ipldcbor.IpldStore, cfg Config, jobs <-chan *MigrationJob, cb func(*MigrationJobResult) error) error {
+	workers := cfg.MaxWorkers
+	if workers < 1 {
+		workers = 1
+	}
+	budget := NewMemoryBudget(cfg.MemoryBudget)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var errOnce sync.Once
+	var firstErr error
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	var cbLk sync.Mutex
+	work := make(chan *MigrationJob)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range work {
+				if ctx.Err() != nil {
+					budget.Release(job.EstimatedSize)
+					continue
+				}
+				err := runMigrationJob(ctx, store, job, func(r *MigrationJobResult) error {
+					cbLk.Lock()
+					defer cbLk.Unlock()
+					return cb(r)
+				})
+				budget.Release(job.EstimatedSize)
+				if err != nil {
+					fail(err)
+				}
+			}
+		}()
+	}
+
+	err := dispatchMigrationJobs(ctx, budget, jobs, work)
+	close(work)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if err == nil {
+		// Jobs may have been dropped after the last was dispatched.
+		err = ctx.Err()
+	}
+	return err
+}
+
+func dispatchMigrationJobs(ctx context.Context, budget *MemoryBudget, jobs <-chan *MigrationJob, work chan<- *MigrationJob) error {
+	for {
+		var job *MigrationJob
+		select {
+		case j, ok := <-jobs:
+			if !ok {
+				return nil
+			}
+			job = j
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		if err := budget.Acquire(ctx, job.EstimatedSize); err != nil {
+			return err
+		}
+		select {
+		case work <- job:
+		case <-ctx.Done():
+			budget.Release(job.EstimatedSize)
+			return ctx.Err()
+		}
+	}
+}
+
+func runMigrationJob(ctx context.Context, store ipldcbor.IpldStore, job *MigrationJob, cb func(*MigrationJobResult) error) error {
+	result, err := job.Migrator.MigrateState(ctx, store, job.ActorMigrationInput)
+	if err != nil {
+		return xerrors.Errorf("state migration failed for actor %s (head %s): %w", job.Address, job.Head, err)
+	}
+	return cb(&MigrationJobResult{ActorMigrationInput: job.ActorMigrationInput, ActorMigrationResult: *result})
+}
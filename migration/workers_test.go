@@ -0,0 +1,124 @@
+package migration_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/filecoin-project/go-address"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/migration"
+)
+
+func TestMemoryBudget(t *testing.T) {
+	ctx := context.Background()
+	b := migration.NewMemoryBudget(100)
+	require.NoError(t, b.Acquire(ctx, 60))
+	require.NoError(t, b.Acquire(ctx, 40))
+	assert.Equal(t, int64(100), b.InUse())
+
+	// Blocks until released.
+	acquired := make(chan error)
+	go func() { acquired <- b.Acquire(ctx, 50) }()
+	select {
+	case <-acquired:
+		t.Fatal("acquired beyond budget")
+	case <-time.After(10 * time.Millisecond):
+	}
+	b.Release(60)
+	require.NoError(t, <-acquired)
+	assert.Equal(t, int64(90), b.InUse())
+
+	// Cancellation unblocks a waiter.
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Equal(t, context.Canceled, b.Acquire(cctx, 20))
+
+	// An oversized request is admitted when nothing else is in flight.
+	b.Release(90)
+	require.NoError(t, b.Acquire(ctx, 500))
+}
+
+// Records the peak total estimated size of concurrent migrations.
+type sizeTrackingMigrator struct {
+	migration.CodeMigrator
+	size int64
+
+	lk    *sync.Mutex
+	inUse *int64
+	peak  *int64
+}
+
+func (m sizeTrackingMigrator) MigrateState(ctx context.Context, store ipldcbor.IpldStore, in migration.ActorMigrationInput) (*migration.ActorMigrationResult, error) {
+	m.lk.Lock()
+	*m.inUse += m.size
+	if *m.inUse > *m.peak {
+		*m.peak = *m.inUse
+	}
+	m.lk.Unlock()
+	time.Sleep(time.Millisecond)
+	m.lk.Lock()
+	*m.inUse -= m.size
+	m.lk.Unlock()
+	return m.CodeMigrator.MigrateState(ctx, store, in)
+}
+
+func TestRunMigrationJobs(t *testing.T) {
+	ctx := context.Background()
+	code := mustCid(t, "new-code")
+	var lk sync.Mutex
+	var inUse, peak int64
+
+	var queued []*migration.MigrationJob
+	for i := 0; i < 50; i++ {
+		a, err := address.NewIDAddress(uint64(100 + i))
+		require.NoError(t, err)
+		size := int64(10 + i%3*20)
+		queued = append(queued, &migration.MigrationJob{
+			ActorMigrationInput: migration.ActorMigrationInput{Address: a, Head: mustCid(t, a.String())},
+			Migrator:            sizeTrackingMigrator{CodeMigrator: migration.CodeMigrator{OutCodeCID: code}, size: size, lk: &lk, inUse: &inUse, peak: &peak},
+			EstimatedSize:       size,
+		})
+	}
+	jobs := make(chan *migration.MigrationJob)
+	go func() {
+		defer close(jobs)
+		for _, job := range queued {
+			jobs <- job
+		}
+	}()
+
+	results := map[address.Address]*migration.MigrationJobResult{}
+	err := migration.RunMigrationJobs(ctx, nil, migration.Config{MaxWorkers: 8, MemoryBudget: 60}, jobs, func(r *migration.MigrationJobResult) error {
+		results[r.Address] = r
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Len(t, results, 50)
+	for _, r := range results {
+		assert.Equal(t, code, r.NewCodeCID)
+		assert.Equal(t, r.Head, r.NewHead)
+	}
+	assert.LessOrEqual(t, peak, int64(60))
+}
+
+func TestRunMigrationJobsError(t *testing.T) {
+	a, err := address.NewIDAddress(100)
+	require.NoError(t, err)
+	jobs := make(chan *migration.MigrationJob, 2)
+	jobs <- &migration.MigrationJob{
+		ActorMigrationInput: migration.ActorMigrationInput{Address: a, Head: mustCid(t, "head")},
+		Migrator:            migration.DeferredMigrator{OutCodeCID: mustCid(t, "new-code")},
+	}
+	close(jobs)
+
+	err = migration.RunMigrationJobs(context.Background(), nil, migration.Config{MaxWorkers: 2}, jobs, func(*migration.MigrationJobResult) error {
+		return xerrors.New("unexpected result")
+	})
+	assert.Error(t, err)
+}
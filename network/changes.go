@@ -0,0 +1,172 @@
+package network
+
+import "sort"
+
+// ChangeKind classifies a change to protocol behaviour at a network version.
+type ChangeKind int
+
+const (
+	// A new version of the builtin actors is deployed, with a state migration.
+	ChangeActors ChangeKind = iota
+	// Actor methods are added, removed, or change parameters.
+	ChangeMethods
+	// Registered proof types are enabled or disabled.
+	ChangeProofs
+	// The gas model or fee market changes.
+	ChangeGas
+	// Consensus rules outside the actors change.
+	ChangeConsensus
+)
+
+var changeKindNames = map[ChangeKind]string{
+	ChangeActors:    "Actors",
+	ChangeMethods:   "Methods",
+	ChangeProofs:    "Proofs",
+	ChangeGas:       "Gas",
+	ChangeConsensus: "Consensus",
+}
+
+func (k ChangeKind) String() string {
+	if name, ok := changeKindNames[k]; ok {
+		return name
+	}
+	return "Unknown"
+}
+
+// A Change describes one change in protocol behaviour taking effect at a network version.
+type Change struct {
+	Kind        ChangeKind
+	Description string
+}
+
+// The changes at each network version. This is descriptive, intended for compatibility checks and
+// display; behaviour is defined by the code keyed on each version.
+var changes = map[Version][]Change{
+	Version0: {
+		{ChangeActors, "Genesis with actors version 0"},
+		{ChangeProofs, "StackedDrg V1 seal proofs and corresponding PoSt proofs enabled"},
+	},
+	Version1: {
+		{ChangeActors, "Actors version 0 patch release, without a change to state layout"},
+	},
+	Version2: {
+		{ChangeGas, "Base fee adjustment no longer scales gas usage by the packing efficiency"},
+	},
+	Version3: {
+		{ChangeActors, "Actors version 0 patch release, without a change to state layout"},
+	},
+	Version4: {
+		{ChangeActors, "Actors version 2, with a state migration"},
+		{ChangeMethods, "Miner methods ConfirmUpdateWorkerKey, RepayDebt and ChangeOwnerAddress added"},
+		{ChangeConsensus, "Miners reported for a consensus fault are ineligible for block rewards until the fault elapses"},
+	},
+	Version5: {
+		{ChangeActors, "Actors version 2 patch release, without a change to state layout"},
+	},
+	Version6: {
+		{ChangeActors, "Actors version 2 patch release, without a change to state layout"},
+	},
+	Version7: {
+		{ChangeActors, "Actors version 2 patch release, without a change to state layout"},
+		{ChangeGas, "Storage and syscall gas repriced (calico)"},
+		{ChangeProofs, "StackedDrg V1_1 seal proofs enabled"},
+	},
+	Version8: {
+		{ChangeActors, "Actors version 2 behaviour transition, without a change to state layout"},
+		{ChangeProofs, "StackedDrg V1 seal proofs disabled for new sectors"},
+	},
+	Version9: {
+		{ChangeActors, "Actors version 2 behaviour transition, without a change to state layout"},
+	},
+	Version10: {
+		{ChangeActors, "Actors version 3, with a state migration"},
+		{ChangeMethods, "Miner method DisputeWindowedPoSt added, with Window PoSt proofs accepted optimistically"},
+	},
+	Version11: {
+		{ChangeActors, "Actors version 3 patch release, without a change to state layout"},
+	},
+	Version12: {
+		{ChangeActors, "Actors version 4, with a state migration, from which a sector's initial pledge is at least 1 attoFIL"},
+	},
+	Version13: {
+		{ChangeActors, "Actors version 5, with a state migration"},
+		{ChangeMethods, "Miner methods PreCommitSectorBatch and ProveCommitAggregate added"},
+		{ChangeProofs, "SnarkPackV1 aggregation of seal proofs enabled"},
+	},
+	Version14: {
+		{ChangeActors, "Actors version 6, with a state migration"},
+		{ChangeGas, "Network fee charged for batched pre-commits and aggregated prove-commits"},
+	},
+	Version15: {
+		{ChangeActors, "Actors version 7, with a state migration"},
+		{ChangeMethods, "Miner method ProveReplicaUpdates added, for replica updates (snap deals)"},
+		{ChangeProofs, "Replica update proofs enabled"},
+	},
+	Version16: {
+		{ChangeActors, "Builtin actors version 8, with a state migration"},
+		{ChangeConsensus, "Messages executed by the FVM"},
+		{ChangeGas, "Wasm execution and memory charged (skyr)"},
+	},
+	Version17: {
+		{ChangeActors, "Builtin actors version 9, with a state migration"},
+		{ChangeMethods, "Datacap actor added, and verified registry allocations and claims replace verified deals"},
+		{ChangeMethods, "Miner beneficiary address added"},
+	},
+	Version18: {
+		{ChangeActors, "Builtin actors version 10, with a state migration"},
+		{ChangeMethods, "User-deployed EVM actors, and the Ethereum address manager and placeholder actors, added"},
+		{ChangeGas, "User-deployed actor and EVM execution charged (hygge)"},
+	},
+	Version19: {
+		{ChangeActors, "Builtin actors version 11, with a state migration"},
+		{ChangeProofs, "StackedDrg Window PoSt V1_1 proofs enabled"},
+	},
+	Version20: {
+		{ChangeProofs, "StackedDrg Window PoSt V1 proofs disabled"},
+	},
+	Version21: {
+		{ChangeActors, "Builtin actors version 12, with a state migration"},
+		{ChangeMethods, "Miner method MovePartitions added, and the maximum deal duration extended"},
+		{ChangeProofs, "Synthetic PoRep seal proofs enabled"},
+		{ChangeGas, "Hashing and event emission repriced (watermelon)"},
+	},
+	Version22: {
+		{ChangeActors, "Builtin actors version 13, with a state migration"},
+		{ChangeMethods, "Miner methods ProveCommitSectors3 and ProveReplicaUpdates3 added, for direct data onboarding"},
+		{ChangeMethods, "Market deal payments settled on demand rather than by cron"},
+	},
+	Version23: {
+		{ChangeActors, "Builtin actors version 14, with a state migration"},
+		{ChangeMethods, "Miner method ProveCommitSectorsNI added"},
+		{ChangeProofs, "Non-interactive PoRep seal proofs, aggregated with SnarkPackV2, enabled"},
+	},
+}
+
+// ChangesAt returns the changes taking effect at a network version, or nil if none are recorded.
+func ChangesAt(v Version) []Change {
+	cs := changes[v]
+	if cs == nil {
+		return nil
+	}
+	out := make([]Change, len(cs))
+	copy(out, cs)
+	return out
+}
+
+// ChangesBetween returns the changes taking effect after network version from, up to and including version to,
+// in version order.
+func ChangesBetween(from, to Version) []Change {
+	versions := make([]Version, 0, len(changes))
+	for v := range changes {
+		if v > from && v <= to {
+			versions = append(versions, v)
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	var out []Change
+	for _, v := range versions {
+		out = append(out, changes[v]...)
+	}
+	return out
+}
@@ -0,0 +1,29 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChanges(t *testing.T) {
+	// Every version has an entry.
+	for v := Version0; v < VersionCount; v++ {
+		assert.NotEmpty(t, ChangesAt(v), "no changes for %v", v)
+	}
+	assert.Nil(t, ChangesAt(VersionCount))
+
+	// V1_1 seal proofs were enabled at version 7, and V1 disabled for new sectors at version 8.
+	assert.Contains(t, ChangesAt(Version7), Change{ChangeProofs, "StackedDrg V1_1 seal proofs enabled"})
+	assert.Contains(t, ChangesAt(Version8), Change{ChangeProofs, "StackedDrg V1 seal proofs disabled for new sectors"})
+
+	// Changes are returned in version order, excluding the lower bound.
+	between := ChangesBetween(Version3, Version5)
+	assert.Equal(t, append(ChangesAt(Version4), ChangesAt(Version5)...), between)
+	assert.Empty(t, ChangesBetween(Version5, Version5))
+
+	// The returned changes are a copy.
+	cs := ChangesAt(Version0)
+	cs[0].Description = "changed"
+	assert.NotEqual(t, "changed", ChangesAt(Version0)[0].Description)
+}
@@ -0,0 +1,92 @@
+package network
+
+// FVMVersion is the major version of the Filecoin Virtual Machine executing messages, which defines the
+// machine context (syscalls, kernel and actor code loading) available to actors.
+type FVMVersion int
+
+const (
+	// Messages are executed by the legacy VM, before the FVM.
+	FVMVersionNone FVMVersion = 0
+	FVMVersion2    FVMVersion = 2
+	FVMVersion3    FVMVersion = 3
+	FVMVersion4    FVMVersion = 4
+)
+
+// GasSchedule identifies the schedule of gas prices charged for execution.
+type GasSchedule int
+
+const (
+	GasScheduleGenesis GasSchedule = iota
+	// Repriced storage and syscalls (calico).
+	GasScheduleCalico
+	// Wasm execution and memory charges with the first FVM (skyr).
+	GasScheduleSkyr
+	// Charges for user-deployed actors and the EVM (hygge).
+	GasScheduleHygge
+	// Repriced hashing and event emission (watermelon).
+	GasScheduleWatermelon
+)
+
+// Execution describes the semantics with which messages are executed at a network version.
+type Execution struct {
+	FVM FVMVersion
+	Gas GasSchedule
+}
+
+// The execution semantics at each network version. A new network version must be added here.
+var executions = map[Version]Execution{
+	Version0:  {FVMVersionNone, GasScheduleGenesis},
+	Version1:  {FVMVersionNone, GasScheduleGenesis},
+	Version2:  {FVMVersionNone, GasScheduleGenesis},
+	Version3:  {FVMVersionNone, GasScheduleGenesis},
+	Version4:  {FVMVersionNone, GasScheduleGenesis},
+	Version5:  {FVMVersionNone, GasScheduleGenesis},
+	Version6:  {FVMVersionNone, GasScheduleGenesis},
+	Version7:  {FVMVersionNone, GasScheduleCalico},
+	Version8:  {FVMVersionNone, GasScheduleCalico},
+	Version9:  {FVMVersionNone, GasScheduleCalico},
+	Version10: {FVMVersionNone, GasScheduleCalico},
+	Version11: {FVMVersionNone, GasScheduleCalico},
+	Version12: {FVMVersionNone, GasScheduleCalico},
+	Version13: {FVMVersionNone, GasScheduleCalico},
+	Version14: {FVMVersionNone, GasScheduleCalico},
+	Version15: {FVMVersionNone, GasScheduleCalico},
+	Version16: {FVMVersion2, GasScheduleSkyr},
+	Version17: {FVMVersion2, GasScheduleSkyr},
+	Version18: {FVMVersion3, GasScheduleHygge},
+	Version19: {FVMVersion3, GasScheduleHygge},
+	Version20: {FVMVersion3, GasScheduleHygge},
+	Version21: {FVMVersion4, GasScheduleWatermelon},
+	Version22: {FVMVersion4, GasScheduleWatermelon},
+	Version23: {FVMVersion4, GasScheduleWatermelon},
+}
+
+// ExecutionAt returns the execution semantics at a network version, and whether the version is known.
+func ExecutionAt(v Version) (Execution, bool) {
+	e, ok := executions[v]
+	return e, ok
+}
+
+// FVMVersionAt returns the FVM version executing messages at a network version, and whether the version is
+// known.
+func FVMVersionAt(v Version) (FVMVersion, bool) {
+	e, ok := executions[v]
+	return e.FVM, ok
+}
+
+// GasScheduleAt returns the gas schedule at a network version, and whether the version is known.
+func GasScheduleAt(v Version) (GasSchedule, bool) {
+	e, ok := executions[v]
+	return e.Gas, ok
+}
+
+// FirstVersionWithFVM returns the first network version at which messages are executed by some version of the
+// FVM, and whether there is one.
+func FirstVersionWithFVM(fvm FVMVersion) (Version, bool) {
+	for v := Version0; v < VersionCount; v++ {
+		if executions[v].FVM == fvm {
+			return v, true
+		}
+	}
+	return 0, false
+}
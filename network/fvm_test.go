@@ -0,0 +1,35 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecution(t *testing.T) {
+	// Every version has an entry, and neither the FVM nor the gas schedule ever goes backwards.
+	var prev Execution
+	for v := Version0; v < VersionCount; v++ {
+		e, ok := ExecutionAt(v)
+		require.True(t, ok, "no execution semantics for %v", v)
+		assert.GreaterOrEqual(t, int(e.FVM), int(prev.FVM), v.String())
+		assert.GreaterOrEqual(t, int(e.Gas), int(prev.Gas), v.String())
+		prev = e
+	}
+	_, ok := ExecutionAt(VersionCount)
+	assert.False(t, ok)
+
+	fvm, ok := FVMVersionAt(Version15)
+	require.True(t, ok)
+	assert.Equal(t, FVMVersionNone, fvm)
+	gas, ok := GasScheduleAt(Version21)
+	require.True(t, ok)
+	assert.Equal(t, "Watermelon", gas.String())
+
+	v, ok := FirstVersionWithFVM(FVMVersion3)
+	require.True(t, ok)
+	assert.Equal(t, Version18, v)
+	_, ok = FirstVersionWithFVM(FVMVersion(1))
+	assert.False(t, ok)
+}
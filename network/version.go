@@ -1,17 +1,65 @@
 package network
 
-import "math"
+import (
+	"math"
+	"strconv"
+)
 
 // Enumeration of network upgrades where actor behaviour can change (without necessarily
 // vendoring and versioning the whole actor codebase).
 type Version uint
 
 const (
-	Version0 = Version(iota) // specs-actors v0.9.3
-	Version1                 // specs-actors v0.9.7
-	Version2                 // specs-actors v0.9.?
-	Version3                 // Coming soon
-	Version4                 // Who knows?
+	Version0  = Version(iota) // specs-actors v0.9.3
+	Version1                  // specs-actors v0.9.7
+	Version2                  // specs-actors v0.9.?
+	Version3                  // Coming soon
+	Version4                  // Who knows?
+	Version5                  // tape (specs-actors v2.1)
+	Version6                  // kumquat (specs-actors v2.2)
+	Version7                  // calico (specs-actors v2.3)
+	Version8                  // persian (post-v2.3 behaviour transition)
+	Version9                  // orange (post-v2.3 behaviour transition)
+	Version10                 // trust (specs-actors v3)
+	Version11                 // norwegian (specs-actors v3.1)
+	Version12                 // turbo (specs-actors v4)
+	Version13                 // hyperdrive (specs-actors v5)
+	Version14                 // chocolate (specs-actors v6)
+	Version15                 // OhSnap (specs-actors v7)
+	Version16                 // skyr (builtin-actors v8)
+	Version17                 // shark (builtin-actors v9)
+	Version18                 // hygge (builtin-actors v10)
+	Version19                 // lightning (builtin-actors v11)
+	Version20                 // thunder (builtin-actors v11)
+	Version21                 // watermelon (builtin-actors v12)
+	Version22                 // dragon (builtin-actors v13)
+	Version23                 // waffle (builtin-actors v14)
+
+	// The number of network versions above. A new version must be added before this.
+	VersionCount
+
+	// The version of current mainnet behaviour. This is updated when mainnet upgrades, which may be after
+	// the next version is added above.
+	VersionLatest = Version23
+	// The upcoming version, which may not yet be enumerated above.
+	VersionNext = VersionLatest + 1
 
 	VersionMax = Version(math.MaxUint32)
 )
+
+// The latest version pinned at build time, as a decimal string, with
+// -ldflags "-X github.com/filecoin-project/go-state-types/network.pinnedLatest=<version>".
+var pinnedLatest string
+
+// LatestVersion returns the version of current mainnet behaviour: the version pinned at build time if any,
+// and otherwise VersionLatest. An invalid pinned version panics.
+func LatestVersion() Version {
+	if pinnedLatest == "" {
+		return VersionLatest
+	}
+	v, err := strconv.ParseUint(pinnedLatest, 10, 32)
+	if err != nil || Version(v) > VersionNext {
+		panic("invalid pinned latest network version " + strconv.Quote(pinnedLatest))
+	}
+	return Version(v)
+}
@@ -0,0 +1,25 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatestVersion(t *testing.T) {
+	assert.Less(t, uint(VersionLatest), uint(VersionCount))
+	assert.Equal(t, VersionLatest+1, VersionNext)
+	assert.Equal(t, VersionLatest, LatestVersion())
+
+	defer func() { pinnedLatest = "" }()
+	pinnedLatest = "22"
+	assert.Equal(t, Version22, LatestVersion())
+	pinnedLatest = "99"
+	assert.Panics(t, func() { LatestVersion() })
+}
+
+func TestVersionString(t *testing.T) {
+	assert.Equal(t, "Version0", Version0.String())
+	assert.Equal(t, "Version21", Version21.String())
+	assert.Equal(t, "Version(1000)", Version(1000).String())
+}
@@ -0,0 +1,29 @@
+package proof
+
+import (
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// The per-sector information necessary to verify a sector's PoRep within an aggregate proof.
+type AggregateSealVerifyInfo struct {
+	Number                abi.SectorNumber
+	Randomness            abi.SealRandomness
+	InteractiveRandomness abi.InteractiveSealRandomness
+
+	// Safe because we get those from the miner actor
+	SealedCID   cid.Cid `checked:"true"` // CommR
+	UnsealedCID cid.Cid `checked:"true"` // CommD
+}
+
+// An aggregate proof of the PoReps of many sectors of one miner, with the information needed to verify it.
+// For non-interactive PoReps, the interactive randomness of each sector is empty and the aggregation
+// must be SnarkPackV2.
+type AggregateSealVerifyProofAndInfos struct {
+	Miner          abi.ActorID
+	SealProof      abi.RegisteredSealProof
+	AggregateProof abi.RegisteredAggregationProof
+	Proof          []byte
+	Infos          []AggregateSealVerifyInfo
+}
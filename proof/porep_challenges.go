@@ -0,0 +1,124 @@
+package proof
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// Challenge derivation for interactive PoRep, following the stacked DRG construction of the proofs library.
+// The replica ID binds a replica to its prover, sector, ticket and data; the interactive seed, drawn after
+// pre-commitment, then selects the challenged nodes. Deriving both locally lets a verifier check the inputs
+// of a SealVerifyInfo before passing it to the (expensive) proof verification.
+
+// PoRepID returns the identifier of a seal proof type mixed into replica IDs: the proof type as 8
+// little-endian bytes, followed by a zero nonce (8 bytes) and zero padding.
+func PoRepID(p abi.RegisteredSealProof) ([32]byte, error) {
+	var id [32]byte
+	if _, ok := abi.SealProofInfos[p]; !ok {
+		return id, xerrors.Errorf("unsupported seal proof type: %v", p)
+	}
+	binary.LittleEndian.PutUint64(id[:8], uint64(p))
+	return id, nil
+}
+
+// ReplicaID returns the replica ID of a sector: the SHA-256 hash of the prover ID, the sector number (8
+// big-endian bytes), the ticket, the unsealed sector commitment (CommD) and the PoRep ID, truncated to a
+// field element.
+func ReplicaID(p abi.RegisteredSealProof, minerID abi.ActorID, sector abi.SectorNumber, ticket abi.SealRandomness, commD abi.Commitment) ([32]byte, error) {
+	var replicaID [32]byte
+	porepID, err := PoRepID(p)
+	if err != nil {
+		return replicaID, err
+	}
+	if len(ticket) != 32 {
+		return replicaID, xerrors.Errorf("ticket must be 32 bytes, got %d", len(ticket))
+	}
+	prover := proverID(minerID)
+	var sectorBytes [8]byte
+	binary.BigEndian.PutUint64(sectorBytes[:], uint64(sector))
+
+	h := sha256.New()
+	h.Write(prover[:])
+	h.Write(sectorBytes[:])
+	h.Write(ticket)
+	h.Write(commD[:])
+	h.Write(porepID[:])
+	copy(replicaID[:], h.Sum(nil))
+	replicaID[31] &= 0x3f
+	return replicaID, nil
+}
+
+// PoRepChallenges returns the nodes challenged in each partition of an interactive PoRep of a replica.
+// Challenge i of partition k is the SHA-256 hash of the replica ID, the seed and the challenge's overall index
+// (4 little-endian bytes), as a little-endian integer, modulo the number of nodes less one, plus one. Node zero
+// is never challenged.
+// The challenges of synthetic and non-interactive PoReps are selected differently, and are not supported.
+func PoRepChallenges(p abi.RegisteredSealProof, replicaID [32]byte, seed abi.InteractiveSealRandomness) ([][]uint64, error) {
+	if p.IsSynthetic() || p.IsNonInteractive() {
+		return nil, xerrors.Errorf("challenges of seal proof type %v are not interactive", p)
+	}
+	if len(seed) != 32 {
+		return nil, xerrors.Errorf("seed must be 32 bytes, got %d", len(seed))
+	}
+	size, err := p.SectorSize()
+	if err != nil {
+		return nil, err
+	}
+	partitions, perPartition, err := PoRepChallengeCount(p)
+	if err != nil {
+		return nil, err
+	}
+	modulus := new(big.Int).SetUint64(uint64(size)/leafSize - 1)
+
+	challenges := make([][]uint64, partitions)
+	var digest [sha256.Size]byte
+	n := new(big.Int)
+	for k := range challenges {
+		challenges[k] = make([]uint64, perPartition)
+		for i := range challenges[k] {
+			var index [4]byte
+			binary.LittleEndian.PutUint32(index[:], uint32(uint64(k)*perPartition+uint64(i)))
+			h := sha256.New()
+			h.Write(replicaID[:])
+			h.Write(seed)
+			h.Write(index[:])
+			h.Sum(digest[:0])
+			reverse(digest[:]) // little-endian => big-endian
+			n.SetBytes(digest[:])
+			challenges[k][i] = n.Mod(n, modulus).Uint64() + 1
+		}
+	}
+	return challenges, nil
+}
+
+// SealVerifyInfoChallenges derives the replica ID and the challenged nodes of the PoRep of a SealVerifyInfo,
+// checking the well-formedness of its commitments and randomness along the way.
+func SealVerifyInfoChallenges(info *SealVerifyInfo) ([32]byte, [][]uint64, error) {
+	if err := abi.ValidateSealedCID(info.SealedCID, info.SealProof); err != nil {
+		return [32]byte{}, nil, err
+	}
+	commD, err := abi.UnsealedCommitment(info.UnsealedCID)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	replicaID, err := ReplicaID(info.SealProof, info.Miner, info.Number, info.Randomness, commD)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	challenges, err := PoRepChallenges(info.SealProof, replicaID, info.InteractiveRandomness)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	return replicaID, challenges, nil
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
@@ -0,0 +1,127 @@
+package proof_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	cid "github.com/ipfs/go-cid"
+	mh "github.com/multiformats/go-multihash"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/proof"
+)
+
+func TestReplicaID(t *testing.T) {
+	p := abi.RegisteredSealProof_StackedDrg32GiBV2
+	ticket := bytes.Repeat([]byte{0xff}, 32)
+	var commD [32]byte
+	commD[0] = 7
+
+	porepID, err := proof.PoRepID(p)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(p), binary.LittleEndian.Uint64(porepID[:8]))
+	assert.Equal(t, make([]byte, 24), porepID[8:])
+
+	replicaID, err := proof.ReplicaID(p, 1000, 42, ticket, commD)
+	require.NoError(t, err)
+	var prover [32]byte
+	binary.PutUvarint(prover[:], 1000)
+	var preimage []byte
+	preimage = append(preimage, prover[:]...)
+	preimage = append(preimage, 0, 0, 0, 0, 0, 0, 0, 42)
+	preimage = append(preimage, ticket...)
+	preimage = append(preimage, commD[:]...)
+	preimage = append(preimage, porepID[:]...)
+	expected := sha256.Sum256(preimage)
+	expected[31] &= 0x3f
+	assert.Equal(t, expected, replicaID)
+
+	// Every input is bound into the replica ID.
+	other, err := proof.ReplicaID(p, 1000, 43, ticket, commD)
+	require.NoError(t, err)
+	assert.NotEqual(t, replicaID, other)
+	other, err = proof.ReplicaID(abi.RegisteredSealProof_StackedDrg32GiBV1, 1000, 42, ticket, commD)
+	require.NoError(t, err)
+	assert.NotEqual(t, replicaID, other)
+
+	_, err = proof.ReplicaID(p, 1000, 42, ticket[:31], commD)
+	assert.Error(t, err)
+	_, err = proof.ReplicaID(abi.RegisteredSealProof(99), 1000, 42, ticket, commD)
+	assert.Error(t, err)
+}
+
+func TestPoRepChallenges(t *testing.T) {
+	p := abi.RegisteredSealProof_StackedDrg32GiBV2
+	var replicaID [32]byte
+	replicaID[0] = 1
+	seed := bytes.Repeat([]byte{3}, 32)
+
+	challenges, err := proof.PoRepChallenges(p, replicaID, seed)
+	require.NoError(t, err)
+	require.Len(t, challenges, 10)
+	nodes := uint64(abi.SectorSize32GiB) / 32
+	for _, partition := range challenges {
+		require.Len(t, partition, 18)
+		for _, c := range partition {
+			assert.True(t, c >= 1 && c < nodes, c)
+		}
+	}
+	// The first challenge of the second partition is the 19th overall.
+	h := sha256.New()
+	h.Write(replicaID[:])
+	h.Write(seed)
+	h.Write([]byte{18, 0, 0, 0})
+	digest := h.Sum(nil)
+	for i, j := 0, len(digest)-1; i < j; i, j = i+1, j-1 {
+		digest[i], digest[j] = digest[j], digest[i]
+	}
+	n := new(big.Int).SetBytes(digest)
+	n.Mod(n, big.NewInt(int64(nodes-1)))
+	assert.Equal(t, n.Uint64()+1, challenges[1][0])
+
+	again, err := proof.PoRepChallenges(p, replicaID, seed)
+	require.NoError(t, err)
+	assert.Equal(t, challenges, again)
+
+	_, err = proof.PoRepChallenges(abi.RegisteredSealProof_StackedDrg32GiBV1_1_Feat_SyntheticPoRep, replicaID, seed)
+	assert.Error(t, err)
+	_, err = proof.PoRepChallenges(abi.RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep, replicaID, seed)
+	assert.Error(t, err)
+	_, err = proof.PoRepChallenges(p, replicaID, seed[:31])
+	assert.Error(t, err)
+}
+
+func TestSealVerifyInfoChallenges(t *testing.T) {
+	commit := func(codec, hashFn uint64, digest byte) cid.Cid {
+		hash, err := mh.Encode(bytes.Repeat([]byte{digest}, 32), hashFn)
+		require.NoError(t, err)
+		return cid.NewCidV1(codec, hash)
+	}
+	info := proof.SealVerifyInfo{
+		SealProof:             abi.RegisteredSealProof_StackedDrg2KiBV2,
+		SectorID:              abi.SectorID{Miner: 1000, Number: 42},
+		Randomness:            bytes.Repeat([]byte{1}, 32),
+		InteractiveRandomness: bytes.Repeat([]byte{2}, 32),
+		SealedCID:             commit(cid.FilCommitmentSealed, mh.POSEIDON_BLS12_381_A1_FC1, 4),
+		UnsealedCID:           commit(cid.FilCommitmentUnsealed, mh.SHA2_256_TRUNC254_PADDED, 5),
+	}
+	replicaID, challenges, err := proof.SealVerifyInfoChallenges(&info)
+	require.NoError(t, err)
+	var commD [32]byte
+	copy(commD[:], bytes.Repeat([]byte{5}, 32))
+	expected, err := proof.ReplicaID(info.SealProof, 1000, 42, info.Randomness, commD)
+	require.NoError(t, err)
+	assert.Equal(t, expected, replicaID)
+	expectedChallenges, err := proof.PoRepChallenges(info.SealProof, replicaID, info.InteractiveRandomness)
+	require.NoError(t, err)
+	assert.Equal(t, expectedChallenges, challenges)
+
+	info.UnsealedCID = info.SealedCID
+	_, _, err = proof.SealVerifyInfoChallenges(&info)
+	assert.Error(t, err)
+}
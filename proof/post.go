@@ -0,0 +1,9 @@
+package proof
+
+import "github.com/filecoin-project/go-state-types/abi"
+
+// PoStProof is a Window or Winning PoSt proof, with its proof type.
+type PoStProof struct {
+	PoStProof  abi.RegisteredPoStProof
+	ProofBytes []byte
+}
@@ -0,0 +1,188 @@
+package proof
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// Challenge generation for Window and Winning PoSt, following the fallback PoSt construction of the proofs
+// library. Computing challenges locally lets a prover check that every challenged leaf is readable
+// before generating and submitting a proof.
+
+// Number of leaf challenges per sector in a Window PoSt.
+const WindowPoStChallengeCount = 10
+
+// Number of leaf challenges per sector in a Winning PoSt.
+const WinningPoStChallengeCount = 66
+
+// Number of sectors challenged in a Winning PoSt.
+const WinningPoStSectorCount = 1
+
+// Size of a leaf (node) of a sector's replica, in bytes.
+const leafSize = 32
+
+var windowPoStPartitionSectors = map[abi.RegisteredPoStProof]uint64{
+	abi.RegisteredPoStProof_StackedDrgWindow2KiBV1:   2,
+	abi.RegisteredPoStProof_StackedDrgWindow8MiBV1:   2,
+	abi.RegisteredPoStProof_StackedDrgWindow512MiBV1: 2,
+	abi.RegisteredPoStProof_StackedDrgWindow32GiBV1:  2349,
+	abi.RegisteredPoStProof_StackedDrgWindow64GiBV1:  2300,
+	abi.RegisteredPoStProof_StackedDrgWindow2KiBV2:   2,
+	abi.RegisteredPoStProof_StackedDrgWindow8MiBV2:   2,
+	abi.RegisteredPoStProof_StackedDrgWindow512MiBV2: 2,
+	abi.RegisteredPoStProof_StackedDrgWindow32GiBV2:  2349,
+	abi.RegisteredPoStProof_StackedDrgWindow64GiBV2:  2300,
+}
+
+// WindowPoStPartitionSectors returns the number of sectors in a full Window PoSt partition for a proof type.
+func WindowPoStPartitionSectors(p abi.RegisteredPoStProof) (uint64, error) {
+	n, ok := windowPoStPartitionSectors[p]
+	if !ok {
+		return 0, xerrors.Errorf("unsupported window PoSt proof type: %v", p)
+	}
+	return n, nil
+}
+
+// SectorChallenges holds the leaf challenges for one sector of a PoSt.
+type SectorChallenges struct {
+	Sector abi.SectorNumber
+	// Indices of challenged leaves (32-byte nodes) within the sector's replica.
+	Leaves []uint64
+}
+
+// SubstituteFaults returns the sectors to prove for a partition, in order, with each faulty (or skipped) sector
+// replaced by the first good sector in the partition.
+// A partition is proven over a fixed number of positions, so a faulty sector cannot simply be dropped;
+// substituting a known good sector keeps the remaining sectors at the positions the verifier expects.
+func SubstituteFaults(partition []abi.SectorNumber, faulty abi.SectorRangeSet) ([]abi.SectorNumber, error) {
+	substitute, found := abi.SectorNumber(0), false
+	for _, s := range partition {
+		if !faulty.Has(s) {
+			substitute, found = s, true
+			break
+		}
+	}
+	if !found {
+		return nil, xerrors.Errorf("no good sectors among %d in partition", len(partition))
+	}
+	proving := make([]abi.SectorNumber, len(partition))
+	for i, s := range partition {
+		if faulty.Has(s) {
+			s = substitute
+		}
+		proving[i] = s
+	}
+	return proving, nil
+}
+
+// WindowPoStChallenges computes the leaf challenges for each sector proven in a Window PoSt partition.
+// The sectors must be given in proving order, i.e. the partition's sectors in ascending order with faults
+// substituted (see SubstituteFaults), since with the V1 proof types each sector's challenges depend on its
+// position in the partition.
+func WindowPoStChallenges(p abi.RegisteredPoStProof, randomness abi.PoStRandomness, partitionIndex uint64, sectors []abi.SectorNumber) ([]SectorChallenges, error) {
+	partitionSectors, err := WindowPoStPartitionSectors(p)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(sectors)) > partitionSectors {
+		return nil, xerrors.Errorf("too many sectors for partition: %d > %d", len(sectors), partitionSectors)
+	}
+	return postChallenges(p, randomness, partitionIndex*partitionSectors, sectors, WindowPoStChallengeCount)
+}
+
+// WinningPoStSectorChallenges selects the sectors challenged in a Winning PoSt, returning indices into the
+// miner's eligible sectors (in ascending sector number order).
+func WinningPoStSectorChallenges(minerID abi.ActorID, randomness abi.PoStRandomness, eligibleSectorCount uint64) ([]uint64, error) {
+	if eligibleSectorCount == 0 {
+		return nil, xerrors.Errorf("no eligible sectors")
+	}
+	rand := normalizeRandomness(randomness)
+	prover := proverID(minerID)
+	indices := make([]uint64, WinningPoStSectorCount)
+	for n := range indices {
+		h := sha256.New()
+		h.Write(prover[:])
+		h.Write(rand[:])
+		writeUint64LE(h, uint64(n))
+		indices[n] = binary.LittleEndian.Uint64(h.Sum(nil)[:8]) % eligibleSectorCount
+	}
+	return indices, nil
+}
+
+// WinningPoStChallenges computes the leaf challenges for the sectors selected by WinningPoStSectorChallenges.
+func WinningPoStChallenges(p abi.RegisteredPoStProof, randomness abi.PoStRandomness, sectors []abi.SectorNumber) ([]SectorChallenges, error) {
+	if len(sectors) != WinningPoStSectorCount {
+		return nil, xerrors.Errorf("expected %d sectors, got %d", WinningPoStSectorCount, len(sectors))
+	}
+	return postChallenges(p, randomness, 0, sectors, WinningPoStChallengeCount)
+}
+
+// Whether a sector's leaf challenges are independent of its position in the partition. The V2 (V1_1) Window
+// PoSt proof types (FIP-0061) derive each challenge from the challenge number alone, so that a sector is
+// challenged identically whichever partition or position it is proven in.
+func positionIndependentChallenges(p abi.RegisteredPoStProof) bool {
+	switch p {
+	case abi.RegisteredPoStProof_StackedDrgWindow2KiBV2,
+		abi.RegisteredPoStProof_StackedDrgWindow8MiBV2,
+		abi.RegisteredPoStProof_StackedDrgWindow512MiBV2,
+		abi.RegisteredPoStProof_StackedDrgWindow32GiBV2,
+		abi.RegisteredPoStProof_StackedDrgWindow64GiBV2:
+		return true
+	}
+	return false
+}
+
+func postChallenges(p abi.RegisteredPoStProof, randomness abi.PoStRandomness, firstPosition uint64, sectors []abi.SectorNumber, challengeCount uint64) ([]SectorChallenges, error) {
+	sectorSize, err := p.SectorSize()
+	if err != nil {
+		return nil, err
+	}
+	leaves := uint64(sectorSize) / leafSize
+	rand := normalizeRandomness(randomness)
+	positional := !positionIndependentChallenges(p)
+
+	challenges := make([]SectorChallenges, len(sectors))
+	for i, sector := range sectors {
+		position := firstPosition + uint64(i)
+		sc := SectorChallenges{Sector: sector, Leaves: make([]uint64, challengeCount)}
+		for n := uint64(0); n < challengeCount; n++ {
+			index := n
+			if positional {
+				index += position * challengeCount
+			}
+			h := sha256.New()
+			h.Write(rand[:])
+			writeUint64LE(h, uint64(sector))
+			writeUint64LE(h, index)
+			sc.Leaves[n] = binary.LittleEndian.Uint64(h.Sum(nil)[:8]) % leaves
+		}
+		challenges[i] = sc
+	}
+	return challenges, nil
+}
+
+// Truncates randomness to a valid field element (as little-endian bytes), as the proofs library does.
+func normalizeRandomness(randomness abi.PoStRandomness) [32]byte {
+	var out [32]byte
+	copy(out[:], randomness)
+	out[31] &= 0x3f
+	return out
+}
+
+// The prover ID is the miner's actor ID as an unsigned varint, zero-padded to 32 bytes.
+func proverID(minerID abi.ActorID) [32]byte {
+	var out [32]byte
+	binary.PutUvarint(out[:], uint64(minerID))
+	return out
+}
+
+func writeUint64LE(w io.Writer, v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	_, _ = w.Write(b[:])
+}
@@ -0,0 +1,123 @@
+package proof_test
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/proof"
+)
+
+func TestSubstituteFaults(t *testing.T) {
+	partition := []abi.SectorNumber{3, 4, 7, 9}
+	proving, err := proof.SubstituteFaults(partition, abi.SectorRangeSetFromNumbers(3, 7))
+	require.NoError(t, err)
+	assert.Equal(t, []abi.SectorNumber{4, 4, 4, 9}, proving)
+
+	proving, err = proof.SubstituteFaults(partition, abi.SectorRangeSet{})
+	require.NoError(t, err)
+	assert.Equal(t, partition, proving)
+
+	_, err = proof.SubstituteFaults(partition, abi.SectorRangeSetFromNumbers(partition...))
+	assert.Error(t, err)
+}
+
+func TestWindowPoStChallenges(t *testing.T) {
+	p := abi.RegisteredPoStProof_StackedDrgWindow2KiBV1
+	randomness := abi.PoStRandomness(make([]byte, 32))
+	for i := range randomness {
+		randomness[i] = 0xff
+	}
+
+	challenges, err := proof.WindowPoStChallenges(p, randomness, 1, []abi.SectorNumber{5, 6})
+	require.NoError(t, err)
+	require.Len(t, challenges, 2)
+
+	// The second sector of partition 1 is at position 3.
+	assert.Equal(t, abi.SectorNumber(6), challenges[1].Sector)
+	require.Len(t, challenges[1].Leaves, proof.WindowPoStChallengeCount)
+	rand := [32]byte{}
+	copy(rand[:], randomness)
+	rand[31] = 0x3f
+	for n, leaf := range challenges[1].Leaves {
+		var buf [48]byte
+		copy(buf[:], rand[:])
+		binary.LittleEndian.PutUint64(buf[32:], 6)
+		binary.LittleEndian.PutUint64(buf[40:], uint64(3*proof.WindowPoStChallengeCount+n))
+		sum := sha256.Sum256(buf[:])
+		assert.Equal(t, binary.LittleEndian.Uint64(sum[:8])%64, leaf)
+	}
+
+	// Challenges depend on position, so the same sector is challenged differently elsewhere.
+	other, err := proof.WindowPoStChallenges(p, randomness, 0, []abi.SectorNumber{5, 6})
+	require.NoError(t, err)
+	assert.NotEqual(t, challenges[1].Leaves, other[1].Leaves)
+
+	_, err = proof.WindowPoStChallenges(p, randomness, 0, []abi.SectorNumber{1, 2, 3})
+	assert.Error(t, err)
+	_, err = proof.WindowPoStChallenges(abi.RegisteredPoStProof_StackedDrgWinning2KiBV1, randomness, 0, nil)
+	assert.Error(t, err)
+}
+
+func TestWindowPoStChallengesV2(t *testing.T) {
+	randomness := abi.PoStRandomness(make([]byte, 32))
+	for i := range randomness {
+		randomness[i] = 0xff
+	}
+	rand := [32]byte{}
+	copy(rand[:], randomness)
+	rand[31] = 0x3f
+
+	for _, p := range []abi.RegisteredPoStProof{
+		abi.RegisteredPoStProof_StackedDrgWindow2KiBV2,
+		abi.RegisteredPoStProof_StackedDrgWindow8MiBV2,
+		abi.RegisteredPoStProof_StackedDrgWindow512MiBV2,
+		abi.RegisteredPoStProof_StackedDrgWindow32GiBV2,
+		abi.RegisteredPoStProof_StackedDrgWindow64GiBV2,
+	} {
+		sectorSize, err := p.SectorSize()
+		require.NoError(t, err)
+		leaves := uint64(sectorSize) / 32
+
+		challenges, err := proof.WindowPoStChallenges(p, randomness, 1, []abi.SectorNumber{5, 6})
+		require.NoError(t, err)
+		require.Len(t, challenges, 2)
+
+		// The challenge index is the challenge number alone, whatever the sector's position.
+		assert.Equal(t, abi.SectorNumber(6), challenges[1].Sector)
+		require.Len(t, challenges[1].Leaves, proof.WindowPoStChallengeCount)
+		for n, leaf := range challenges[1].Leaves {
+			var buf [48]byte
+			copy(buf[:], rand[:])
+			binary.LittleEndian.PutUint64(buf[32:], 6)
+			binary.LittleEndian.PutUint64(buf[40:], uint64(n))
+			sum := sha256.Sum256(buf[:])
+			assert.Equal(t, binary.LittleEndian.Uint64(sum[:8])%leaves, leaf, "proof %d challenge %d", p, n)
+		}
+
+		// So the same sector is challenged identically in another partition and position.
+		other, err := proof.WindowPoStChallenges(p, randomness, 0, []abi.SectorNumber{6})
+		require.NoError(t, err)
+		assert.Equal(t, challenges[1].Leaves, other[0].Leaves)
+	}
+}
+
+func TestWinningPoStChallenges(t *testing.T) {
+	randomness := abi.PoStRandomness(make([]byte, 32))
+	indices, err := proof.WinningPoStSectorChallenges(1000, randomness, 7)
+	require.NoError(t, err)
+	require.Len(t, indices, proof.WinningPoStSectorCount)
+	assert.Less(t, indices[0], uint64(7))
+
+	challenges, err := proof.WinningPoStChallenges(abi.RegisteredPoStProof_StackedDrgWinning2KiBV1, randomness, []abi.SectorNumber{2})
+	require.NoError(t, err)
+	require.Len(t, challenges, 1)
+	assert.Len(t, challenges[0].Leaves, proof.WinningPoStChallengeCount)
+
+	_, err = proof.WinningPoStSectorChallenges(1000, randomness, 0)
+	assert.Error(t, err)
+}
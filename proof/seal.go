@@ -0,0 +1,21 @@
+package proof
+
+import (
+	"github.com/ipfs/go-cid"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// SealVerifyInfo is the information necessary to verify a PoRep (proof of replication) for a sector.
+type SealVerifyInfo struct {
+	SealProof abi.RegisteredSealProof
+	abi.SectorID
+	DealIDs               []abi.DealID
+	Randomness            abi.SealRandomness
+	InteractiveRandomness abi.InteractiveSealRandomness
+	Proof                 []byte
+
+	// Safe because we get those from the miner actor
+	SealedCID   cid.Cid `checked:"true"` // CommR
+	UnsealedCID cid.Cid `checked:"true"` // CommD
+}
@@ -0,0 +1,80 @@
+package proof
+
+import (
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// Proofs are Groth16 SNARKs, of which a PoRep or PoSt has one per partition. The byte sizes here let
+// messages be validated and buffers sized without calling into the proofs library.
+
+// Size of a single Groth16 SNARK proof, in bytes.
+const GrothProofBytes = 192
+
+// Maximum size of an aggregate of seal proofs, in bytes, for any aggregation scheme.
+const MaxAggregateProofBytes = 81960
+
+// Number of partitions of a non-interactive PoRep, by sector size.
+var niPorepPartitions = map[abi.SectorSize]uint64{
+	abi.SectorSize2KiB:   2,
+	abi.SectorSize8MiB:   2,
+	abi.SectorSize512MiB: 2,
+	abi.SectorSize32GiB:  126,
+	abi.SectorSize64GiB:  126,
+}
+
+// MaxSealProofSize returns the size in bytes of a seal proof (PoRep) of some type: a SNARK for each partition.
+func MaxSealProofSize(p abi.RegisteredSealProof) (int, error) {
+	size, err := p.SectorSize()
+	if err != nil {
+		return 0, err
+	}
+	partitions := porepPartitions
+	if p.IsNonInteractive() {
+		partitions = niPorepPartitions
+	}
+	n, ok := partitions[size]
+	if !ok {
+		return 0, xerrors.Errorf("unsupported sector size %d", size)
+	}
+	return int(n) * GrothProofBytes, nil
+}
+
+// MaxPoStProofSize returns the size in bytes of a PoSt proof of some type for a number of partitions.
+// A Winning PoSt proves a single partition.
+func MaxPoStProofSize(p abi.RegisteredPoStProof, partitions int) (int, error) {
+	if _, ok := abi.PoStSealProofTypes[p]; !ok {
+		return 0, xerrors.Errorf("unsupported PoSt proof type %d", p)
+	}
+	if partitions < 1 {
+		return 0, xerrors.Errorf("invalid partition count %d", partitions)
+	}
+	if isWinningPoSt(p) && partitions != 1 {
+		return 0, xerrors.Errorf("winning PoSt proves one partition, not %d", partitions)
+	}
+	return partitions * GrothProofBytes, nil
+}
+
+// MaxAggregateProofSize returns the maximum size in bytes of an aggregate proof of some type.
+func MaxAggregateProofSize(p abi.RegisteredAggregationProof) (int, error) {
+	switch p {
+	case abi.RegisteredAggregationProof_SnarkPackV1, abi.RegisteredAggregationProof_SnarkPackV2:
+		return MaxAggregateProofBytes, nil
+	default:
+		return 0, xerrors.Errorf("unsupported aggregation proof type %d", p)
+	}
+}
+
+func isWinningPoSt(p abi.RegisteredPoStProof) bool {
+	switch p {
+	case abi.RegisteredPoStProof_StackedDrgWinning2KiBV1, abi.RegisteredPoStProof_StackedDrgWinning8MiBV1,
+		abi.RegisteredPoStProof_StackedDrgWinning512MiBV1, abi.RegisteredPoStProof_StackedDrgWinning32GiBV1,
+		abi.RegisteredPoStProof_StackedDrgWinning64GiBV1, abi.RegisteredPoStProof_StackedDrgWinning2KiBV2,
+		abi.RegisteredPoStProof_StackedDrgWinning8MiBV2, abi.RegisteredPoStProof_StackedDrgWinning512MiBV2,
+		abi.RegisteredPoStProof_StackedDrgWinning32GiBV2, abi.RegisteredPoStProof_StackedDrgWinning64GiBV2:
+		return true
+	default:
+		return false
+	}
+}
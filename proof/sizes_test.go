@@ -0,0 +1,53 @@
+package proof_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/proof"
+)
+
+func TestMaxSealProofSize(t *testing.T) {
+	for p, expected := range map[abi.RegisteredSealProof]int{
+		abi.RegisteredSealProof_StackedDrg2KiBV1:                        192,
+		abi.RegisteredSealProof_StackedDrg32GiBV1:                       1920,
+		abi.RegisteredSealProof_StackedDrg64GiBV2:                       1920,
+		abi.RegisteredSealProof_StackedDrg32GiBV1_1_Feat_SyntheticPoRep: 1920,
+		abi.RegisteredSealProof_StackedDrg32GiBV1_2_Feat_NiPoRep:        126 * 192,
+	} {
+		size, err := proof.MaxSealProofSize(p)
+		require.NoError(t, err)
+		assert.Equal(t, expected, size, p)
+	}
+	_, err := proof.MaxSealProofSize(abi.RegisteredSealProof(100))
+	assert.Error(t, err)
+}
+
+func TestMaxPoStProofSize(t *testing.T) {
+	size, err := proof.MaxPoStProofSize(abi.RegisteredPoStProof_StackedDrgWinning32GiBV1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 192, size)
+	_, err = proof.MaxPoStProofSize(abi.RegisteredPoStProof_StackedDrgWinning32GiBV1, 2)
+	assert.Error(t, err)
+
+	size, err = proof.MaxPoStProofSize(abi.RegisteredPoStProof_StackedDrgWindow32GiBV2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 576, size)
+	_, err = proof.MaxPoStProofSize(abi.RegisteredPoStProof_StackedDrgWindow32GiBV2, 0)
+	assert.Error(t, err)
+	_, err = proof.MaxPoStProofSize(abi.RegisteredPoStProof(100), 1)
+	assert.Error(t, err)
+}
+
+func TestMaxAggregateProofSize(t *testing.T) {
+	for _, p := range []abi.RegisteredAggregationProof{abi.RegisteredAggregationProof_SnarkPackV1, abi.RegisteredAggregationProof_SnarkPackV2} {
+		size, err := proof.MaxAggregateProofSize(p)
+		require.NoError(t, err)
+		assert.Equal(t, proof.MaxAggregateProofBytes, size)
+	}
+	_, err := proof.MaxAggregateProofSize(abi.RegisteredAggregationProof(5))
+	assert.Error(t, err)
+}
@@ -0,0 +1,62 @@
+package proof
+
+import (
+	"crypto/sha256"
+
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+)
+
+// Synthetic PoRep: after sealing, the sealer generates proofs for a large set of synthetic challenges,
+// derived from the replica alone, and may then discard the layers. The interactive challenges drawn from the
+// seal seed select among the synthetic challenges, so the PoRep can be assembled from the precomputed proofs.
+
+// Number of synthetic challenges for which proofs are precomputed.
+const SyntheticChallengeCount = 1 << 18
+
+// Minimum number of interactive PoRep challenges, by sector size.
+var porepMinimumChallenges = map[abi.SectorSize]uint64{
+	abi.SectorSize2KiB:   2,
+	abi.SectorSize8MiB:   2,
+	abi.SectorSize512MiB: 2,
+	abi.SectorSize32GiB:  176,
+	abi.SectorSize64GiB:  176,
+}
+
+// Number of partitions of a PoRep, by sector size.
+var porepPartitions = map[abi.SectorSize]uint64{
+	abi.SectorSize2KiB:   1,
+	abi.SectorSize8MiB:   1,
+	abi.SectorSize512MiB: 1,
+	abi.SectorSize32GiB:  10,
+	abi.SectorSize64GiB:  10,
+}
+
+// PoRepChallengeCount returns the number of partitions of a PoRep, and the number of challenges in each.
+// Challenges are divided evenly between partitions, rounding up. For a synthetic PoRep, each challenge
+// selects one of the SyntheticChallengeCount synthetic challenges.
+func PoRepChallengeCount(p abi.RegisteredSealProof) (partitions, perPartition uint64, err error) {
+	size, err := p.SectorSize()
+	if err != nil {
+		return 0, 0, err
+	}
+	partitions, ok := porepPartitions[size]
+	if !ok {
+		return 0, 0, xerrors.Errorf("unsupported sector size %d", size)
+	}
+	total := porepMinimumChallenges[size]
+	return partitions, (total + partitions - 1) / partitions, nil
+}
+
+// SyntheticChallengeSeed returns the seed from which the synthetic challenges of a replica are generated:
+// the SHA-256 hash of the replica ID followed by the sealed sector commitment (CommR), both as 32-byte
+// little-endian field elements. The proofs library expands the seed into challenges with a ChaCha20 stream.
+func SyntheticChallengeSeed(replicaID [32]byte, commR abi.Commitment) [32]byte {
+	h := sha256.New()
+	h.Write(replicaID[:])
+	h.Write(commR[:])
+	var seed [32]byte
+	copy(seed[:], h.Sum(nil))
+	return seed
+}
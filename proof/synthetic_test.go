@@ -0,0 +1,35 @@
+package proof_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/proof"
+)
+
+func TestPoRepChallengeCount(t *testing.T) {
+	partitions, perPartition, err := proof.PoRepChallengeCount(abi.RegisteredSealProof_StackedDrg32GiBV1_1_Feat_SyntheticPoRep)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(10), partitions)
+	assert.Equal(t, uint64(18), perPartition)
+
+	partitions, perPartition, err = proof.PoRepChallengeCount(abi.RegisteredSealProof_StackedDrg2KiBV2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), partitions)
+	assert.Equal(t, uint64(2), perPartition)
+
+	_, _, err = proof.PoRepChallengeCount(abi.RegisteredSealProof(99))
+	assert.Error(t, err)
+}
+
+func TestSyntheticChallengeSeed(t *testing.T) {
+	var replicaID, commR [32]byte
+	replicaID[0], commR[0] = 1, 2
+	expected := sha256.Sum256(append(replicaID[:], commR[:]...))
+	assert.Equal(t, expected, proof.SyntheticChallengeSeed(replicaID, commR))
+	assert.NotEqual(t, expected, proof.SyntheticChallengeSeed(commR, replicaID))
+}
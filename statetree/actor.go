@@ -0,0 +1,91 @@
+package statetree
+
+import (
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+// Actor is the envelope of an actor's state in the latest state tree version.
+type Actor = ActorV5
+
+// ActorV4 is the on-chain envelope of an actor's state, up to StateTreeVersion4.
+type ActorV4 struct {
+	// Identifies the type of actor (string coded as a CID), see `builtin`.
+	Code cid.Cid
+	// CID of the root of optional actor-specific sub-state.
+	Head cid.Cid
+	// CallSeqNum for the next message to be received by the actor (non-zero for accounts only)
+	Nonce uint64
+	// Token balance of the actor
+	Balance abi.TokenAmount
+}
+
+// ActorV5 is the on-chain envelope of an actor's state from StateTreeVersion5.
+type ActorV5 struct {
+	// Identifies the type of actor (string coded as a CID), see `builtin`.
+	Code cid.Cid
+	// CID of the root of optional actor-specific sub-state.
+	Head cid.Cid
+	// CallSeqNum for the next message to be received by the actor (non-zero for accounts only)
+	Nonce uint64
+	// Token balance of the actor
+	Balance abi.TokenAmount
+	// The f4 (delegated) address of the actor, if any.
+	DelegatedAddress *addr.Address
+}
+
+// AsActorV4 converts an actor to the layout prior to StateTreeVersion5, failing if it has a delegated address
+// (which cannot be represented).
+func AsActorV4(act *ActorV5) (*ActorV4, error) {
+	if act.DelegatedAddress != nil {
+		return nil, xerrors.Errorf("actor with delegated address %s cannot be represented before state tree version 5", act.DelegatedAddress)
+	}
+	return &ActorV4{
+		Code:    act.Code,
+		Head:    act.Head,
+		Nonce:   act.Nonce,
+		Balance: act.Balance,
+	}, nil
+}
+
+// AsActorV5 converts an actor to the StateTreeVersion5 layout, with no delegated address.
+func AsActorV5(act *ActorV4) *ActorV5 {
+	return &ActorV5{
+		Code:    act.Code,
+		Head:    act.Head,
+		Nonce:   act.Nonce,
+		Balance: act.Balance,
+	}
+}
+
+// DecodeActor decodes an actor in the layout of some state tree version.
+func DecodeActor(version StateTreeVersion, b []byte) (*Actor, error) {
+	if version >= StateTreeVersion5 {
+		var act ActorV5
+		if _, err := cbor.Unmarshal(b, &act); err != nil {
+			return nil, err
+		}
+		return &act, nil
+	}
+	var act ActorV4
+	if _, err := cbor.Unmarshal(b, &act); err != nil {
+		return nil, err
+	}
+	return AsActorV5(&act), nil
+}
+
+// EncodeActor encodes an actor in the layout of some state tree version.
+func EncodeActor(version StateTreeVersion, act *Actor) ([]byte, error) {
+	if version >= StateTreeVersion5 {
+		return cbor.Marshal(act)
+	}
+	act4, err := AsActorV4(act)
+	if err != nil {
+		return nil, err
+	}
+	return cbor.Marshal(act4)
+}
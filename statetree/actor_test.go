@@ -0,0 +1,45 @@
+package statetree_test
+
+import (
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/statetree"
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+func TestActorVersions(t *testing.T) {
+	store := ipld.NewMemStore()
+	_, act := testActor(t, store, 100)
+
+	// Without a delegated address, an actor round-trips through either layout.
+	for _, v := range []statetree.StateTreeVersion{statetree.StateTreeVersion0, statetree.StateTreeVersion4, statetree.StateTreeVersion5} {
+		b, err := statetree.EncodeActor(v, &act)
+		require.NoError(t, err)
+		decoded, err := statetree.DecodeActor(v, b)
+		require.NoError(t, err)
+		assert.Equal(t, act, *decoded)
+	}
+
+	b4, err := statetree.EncodeActor(statetree.StateTreeVersion4, &act)
+	require.NoError(t, err)
+	_, err = statetree.DecodeActor(statetree.StateTreeVersion5, b4)
+	assert.Error(t, err)
+
+	delegated, err := addr.NewActorAddress([]byte("delegated"))
+	require.NoError(t, err)
+	act.DelegatedAddress = &delegated
+	b5, err := statetree.EncodeActor(statetree.StateTreeVersion5, &act)
+	require.NoError(t, err)
+	decoded, err := statetree.DecodeActor(statetree.StateTreeVersion5, b5)
+	require.NoError(t, err)
+	assert.Equal(t, delegated, *decoded.DelegatedAddress)
+
+	_, err = statetree.EncodeActor(statetree.StateTreeVersion4, &act)
+	assert.Error(t, err)
+	_, err = statetree.AsActorV4(&act)
+	assert.Error(t, err)
+}
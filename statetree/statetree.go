@@ -0,0 +1,202 @@
+package statetree
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/adt"
+)
+
+// StateTreeVersion is the version of the state tree's root layout and actor HAMT encoding.
+type StateTreeVersion uint64
+
+const (
+	// The root is the actors HAMT itself, in the original HAMT encoding (actors v0 and v2).
+	StateTreeVersion0 = StateTreeVersion(iota)
+	// The root is a StateRoot, and the actors HAMT uses the compact encoding (actors v3).
+	StateTreeVersion1
+	// Version bump only (actors v4).
+	StateTreeVersion2
+	// Version bump only (actors v5).
+	StateTreeVersion3
+	// Version bump only (actors v6 to v9).
+	StateTreeVersion4
+	// Actors carry an optional delegated address (actors v10 onwards, with the FVM).
+	StateTreeVersion5
+)
+
+// The latest supported state tree version.
+const StateTreeVersionLatest = StateTreeVersion5
+
+// StateRoot is the root of the state tree from StateTreeVersion1.
+type StateRoot struct {
+	// State tree version.
+	Version StateTreeVersion
+	// Actors tree. The structure depends on the state root version.
+	Actors cid.Cid
+	// Info. The structure depends on the state root version.
+	Info cid.Cid
+}
+
+// StateInfo0 is the (empty) info of a state root, from StateTreeVersion1.
+type StateInfo0 struct{}
+
+// MarshalCBOR writes the info as an empty array.
+func (si *StateInfo0) MarshalCBOR(w io.Writer) error {
+	_, err := w.Write([]byte{cbg.MajArray << 5})
+	return err
+}
+
+// UnmarshalCBOR reads the info, which must be an empty array.
+func (si *StateInfo0) UnmarshalCBOR(r io.Reader) error {
+	maj, n, err := cbg.CborReadHeader(r)
+	if err != nil {
+		return err
+	}
+	if maj != cbg.MajArray || n != 0 {
+		return xerrors.Errorf("expected empty array for state info")
+	}
+	return nil
+}
+
+// StateTree provides read-only traversal of the actors in a state tree.
+type StateTree struct {
+	store   ipldcbor.IpldStore
+	version StateTreeVersion
+	actors  cid.Cid
+}
+
+// LoadStateTree loads the state tree with some root CID, detecting its version.
+func LoadStateTree(ctx context.Context, store ipldcbor.IpldStore, root cid.Cid) (*StateTree, error) {
+	sr, err := LoadStateRoot(ctx, store, root)
+	if err != nil {
+		return nil, err
+	}
+	return &StateTree{store: store, version: sr.Version, actors: sr.Actors}, nil
+}
+
+// LoadStateRoot loads the root of a state tree, detecting its version.
+// A StateTreeVersion0 tree has no StateRoot envelope: the root is the actors HAMT itself, so the returned root
+// has the version, the root CID as actors tree, and an undefined info CID.
+func LoadStateRoot(ctx context.Context, store ipldcbor.IpldStore, root cid.Cid) (*StateRoot, error) {
+	var raw cbg.Deferred
+	if err := store.Get(ctx, root, &raw); err != nil {
+		return nil, xerrors.Errorf("failed to load state root %s: %w", root, err)
+	}
+
+	// A StateRoot is a 3-tuple beginning with an integer version, while a HAMT node is a 2-tuple.
+	maj, n, err := cbg.CborReadHeader(bytes.NewReader(raw.Raw))
+	if err != nil {
+		return nil, xerrors.Errorf("failed to read state root %s: %w", root, err)
+	}
+	if maj != cbg.MajArray {
+		return nil, xerrors.Errorf("state root %s is not an array", root)
+	}
+	if n == adt.HamtNodeFields {
+		return &StateRoot{Version: StateTreeVersion0, Actors: root, Info: cid.Undef}, nil
+	}
+
+	var sr StateRoot
+	if err := sr.UnmarshalCBOR(bytes.NewReader(raw.Raw)); err != nil {
+		return nil, xerrors.Errorf("failed to decode state root %s: %w", root, err)
+	}
+	if sr.Version < StateTreeVersion1 || sr.Version > StateTreeVersionLatest {
+		return nil, xerrors.Errorf("unsupported state tree version %d", sr.Version)
+	}
+	return &sr, nil
+}
+
+// Version returns the state tree version.
+func (t *StateTree) Version() StateTreeVersion {
+	return t.version
+}
+
+// ActorsRoot returns the root CID of the actors HAMT.
+func (t *StateTree) ActorsRoot() cid.Cid {
+	return t.actors
+}
+
+// ForEach calls cb for each actor in the state tree, in HAMT order, stopping at the first error.
+func (t *StateTree) ForEach(ctx context.Context, cb func(a addr.Address, act *Actor) error) error {
+	return adt.ForEachHamtEntry(ctx, t.store, t.actors, func(k, v []byte) error {
+		a, act, err := t.decodeEntry(k, v)
+		if err != nil {
+			return err
+		}
+		return cb(a, act)
+	})
+}
+
+// ForEachParallel calls cb for each actor in the state tree from the given number of concurrent workers.
+// The order of calls is unspecified. Traversal stops after the first error, which is returned.
+func (t *StateTree) ForEachParallel(ctx context.Context, workers int, cb func(a addr.Address, act *Actor) error) error {
+	if workers < 1 {
+		workers = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type entry struct {
+		addr  addr.Address
+		actor *Actor
+	}
+	entries := make(chan entry, workers)
+
+	var errOnce sync.Once
+	var firstErr error
+	fail := func(err error) {
+		errOnce.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range entries {
+				if err := cb(e.addr, e.actor); err != nil {
+					fail(err)
+				}
+			}
+		}()
+	}
+
+	err := t.ForEach(ctx, func(a addr.Address, act *Actor) error {
+		select {
+		case entries <- entry{a, act}:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	})
+	close(entries)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return err
+}
+
+func (t *StateTree) decodeEntry(k, v []byte) (addr.Address, *Actor, error) {
+	a, err := addr.NewFromBytes(k)
+	if err != nil {
+		return addr.Undef, nil, xerrors.Errorf("invalid address key %x: %w", k, err)
+	}
+	act, err := DecodeActor(t.version, v)
+	if err != nil {
+		return addr.Undef, nil, xerrors.Errorf("failed to decode actor %s: %w", a, err)
+	}
+	return a, act, nil
+}
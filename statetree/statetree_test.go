@@ -0,0 +1,97 @@
+package statetree_test
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+	"testing"
+
+	addr "github.com/filecoin-project/go-address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/big"
+	"github.com/filecoin-project/go-state-types/statetree"
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+func TestLoadStateTreeCompact(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+	a1, act1 := testActor(t, store, 100)
+	a2, act2 := testActor(t, store, 101)
+	a3, act3 := testActor(t, store, 102)
+
+	// A child node holding one entry, linked from a root holding two entries in a bucket.
+	child := ipld.PutBlock(t, store, ipld.HamtNode(false, ipld.HamtBucket(false, kv(t, statetree.StateTreeVersion4, a3, act3))))
+	root := ipld.PutBlock(t, store, ipld.HamtNode(false, ipld.HamtBucket(false, kv(t, statetree.StateTreeVersion4, a1, act1), kv(t, statetree.StateTreeVersion4, a2, act2)), ipld.HamtLink(false, child)))
+	info := ipld.PutBlock(t, store, []byte{0x80})
+	sr := statetree.StateRoot{Version: statetree.StateTreeVersion4, Actors: root, Info: info}
+	var buf bytes.Buffer
+	require.NoError(t, sr.MarshalCBOR(&buf))
+	stateRoot := ipld.PutBlock(t, store, buf.Bytes())
+
+	tree, err := statetree.LoadStateTree(ctx, store, stateRoot)
+	require.NoError(t, err)
+	assert.Equal(t, statetree.StateTreeVersion4, tree.Version())
+	assert.Equal(t, root, tree.ActorsRoot())
+
+	var visited []addr.Address
+	require.NoError(t, tree.ForEach(ctx, func(a addr.Address, act *statetree.Actor) error {
+		visited = append(visited, a)
+		return nil
+	}))
+	assert.Equal(t, []addr.Address{a1, a2, a3}, visited)
+
+	var mu sync.Mutex
+	actors := map[addr.Address]statetree.Actor{}
+	require.NoError(t, tree.ForEachParallel(ctx, 4, func(a addr.Address, act *statetree.Actor) error {
+		mu.Lock()
+		defer mu.Unlock()
+		actors[a] = *act
+		return nil
+	}))
+	assert.Equal(t, map[addr.Address]statetree.Actor{a1: act1, a2: act2, a3: act3}, actors)
+
+	expectedErr := xerrors.New("stop")
+	assert.Equal(t, expectedErr, tree.ForEachParallel(ctx, 2, func(a addr.Address, act *statetree.Actor) error {
+		return expectedErr
+	}))
+}
+
+func TestLoadStateTreeVersion0(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+	a1, act1 := testActor(t, store, 100)
+	a2, act2 := testActor(t, store, 101)
+
+	child := ipld.PutBlock(t, store, ipld.HamtNode(true, ipld.HamtBucket(true, kv(t, statetree.StateTreeVersion0, a2, act2))))
+	root := ipld.PutBlock(t, store, ipld.HamtNode(true, ipld.HamtLink(true, child), ipld.HamtBucket(true, kv(t, statetree.StateTreeVersion0, a1, act1))))
+
+	tree, err := statetree.LoadStateTree(ctx, store, root)
+	require.NoError(t, err)
+	assert.Equal(t, statetree.StateTreeVersion0, tree.Version())
+
+	var visited []string
+	require.NoError(t, tree.ForEach(ctx, func(a addr.Address, act *statetree.Actor) error {
+		visited = append(visited, a.String())
+		return nil
+	}))
+	sort.Strings(visited)
+	assert.Equal(t, []string{a1.String(), a2.String()}, visited)
+}
+
+func testActor(t *testing.T, store *ipld.MemStore, id uint64) (addr.Address, statetree.Actor) {
+	a, err := addr.NewIDAddress(id)
+	require.NoError(t, err)
+	code := ipld.PutBlock(t, store, []byte{0x60})
+	return a, statetree.Actor{Code: code, Head: code, Nonce: id, Balance: big.NewInt(int64(id))}
+}
+
+func kv(t *testing.T, version statetree.StateTreeVersion, a addr.Address, act statetree.Actor) []byte {
+	b, err := statetree.EncodeActor(version, &act)
+	require.NoError(t, err)
+	return ipld.HamtEntry(a.Bytes(), b)
+}
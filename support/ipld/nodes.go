@@ -0,0 +1,129 @@
+package ipld
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+)
+
+// Encoders for the blocks of HAMTs and AMTs, so that tests may build state in a MemStore without depending
+// on the HAMT and AMT implementations. Writes to a bytes.Buffer cannot fail, so the encoders return only bytes.
+
+// PutBlock stores a raw block, failing the test if it cannot be stored.
+func PutBlock(t testing.TB, s *MemStore, b []byte) cid.Cid {
+	t.Helper()
+	c, err := s.PutRaw(b)
+	if err != nil {
+		t.Fatalf("failed to store block: %s", err)
+	}
+	return c
+}
+
+// Tuple encodes a CBOR array of encoded elements.
+func Tuple(elems ...[]byte) []byte {
+	var buf bytes.Buffer
+	_ = cbg.CborWriteHeader(&buf, cbg.MajArray, uint64(len(elems)))
+	for _, e := range elems {
+		buf.Write(e)
+	}
+	return buf.Bytes()
+}
+
+// Uint encodes an unsigned integer.
+func Uint(v uint64) []byte {
+	var buf bytes.Buffer
+	_ = cbg.CborWriteHeader(&buf, cbg.MajUnsignedInt, v)
+	return buf.Bytes()
+}
+
+// Bytes encodes a byte string.
+func Bytes(b []byte) []byte {
+	var buf bytes.Buffer
+	_ = cbg.CborWriteHeader(&buf, cbg.MajByteString, uint64(len(b)))
+	buf.Write(b)
+	return buf.Bytes()
+}
+
+// Link encodes a CID.
+func Link(c cid.Cid) []byte {
+	var buf bytes.Buffer
+	_ = cbg.WriteCid(&buf, c)
+	return buf.Bytes()
+}
+
+// HamtNode encodes a HAMT node holding some (at most 8) pointers, each of which is a HamtLink or a HamtBucket.
+// The pointers occupy the lowest positions of the node's bitfield. A legacy node, as in state tree version 0,
+// tags the bitfield as a bignum.
+func HamtNode(legacy bool, pointers ...[]byte) []byte {
+	var buf bytes.Buffer
+	_ = cbg.CborWriteHeader(&buf, cbg.MajArray, 2)
+	if legacy {
+		_ = cbg.CborWriteHeader(&buf, cbg.MajTag, 2)
+	}
+	buf.Write(Bytes([]byte{byte(1<<len(pointers) - 1)}))
+	buf.Write(Tuple(pointers...))
+	return buf.Bytes()
+}
+
+// HamtBucketNode encodes a HAMT node holding all the entries in a single bucket.
+func HamtBucketNode(entries ...[]byte) []byte {
+	return HamtNode(false, HamtBucket(false, entries...))
+}
+
+// HamtLink encodes a HAMT pointer to a child node. A legacy pointer is a map keyed by "0".
+func HamtLink(legacy bool, c cid.Cid) []byte {
+	var buf bytes.Buffer
+	if legacy {
+		writeLegacyPointerKey(&buf, "0")
+	}
+	buf.Write(Link(c))
+	return buf.Bytes()
+}
+
+// HamtBucket encodes a HAMT pointer holding a bucket of entries, each encoded by HamtEntry.
+// A legacy pointer is a map keyed by "1".
+func HamtBucket(legacy bool, entries ...[]byte) []byte {
+	var buf bytes.Buffer
+	if legacy {
+		writeLegacyPointerKey(&buf, "1")
+	}
+	buf.Write(Tuple(entries...))
+	return buf.Bytes()
+}
+
+// HamtEntry encodes a key and an encoded value as an entry of a HAMT bucket.
+func HamtEntry(key, value []byte) []byte {
+	return Tuple(Bytes(key), value)
+}
+
+func writeLegacyPointerKey(buf *bytes.Buffer, key string) {
+	_ = cbg.CborWriteHeader(buf, cbg.MajMap, 1)
+	_ = cbg.CborWriteHeader(buf, cbg.MajTextString, uint64(len(key)))
+	buf.WriteString(key)
+}
+
+// AmtRoot encodes an AMT root, in the layout (from AMT version 3) recording the bit width.
+func AmtRoot(bitWidth, height, count uint64, node []byte) []byte {
+	return Tuple(Uint(bitWidth), Uint(height), Uint(count), node)
+}
+
+// AmtNode encodes an AMT node with a bitmap of its occupied slots, followed by the links of an interior node
+// or the encoded values of a leaf.
+func AmtNode(bitmap []byte, links []cid.Cid, values ...[]byte) []byte {
+	encodedLinks := make([][]byte, len(links))
+	for i, c := range links {
+		encodedLinks[i] = Link(c)
+	}
+	return Tuple(Bytes(bitmap), Tuple(encodedLinks...), Tuple(values...))
+}
+
+// AmtBitmap returns the bitmap of a node of some width (a power of two) with some slots occupied.
+func AmtBitmap(width int, slots ...uint64) []byte {
+	bitmap := make([]byte, (width+7)/8)
+	for _, s := range slots {
+		bitmap[s/8] |= 1 << (s % 8)
+	}
+	return bitmap
+}
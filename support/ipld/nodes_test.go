@@ -0,0 +1,33 @@
+package ipld_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+func TestNodes(t *testing.T) {
+	store := ipld.NewMemStore()
+	c := ipld.PutBlock(t, store, []byte{0x80})
+	link := ipld.Link(c)
+	assert.Equal(t, []byte{0xd8, 0x2a}, link[:2])
+
+	entry := ipld.HamtEntry([]byte{0x00, 0x64}, []byte{0x01})
+	assert.Equal(t, []byte{0x82, 0x42, 0x00, 0x64, 0x01}, entry)
+
+	// A compact node with a bucket and a link in its two lowest positions.
+	node := ipld.HamtNode(false, ipld.HamtBucket(false, entry), ipld.HamtLink(false, c))
+	expected := append([]byte{0x82, 0x41, 0x03, 0x82, 0x81}, entry...)
+	assert.Equal(t, append(expected, link...), node)
+	assert.Equal(t, ipld.HamtNode(false, ipld.HamtBucket(false, entry)), ipld.HamtBucketNode(entry))
+
+	// A legacy node tags the bitfield, and keys each pointer by its kind.
+	node = ipld.HamtNode(true, ipld.HamtBucket(true, entry))
+	assert.Equal(t, append([]byte{0x82, 0xc2, 0x41, 0x01, 0x81, 0xa1, 0x61, '1', 0x81}, entry...), node)
+
+	root := ipld.AmtRoot(3, 0, 2, ipld.AmtNode(ipld.AmtBitmap(8, 0, 2), nil, ipld.Uint(7), ipld.Uint(9)))
+	assert.Equal(t, []byte{0x84, 0x03, 0x00, 0x02, 0x83, 0x41, 0x05, 0x80, 0x82, 0x07, 0x09}, root)
+	assert.Equal(t, []byte{0x01, 0x00, 0x00, 0x80}, ipld.AmtBitmap(32, 0, 31))
+}
@@ -0,0 +1,151 @@
+// Package ipld provides an in-memory IPLD store for testing code that reads and writes state,
+// such as builtin actor state accessors and migrations.
+package ipld
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	ipldcbor "github.com/ipfs/go-ipld-cbor"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/cbor"
+)
+
+// StoreStats counts the operations on a MemStore.
+type StoreStats struct {
+	// Number of Get calls, including misses.
+	Reads uint64
+	// Number of Put calls.
+	Writes uint64
+	// Number of Get calls for absent blocks.
+	Misses uint64
+	// Total size of blocks read.
+	BytesRead uint64
+	// Total size of blocks written.
+	BytesWritten uint64
+}
+
+// MemStore is a map-backed IpldStore for CBOR-encoded blocks. It is safe for concurrent use.
+// Values passed to Get and Put must implement the cbor-gen unmarshaler and marshaler respectively.
+type MemStore struct {
+	// Accessed atomically, so first in the struct for 64-bit alignment.
+	reads, writes, misses, bytesRead, bytesWritten uint64
+
+	lk     sync.RWMutex
+	blocks map[cid.Cid][]byte
+
+	// Delay applied to each Get and Put, to simulate a slow blockstore. Set before first use.
+	Latency time.Duration
+}
+
+var _ ipldcbor.IpldStore = (*MemStore)(nil)
+
+// NewMemStore returns an empty store.
+func NewMemStore() *MemStore {
+	return &MemStore{blocks: map[cid.Cid][]byte{}}
+}
+
+// Get decodes the block with CID c into out.
+func (s *MemStore) Get(ctx context.Context, c cid.Cid, out interface{}) error {
+	if err := s.wait(ctx); err != nil {
+		return err
+	}
+	u, ok := out.(cbg.CBORUnmarshaler)
+	if !ok {
+		return xerrors.Errorf("cannot unmarshal into %T", out)
+	}
+	atomic.AddUint64(&s.reads, 1)
+	b, ok := s.GetRaw(c)
+	if !ok {
+		atomic.AddUint64(&s.misses, 1)
+		return xerrors.Errorf("block not found: %s", c)
+	}
+	atomic.AddUint64(&s.bytesRead, uint64(len(b)))
+	_, err := cbor.Unmarshal(b, u)
+	return err
+}
+
+// Put encodes v and stores it as a block, returning its CID.
+func (s *MemStore) Put(ctx context.Context, v interface{}) (cid.Cid, error) {
+	if err := s.wait(ctx); err != nil {
+		return cid.Undef, err
+	}
+	m, ok := v.(cbg.CBORMarshaler)
+	if !ok {
+		return cid.Undef, xerrors.Errorf("cannot marshal %T", v)
+	}
+	b, err := cbor.Marshal(m)
+	if err != nil {
+		return cid.Undef, err
+	}
+	atomic.AddUint64(&s.writes, 1)
+	atomic.AddUint64(&s.bytesWritten, uint64(len(b)))
+	return s.PutRaw(b)
+}
+
+// GetRaw returns the raw block with CID c, if present. It does not count towards the stats.
+func (s *MemStore) GetRaw(c cid.Cid) ([]byte, bool) {
+	s.lk.RLock()
+	defer s.lk.RUnlock()
+	b, ok := s.blocks[c]
+	return b, ok
+}
+
+// PutRaw stores a raw CBOR block, returning its CID. It does not count towards the stats.
+func (s *MemStore) PutRaw(b []byte) (cid.Cid, error) {
+	c, err := abi.CidBuilder.Sum(b)
+	if err != nil {
+		return cid.Undef, err
+	}
+	s.lk.Lock()
+	defer s.lk.Unlock()
+	s.blocks[c] = b
+	return c, nil
+}
+
+// Len returns the number of blocks in the store.
+func (s *MemStore) Len() int {
+	s.lk.RLock()
+	defer s.lk.RUnlock()
+	return len(s.blocks)
+}
+
+// Stats returns a snapshot of the operation counters.
+func (s *MemStore) Stats() StoreStats {
+	return StoreStats{
+		Reads:        atomic.LoadUint64(&s.reads),
+		Writes:       atomic.LoadUint64(&s.writes),
+		Misses:       atomic.LoadUint64(&s.misses),
+		BytesRead:    atomic.LoadUint64(&s.bytesRead),
+		BytesWritten: atomic.LoadUint64(&s.bytesWritten),
+	}
+}
+
+// ResetStats zeroes the operation counters.
+func (s *MemStore) ResetStats() {
+	atomic.StoreUint64(&s.reads, 0)
+	atomic.StoreUint64(&s.writes, 0)
+	atomic.StoreUint64(&s.misses, 0)
+	atomic.StoreUint64(&s.bytesRead, 0)
+	atomic.StoreUint64(&s.bytesWritten, 0)
+}
+
+func (s *MemStore) wait(ctx context.Context) error {
+	if s.Latency <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(s.Latency)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,52 @@
+package ipld_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/support/ipld"
+)
+
+func TestMemStore(t *testing.T) {
+	ctx := context.Background()
+	store := ipld.NewMemStore()
+
+	id := abi.SectorID{Miner: 1000, Number: 7}
+	c, err := store.Put(ctx, &id)
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.Len())
+
+	var out abi.SectorID
+	require.NoError(t, store.Get(ctx, c, &out))
+	assert.Equal(t, id, out)
+
+	missing, err := abi.CidBuilder.Sum([]byte{0x80})
+	require.NoError(t, err)
+	assert.Error(t, store.Get(ctx, missing, &out))
+
+	assert.Equal(t, ipld.StoreStats{Reads: 2, Writes: 1, Misses: 1, BytesRead: 5, BytesWritten: 5}, store.Stats())
+	store.ResetStats()
+	assert.Equal(t, ipld.StoreStats{}, store.Stats())
+
+	// Raw access does not count.
+	raw, err := store.PutRaw([]byte{0x80})
+	require.NoError(t, err)
+	assert.Equal(t, missing, raw)
+	assert.Equal(t, ipld.StoreStats{}, store.Stats())
+}
+
+func TestMemStoreLatency(t *testing.T) {
+	store := ipld.NewMemStore()
+	store.Latency = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := store.Put(ctx, &abi.SectorID{})
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, ipld.StoreStats{}, store.Stats())
+}
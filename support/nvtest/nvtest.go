@@ -0,0 +1,55 @@
+// Package nvtest provides test helpers asserting that behaviour keyed by network version covers every version,
+// so that adding a network version without extending such behaviour fails tests rather than leaving a gap.
+package nvtest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/filecoin-project/go-state-types/network"
+)
+
+// AllVersions returns every network version, from Version0 up to (but excluding) network.VersionCount.
+func AllVersions() []network.Version {
+	versions := make([]network.Version, 0, network.VersionCount)
+	for nv := network.Version0; nv < network.VersionCount; nv++ {
+		versions = append(versions, nv)
+	}
+	return versions
+}
+
+// RequireAllVersionsHandled calls f with every network version, and fails the test (after trying them all)
+// if f returns an error for any of them.
+func RequireAllVersionsHandled(t testing.TB, f func(nv network.Version) error) {
+	t.Helper()
+	failed := false
+	for _, nv := range AllVersions() {
+		if err := f(nv); err != nil {
+			t.Errorf("network version %d is not handled: %s", nv, err)
+			failed = true
+		}
+	}
+	if failed {
+		t.FailNow()
+	}
+}
+
+// RequireAllVersionKeys fails the test if the map m, which must be keyed by network.Version, lacks an entry for
+// any network version.
+func RequireAllVersionKeys(t testing.TB, m interface{}) {
+	t.Helper()
+	v := reflect.ValueOf(m)
+	if v.Kind() != reflect.Map || v.Type().Key() != reflect.TypeOf(network.Version0) {
+		t.Fatalf("expected a map keyed by network.Version, got %T", m)
+	}
+	failed := false
+	for _, nv := range AllVersions() {
+		if !v.MapIndex(reflect.ValueOf(nv)).IsValid() {
+			t.Errorf("network version %d has no entry", nv)
+			failed = true
+		}
+	}
+	if failed {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,64 @@
+package nvtest_test
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/xerrors"
+
+	"github.com/filecoin-project/go-state-types/network"
+	"github.com/filecoin-project/go-state-types/support/nvtest"
+)
+
+func TestAllVersions(t *testing.T) {
+	versions := nvtest.AllVersions()
+	assert.Equal(t, network.Version0, versions[0])
+	assert.Equal(t, network.VersionCount-1, versions[len(versions)-1])
+}
+
+func TestRequireAllVersions(t *testing.T) {
+	m := map[network.Version]bool{}
+	for _, nv := range nvtest.AllVersions() {
+		m[nv] = true
+	}
+	nvtest.RequireAllVersionKeys(t, m)
+	nvtest.RequireAllVersionsHandled(t, func(nv network.Version) error { return nil })
+
+	// A gap fails the test.
+	delete(m, network.Version3)
+	assert.True(t, fails(func(tb testing.TB) { nvtest.RequireAllVersionKeys(tb, m) }))
+	assert.True(t, fails(func(tb testing.TB) { nvtest.RequireAllVersionKeys(tb, map[int]bool{}) }))
+	assert.True(t, fails(func(tb testing.TB) {
+		nvtest.RequireAllVersionsHandled(tb, func(nv network.Version) error {
+			if nv == network.VersionCount-1 {
+				return xerrors.New("unsupported")
+			}
+			return nil
+		})
+	}))
+}
+
+// Reports whether f fails the test passed to it, without failing t.
+func fails(f func(tb testing.TB)) bool {
+	ft := &fakeT{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f(ft)
+	}()
+	<-done
+	return ft.Failed()
+}
+
+// A testing.TB recording failure. FailNow stops the calling goroutine, as it does for a real test.
+type fakeT struct {
+	testing.TB
+	failed bool
+}
+
+func (f *fakeT) Helper()                                   {}
+func (f *fakeT) Errorf(format string, args ...interface{}) { f.failed = true }
+func (f *fakeT) Fatalf(format string, args ...interface{}) { f.failed = true; f.FailNow() }
+func (f *fakeT) FailNow()                                  { f.failed = true; runtime.Goexit() }
+func (f *fakeT) Failed() bool                              { return f.failed }
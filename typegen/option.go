@@ -0,0 +1,121 @@
+//go:build go1.18
+
+// Package typegen provides helpers for the CBOR encoding of actor state types.
+package typegen
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/ipfs/go-cid"
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+var cborNull = []byte{0xf6}
+
+// Option holds an optional value of a state field, encoded as CBOR null when absent.
+// It replaces a nullable pointer field, whose nil checks are easy to miss.
+//
+// The value type must be a cid.Cid or a type whose pointer implements the cbor-gen marshaling interfaces.
+// The zero value is None.
+type Option[T any] struct {
+	value T
+	some  bool
+}
+
+// Some returns an Option holding v.
+func Some[T any](v T) Option[T] {
+	return Option[T]{value: v, some: true}
+}
+
+// None returns an empty Option.
+func None[T any]() Option[T] {
+	return Option[T]{}
+}
+
+// OptionFromPtr returns an Option holding *p, or None if p is nil.
+func OptionFromPtr[T any](p *T) Option[T] {
+	if p == nil {
+		return None[T]()
+	}
+	return Some(*p)
+}
+
+// IsSome returns whether the Option holds a value.
+func (o Option[T]) IsSome() bool {
+	return o.some
+}
+
+// IsNone returns whether the Option is empty.
+func (o Option[T]) IsNone() bool {
+	return !o.some
+}
+
+// Get returns the value and whether it is present.
+func (o Option[T]) Get() (T, bool) {
+	return o.value, o.some
+}
+
+// ValueOr returns the value, or def if the Option is empty.
+func (o Option[T]) ValueOr(def T) T {
+	if !o.some {
+		return def
+	}
+	return o.value
+}
+
+// Ptr returns a pointer to a copy of the value, or nil if the Option is empty.
+func (o Option[T]) Ptr() *T {
+	if !o.some {
+		return nil
+	}
+	v := o.value
+	return &v
+}
+
+func (o *Option[T]) MarshalCBOR(w io.Writer) error {
+	if o == nil || !o.some {
+		_, err := w.Write(cborNull)
+		return err
+	}
+	switch v := any(&o.value).(type) {
+	case *cid.Cid:
+		return cbg.WriteCid(w, *v)
+	case cbg.CBORMarshaler:
+		return v.MarshalCBOR(w)
+	default:
+		return xerrors.Errorf("cannot marshal option of %T", o.value)
+	}
+}
+
+func (o *Option[T]) UnmarshalCBOR(r io.Reader) error {
+	*o = Option[T]{}
+	var first [1]byte
+	if _, err := io.ReadFull(r, first[:]); err != nil {
+		return err
+	}
+	if first[0] == cborNull[0] {
+		return nil
+	}
+	// Put back the first byte for the value's decoder.
+	r = io.MultiReader(bytes.NewReader(first[:]), r)
+
+	var v T
+	switch p := any(&v).(type) {
+	case *cid.Cid:
+		c, err := cbg.ReadCid(r)
+		if err != nil {
+			return xerrors.Errorf("failed to read optional cid: %w", err)
+		}
+		*p = c
+	case cbg.CBORUnmarshaler:
+		if err := p.UnmarshalCBOR(r); err != nil {
+			return err
+		}
+	default:
+		return xerrors.Errorf("cannot unmarshal option of %T", v)
+	}
+	*o = Some(v)
+	return nil
+}
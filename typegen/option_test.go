@@ -0,0 +1,52 @@
+//go:build go1.18
+
+package typegen_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ipfs/go-cid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/typegen"
+)
+
+func TestOptionCBOR(t *testing.T) {
+	var none typegen.Option[abi.SectorID]
+	var buf bytes.Buffer
+	require.NoError(t, none.MarshalCBOR(&buf))
+	assert.Equal(t, []byte{0xf6}, buf.Bytes())
+
+	decoded := typegen.Some(abi.SectorID{Miner: 1})
+	require.NoError(t, decoded.UnmarshalCBOR(&buf))
+	assert.True(t, decoded.IsNone())
+	assert.Nil(t, decoded.Ptr())
+
+	some := typegen.Some(abi.SectorID{Miner: 1000, Number: 3})
+	buf.Reset()
+	require.NoError(t, some.MarshalCBOR(&buf))
+	require.NoError(t, decoded.UnmarshalCBOR(&buf))
+	v, ok := decoded.Get()
+	assert.True(t, ok)
+	assert.Equal(t, abi.SectorID{Miner: 1000, Number: 3}, v)
+	assert.Equal(t, some, typegen.OptionFromPtr(some.Ptr()))
+}
+
+func TestOptionCid(t *testing.T) {
+	c, err := abi.CidBuilder.Sum([]byte("sector key"))
+	require.NoError(t, err)
+	o := typegen.Some(c)
+	var buf bytes.Buffer
+	require.NoError(t, o.MarshalCBOR(&buf))
+
+	var decoded typegen.Option[cid.Cid]
+	require.NoError(t, decoded.UnmarshalCBOR(&buf))
+	assert.Equal(t, c, decoded.ValueOr(cid.Undef))
+
+	unsupported := typegen.Some(1)
+	assert.Error(t, unsupported.MarshalCBOR(&buf))
+	assert.Error(t, unsupported.UnmarshalCBOR(bytes.NewReader([]byte{0x01})))
+}
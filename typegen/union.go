@@ -0,0 +1,147 @@
+//go:build go1.18
+
+package typegen
+
+import (
+	"bytes"
+	"io"
+
+	cbg "github.com/whyrusleeping/cbor-gen"
+	"golang.org/x/xerrors"
+)
+
+// Variant is implemented by pointers to the alternatives of a Union.
+// Each alternative of a union must encode as a distinct CBOR major type, which identifies it when decoding.
+type Variant interface {
+	cbg.CBORMarshaler
+	cbg.CBORUnmarshaler
+	CBORMajorType() byte
+}
+
+// validator is optionally implemented by variants with constraints beyond their encoding.
+type validator interface {
+	Validate() error
+}
+
+// Union holds exactly one of two alternatives, such as a deal label that is either a string or bytes.
+// It is encoded as the held alternative alone, with no tag.
+//
+// Pointers to both alternative types must implement Variant, with different major types.
+// Any Validate method of the held alternative is checked both when encoding and after decoding,
+// so a union that decodes successfully always re-encodes to the same bytes.
+// The zero value holds the zero value of A.
+type Union[A, B any] struct {
+	a   A
+	b   B
+	isB bool
+}
+
+// UnionA returns a Union holding the first alternative.
+func UnionA[A, B any](v A) Union[A, B] {
+	return Union[A, B]{a: v}
+}
+
+// UnionB returns a Union holding the second alternative.
+func UnionB[A, B any](v B) Union[A, B] {
+	return Union[A, B]{b: v, isB: true}
+}
+
+// IsA returns whether the Union holds the first alternative.
+func (u Union[A, B]) IsA() bool {
+	return !u.isB
+}
+
+// IsB returns whether the Union holds the second alternative.
+func (u Union[A, B]) IsB() bool {
+	return u.isB
+}
+
+// A returns the first alternative and whether it is the one held.
+func (u Union[A, B]) A() (A, bool) {
+	return u.a, !u.isB
+}
+
+// B returns the second alternative and whether it is the one held.
+func (u Union[A, B]) B() (B, bool) {
+	return u.b, u.isB
+}
+
+// Validate checks the held alternative, if it has a Validate method.
+func (u Union[A, B]) Validate() error {
+	if u.isB {
+		return validateVariant(&u.b)
+	}
+	return validateVariant(&u.a)
+}
+
+func (u *Union[A, B]) variants() (Variant, Variant, error) {
+	va, ok := any(&u.a).(Variant)
+	if !ok {
+		return nil, nil, xerrors.Errorf("union alternative %T is not a variant", u.a)
+	}
+	vb, ok := any(&u.b).(Variant)
+	if !ok {
+		return nil, nil, xerrors.Errorf("union alternative %T is not a variant", u.b)
+	}
+	if va.CBORMajorType() == vb.CBORMajorType() {
+		return nil, nil, xerrors.Errorf("union alternatives %T and %T share major type %d", u.a, u.b, va.CBORMajorType())
+	}
+	return va, vb, nil
+}
+
+func (u *Union[A, B]) MarshalCBOR(w io.Writer) error {
+	if u == nil {
+		return xerrors.Errorf("cannot marshal nil union")
+	}
+	va, vb, err := u.variants()
+	if err != nil {
+		return err
+	}
+	v := va
+	if u.isB {
+		v = vb
+	}
+	if err := validateVariant(v); err != nil {
+		return xerrors.Errorf("invalid union value: %w", err)
+	}
+	return v.MarshalCBOR(w)
+}
+
+func (u *Union[A, B]) UnmarshalCBOR(r io.Reader) error {
+	*u = Union[A, B]{}
+	va, vb, err := u.variants()
+	if err != nil {
+		return err
+	}
+	var first [1]byte
+	if _, err := io.ReadFull(r, first[:]); err != nil {
+		return err
+	}
+	// Put back the first byte for the alternative's decoder.
+	r = io.MultiReader(bytes.NewReader(first[:]), r)
+
+	var v Variant
+	switch maj := first[0] >> 5; maj {
+	case va.CBORMajorType():
+		v = va
+	case vb.CBORMajorType():
+		v = vb
+		u.isB = true
+	default:
+		return xerrors.Errorf("union of %T and %T cannot hold major type %d", u.a, u.b, maj)
+	}
+	if err := v.UnmarshalCBOR(r); err != nil {
+		return err
+	}
+	if err := validateVariant(v); err != nil {
+		return xerrors.Errorf("invalid union value: %w", err)
+	}
+	return nil
+}
+
+func validateVariant(v any) error {
+	if val, ok := v.(validator); ok {
+		return val.Validate()
+	}
+	return nil
+}
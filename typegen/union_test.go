@@ -0,0 +1,71 @@
+//go:build go1.18
+
+package typegen_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/filecoin-project/go-state-types/abi"
+	"github.com/filecoin-project/go-state-types/typegen"
+)
+
+func TestUnionRoundTrip(t *testing.T) {
+	for _, l := range []abi.DealLabel{
+		{},
+		typegen.UnionA[abi.Label, abi.LabelBytes]("hello"),
+		typegen.UnionB[abi.Label, abi.LabelBytes](abi.LabelBytes{0xff, 0x00}),
+	} {
+		var buf bytes.Buffer
+		require.NoError(t, l.MarshalCBOR(&buf))
+		enc := append([]byte(nil), buf.Bytes()...)
+
+		var out abi.DealLabel
+		require.NoError(t, out.UnmarshalCBOR(&buf))
+		assert.Equal(t, l.IsB(), out.IsB())
+
+		var again bytes.Buffer
+		require.NoError(t, out.MarshalCBOR(&again))
+		assert.Equal(t, enc, again.Bytes())
+	}
+}
+
+func TestUnionDecodesByMajorType(t *testing.T) {
+	var l abi.DealLabel
+	require.NoError(t, l.UnmarshalCBOR(bytes.NewReader([]byte{0x61, 'a'})))
+	s, ok := l.A()
+	require.True(t, ok)
+	assert.Equal(t, abi.Label("a"), s)
+
+	require.NoError(t, l.UnmarshalCBOR(bytes.NewReader([]byte{0x41, 0xff})))
+	b, ok := l.B()
+	require.True(t, ok)
+	assert.Equal(t, abi.LabelBytes{0xff}, b)
+
+	// An unsigned integer is neither alternative.
+	assert.Error(t, l.UnmarshalCBOR(bytes.NewReader([]byte{0x01})))
+}
+
+func TestUnionValidates(t *testing.T) {
+	// Invalid UTF-8 in a text string is rejected on decode.
+	var l abi.DealLabel
+	assert.Error(t, l.UnmarshalCBOR(bytes.NewReader([]byte{0x61, 0xff})))
+
+	_, err := abi.NewStringDealLabel(strings.Repeat("x", abi.MaxLabelLength+1))
+	assert.Error(t, err)
+	_, err = abi.NewBytesDealLabel(make([]byte, abi.MaxLabelLength+1))
+	assert.Error(t, err)
+
+	long := typegen.UnionB[abi.Label, abi.LabelBytes](make(abi.LabelBytes, abi.MaxLabelLength+1))
+	assert.Error(t, long.MarshalCBOR(&bytes.Buffer{}))
+}
+
+func TestUnionRejectsAmbiguousAlternatives(t *testing.T) {
+	u := typegen.UnionA[abi.Label, abi.Label]("a")
+	assert.Error(t, u.MarshalCBOR(&bytes.Buffer{}))
+	assert.Error(t, u.UnmarshalCBOR(bytes.NewReader([]byte{0x61, 'a'})))
+}